@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"time"
+)
+
+type LogFormat int
+
+const (
+	LogFormatText LogFormat = iota
+	LogFormatJSON
+)
+
+type AccessLogConfig struct {
+	// Disabled mematikan access log sepenuhnya.
+	Disabled bool
+	// Skip berisi route pattern atau path yang tidak dicatat, misalnya "/health".
+	Skip []string
+	// SampleRate adalah porsi request sukses yang dicatat (0 < rate < 1).
+	// Nilai 0 atau >= 1 berarti semua dicatat. Request gagal selalu dicatat.
+	SampleRate float64
+	Format     LogFormat
+	// Output untuk format JSON; default os.Stderr.
+	Output io.Writer
+}
+
+type accessLogger struct {
+	cfg  AccessLogConfig
+	skip map[string]struct{}
+	json *log.Logger
+}
+
+func newAccessLogger(cfg AccessLogConfig) *accessLogger {
+	l := &accessLogger{cfg: cfg, skip: make(map[string]struct{}, len(cfg.Skip))}
+	for _, p := range cfg.Skip {
+		l.skip[p] = struct{}{}
+	}
+
+	out := cfg.Output
+	if out == nil {
+		out = os.Stderr
+	}
+	l.json = log.New(out, "", 0)
+	return l
+}
+
+type accessRecord struct {
+	Time     string `json:"time"`
+	Method   string `json:"method"`
+	Status   int    `json:"status"`
+	Path     string `json:"path"`
+	Route    string `json:"route,omitempty"`
+	Remote   string `json:"remote"`
+	Message  string `json:"message"`
+	Duration int64  `json:"duration_ms"`
+}
+
+func (l *accessLogger) log(ctx *Context, message string, failed bool, elapsed time.Duration) {
+	if l.cfg.Disabled {
+		return
+	}
+
+	r := ctx.Request()
+	if _, ok := l.skip[ctx.route]; ok {
+		return
+	}
+	if _, ok := l.skip[r.URL.Path]; ok {
+		return
+	}
+
+	failed = failed || ctx.httpStatus >= 400
+	if !failed && l.cfg.SampleRate > 0 && l.cfg.SampleRate < 1 && rand.Float64() >= l.cfg.SampleRate {
+		return
+	}
+
+	if l.cfg.Format == LogFormatJSON {
+		line, _ := json.Marshal(accessRecord{
+			Time:     time.Now().Format(time.RFC3339Nano),
+			Method:   r.Method,
+			Status:   ctx.httpStatus,
+			Path:     r.URL.Path,
+			Route:    ctx.route,
+			Remote:   r.RemoteAddr,
+			Message:  message,
+			Duration: elapsed.Milliseconds(),
+		})
+		l.json.Println(string(line))
+		return
+	}
+
+	log.Printf("%s [%d] %s %s (%s) %d milliseconds", r.Method,
+		ctx.httpStatus,
+		r.URL.Path,
+		r.RemoteAddr,
+		message, elapsed.Milliseconds())
+}
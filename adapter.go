@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+type adapterCallKey struct{}
+
+type adapterCall struct {
+	ctx *Context
+	err error
+}
+
+// ContextFromRequest mengembalikan Context netpath yang melekat pada request,
+// misalnya dari dalam http.Handler yang dipasang lewat Mount.
+func ContextFromRequest(r *http.Request) (*Context, bool) {
+	return FromContext(r.Context())
+}
+
+func withContext(r *http.Request, ctx *Context) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), contextKey{}, ctx))
+}
+
+// WrapHTTPMiddleware mengubah middleware gaya net/http menjadi MiddlewareFunc.
+// Writer dan request yang diteruskan middleware ke handler berikutnya dipakai
+// oleh Context selama sisa chain.
+func WrapHTTPMiddleware(mw func(http.Handler) http.Handler) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			call := r.Context().Value(adapterCallKey{}).(*adapterCall)
+			call.ctx.writer = w
+			call.ctx.request = r
+			call.err = next(call.ctx)
+		}))
+
+		return func(ctx *Context) error {
+			w, r := ctx.writer, ctx.request
+			defer func() {
+				ctx.writer, ctx.request = w, r
+			}()
+
+			call := &adapterCall{ctx: ctx}
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), adapterCallKey{}, call)))
+			return call.err
+		}
+	}
+}
+
+// HTTPMiddleware mengubah MiddlewareFunc menjadi middleware gaya net/http.
+// Jika request sudah membawa Context netpath, Context tersebut dipakai ulang;
+// jika tidak, Context dibuat seperti di ServeHTTP sehingga error, bahasa, dan
+// hook app tetap berlaku. Error yang dikembalikan chain ditulis dengan ctx.Error
+// bila response belum ditulis.
+func (app *App) HTTPMiddleware(mw MiddlewareFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		final := mw(func(ctx *Context) error {
+			next.ServeHTTP(ctx.writer, ctx.request)
+			return nil
+		})
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, ok := ContextFromRequest(r)
+			if ok {
+				ctx.writer = w
+				ctx.request = r
+			} else {
+				ctx = app.newContext(w, r)
+			}
+
+			if err := final(ctx); err != nil {
+				if !ctx.Written() {
+					ctx.Error(err)
+				}
+				ctx.app.reportError(ctx, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPMiddlewareOutsideRouter(t *testing.T) {
+	app := New()
+	var reported []error
+	app.OnError(func(ctx *Context, err error) {
+		reported = append(reported, err)
+	})
+
+	auth := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			ctx.Writer().Header().Set("X-Seen-Request-ID", ctx.RequestID())
+			if ctx.Request().Header.Get("Authorization") == "" {
+				return ctx.Unauthorized(errors.New("missing token"))
+			}
+			return next(ctx)
+		}
+	}
+	failing := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			return errors.New("upstream down")
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/private", app.HTTPMiddleware(auth)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, ok := ContextFromRequest(r)
+		if !ok || ctx.app != app {
+			t.Error("handler did not receive the app Context")
+		}
+		w.Write([]byte("secret"))
+	})))
+	mux.Handle("/broken", app.HTTPMiddleware(failing)(http.NotFoundHandler()))
+
+	r := httptest.NewRequest(http.MethodGet, "/private", nil)
+	r.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized || w.Header().Get("X-Seen-Request-ID") != "req-1" {
+		t.Fatalf("status %d request id %q", w.Code, w.Header().Get("X-Seen-Request-ID"))
+	}
+	if !strings.Contains(w.Body.String(), `"code":401`) {
+		t.Fatalf("body %s, want the error envelope", w.Body)
+	}
+
+	r.Header.Set("Authorization", "Bearer x")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "secret" {
+		t.Fatalf("authorized: %d %s", w.Code, w.Body)
+	}
+
+	reported = nil
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/broken", nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("returned error: status %d, want 500", w.Code)
+	}
+	if len(reported) != 1 || reported[0].Error() != "upstream down" {
+		t.Fatalf("error hooks saw %v", reported)
+	}
+}
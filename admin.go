@@ -0,0 +1,288 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	tools "github.com/godev90/netpath/database"
+	logging "github.com/godev90/netpath/helpers/logging"
+	"github.com/godev90/validator/faults"
+)
+
+var limiters struct {
+	mu   sync.Mutex
+	list []*windowLimiter
+}
+
+func registerLimiter(l *windowLimiter) {
+	limiters.mu.Lock()
+	defer limiters.mu.Unlock()
+
+	limiters.list = append(limiters.list, l)
+}
+
+// LimiterBucket adalah kuota satu client pada satu limiter WithRateLimit.
+type LimiterBucket struct {
+	Limiter   string    `json:"limiter"`
+	Key       string    `json:"key"`
+	Count     int       `json:"count"`
+	Remaining int       `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// LimiterBuckets mengembalikan semua bucket rate limit yang masih aktif.
+func LimiterBuckets() []LimiterBucket {
+	limiters.mu.Lock()
+	list := append([]*windowLimiter(nil), limiters.list...)
+	limiters.mu.Unlock()
+
+	now := time.Now()
+	var buckets []LimiterBucket
+	for i, l := range list {
+		l.mu.Lock()
+		name := l.name
+		if name == "" {
+			name = fmt.Sprintf("#%d %d/%s", i, l.limit, l.per)
+		}
+		for key, w := range l.windows {
+			if now.Sub(w.start) >= l.per {
+				continue
+			}
+			buckets = append(buckets, LimiterBucket{
+				Limiter:   name,
+				Key:       key,
+				Count:     w.count,
+				Remaining: max(l.limit-w.count, 0),
+				ResetsAt:  w.start.Add(l.per),
+			})
+		}
+		l.mu.Unlock()
+	}
+	return buckets
+}
+
+// PurgeLimiterBuckets menghapus bucket milik key, atau semua bucket jika key kosong.
+// Mengembalikan jumlah bucket yang dihapus.
+func PurgeLimiterBuckets(key string) int {
+	limiters.mu.Lock()
+	list := append([]*windowLimiter(nil), limiters.list...)
+	limiters.mu.Unlock()
+
+	n := 0
+	for _, l := range list {
+		l.mu.Lock()
+		for k := range l.windows {
+			if key == "" || k == key {
+				delete(l.windows, k)
+				n++
+			}
+		}
+		l.mu.Unlock()
+	}
+	return n
+}
+
+var idempotencyEntries struct {
+	mu      sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	IdempotencyEntry
+	store cache.Cache
+}
+
+// IdempotencyEntry adalah satu key WithIdempotency yang tersimpan di Store.
+type IdempotencyEntry struct {
+	Key       string    `json:"key"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Pending   bool      `json:"pending"`
+	Status    int       `json:"status,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func trackIdempotency(storeKey string, store cache.Cache, e IdempotencyEntry) {
+	idempotencyEntries.mu.Lock()
+	defer idempotencyEntries.mu.Unlock()
+
+	if idempotencyEntries.entries == nil {
+		idempotencyEntries.entries = make(map[string]*idempotencyEntry)
+	}
+	idempotencyEntries.entries[storeKey] = &idempotencyEntry{IdempotencyEntry: e, store: store}
+}
+
+func untrackIdempotency(storeKey string) {
+	idempotencyEntries.mu.Lock()
+	defer idempotencyEntries.mu.Unlock()
+
+	delete(idempotencyEntries.entries, storeKey)
+}
+
+// IdempotencyEntries mengembalikan key WithIdempotency yang belum kedaluwarsa.
+// Store hanya punya Get/Set/Delete tanpa daftar key, jadi yang terlihat hanya
+// key yang ditulis instance ini.
+func IdempotencyEntries() []IdempotencyEntry {
+	idempotencyEntries.mu.Lock()
+	defer idempotencyEntries.mu.Unlock()
+
+	now := time.Now()
+	var list []IdempotencyEntry
+	for storeKey, e := range idempotencyEntries.entries {
+		if !now.Before(e.ExpiresAt) {
+			delete(idempotencyEntries.entries, storeKey)
+			continue
+		}
+		list = append(list, e.IdempotencyEntry)
+	}
+	return list
+}
+
+// PurgeIdempotencyEntries menghapus dari Store key idempotency milik key, atau
+// semua key yang ditulis instance ini jika key kosong. Request berikutnya
+// dengan key tersebut dijalankan ulang. Mengembalikan jumlah key yang dihapus.
+func PurgeIdempotencyEntries(ctx context.Context, key string) (int, error) {
+	idempotencyEntries.mu.Lock()
+	purge := make(map[string]*idempotencyEntry)
+	for storeKey, e := range idempotencyEntries.entries {
+		if key == "" || e.Key == key {
+			purge[storeKey] = e
+		}
+	}
+	idempotencyEntries.mu.Unlock()
+
+	n := 0
+	for storeKey, e := range purge {
+		if err := e.store.Delete(ctx, storeKey); err != nil {
+			return n, err
+		}
+		untrackIdempotency(storeKey)
+		n++
+	}
+	return n, nil
+}
+
+// Admin mendaftarkan route introspeksi di bawah prefix:
+//
+//	GET    {prefix}/limits              daftar bucket rate limit
+//	DELETE {prefix}/limits?key=..       hapus bucket (semua jika key kosong)
+//	GET    {prefix}/idempotency         daftar key WithIdempotency
+//	DELETE {prefix}/idempotency?key=..  hapus key (semua jika key kosong)
+//
+// Route ini membuka data operasional, jadi selalu pasang middleware auth lewat mws.
+func (r *Router) Admin(prefix string, mws ...MiddlewareFunc) *Router {
+	g := r.Group(prefix, mws...)
+
+	g.GET("/limits", func(ctx *Context) error {
+		return ctx.Success(LimiterBuckets())
+	})
+	g.Handle(http.MethodDelete, "/limits", func(ctx *Context) error {
+		return ctx.Success(map[string]int{"purged": PurgeLimiterBuckets(ctx.Query("key"))})
+	})
+
+	g.GET("/idempotency", func(ctx *Context) error {
+		return ctx.Success(IdempotencyEntries())
+	})
+	g.Handle(http.MethodDelete, "/idempotency", func(ctx *Context) error {
+		n, err := PurgeIdempotencyEntries(ctx.Request().Context(), ctx.Query("key"))
+		if err != nil {
+			return ctx.Unavailable(err)
+		}
+		return ctx.Success(map[string]int{"purged": n})
+	})
+
+	return g
+}
+
+// MountAdmin mendaftarkan API admin di prefix pada router utama. Selain
+// route dari Router.Admin, tersedia:
+//
+//	GET  {prefix}/routes       tabel route
+//	GET  {prefix}/pools        statistik pool database dan Redis
+//	GET  {prefix}/maintenance  status mode maintenance
+//	PUT  {prefix}/maintenance  {"enabled": true|false}
+//	GET  {prefix}/log-level    level log saat ini
+//	PUT  {prefix}/log-level    {"level": "debug"|"info"|"warn"|"error"}
+//	POST {prefix}/shutdown     graceful shutdown server dari Run
+//	GET  {prefix}/diagnostics  daftar capture (dengan WithDiagnostics)
+//	POST {prefix}/diagnostics  capture goroutine, heap, dan mutex
+//
+// Route admin tetap bisa diakses selama maintenance. Selalu pasang
+// middleware auth lewat mws.
+func (app *App) MountAdmin(prefix string, mws ...MiddlewareFunc) *Router {
+	app.adminPrefix = strings.TrimSuffix(prefix, "/")
+	g := app.router.Admin(prefix, mws...)
+
+	g.GET("/routes", func(ctx *Context) error {
+		return ctx.Success(app.Routes())
+	})
+
+	g.GET("/pools", func(ctx *Context) error {
+		return ctx.Success(map[string]any{
+			"database": tools.Pool().Stats(),
+			"redis":    cache.Pool().Stats(),
+		})
+	})
+
+	g.GET("/maintenance", func(ctx *Context) error {
+		return ctx.Success(map[string]bool{"enabled": app.Maintenance()})
+	})
+	g.Handle(http.MethodPut, "/maintenance", func(ctx *Context) error {
+		var body struct {
+			Enabled bool `json:"enabled"`
+		}
+		raw, err := ctx.BodyBytes()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return ctx.BadInput(err)
+		}
+		app.SetMaintenance(body.Enabled)
+		return ctx.Success(map[string]bool{"enabled": body.Enabled})
+	})
+
+	g.GET("/log-level", func(ctx *Context) error {
+		return ctx.Success(map[string]string{"level": logging.GetLevel().String()})
+	})
+	g.Handle(http.MethodPut, "/log-level", func(ctx *Context) error {
+		var body struct {
+			Level string `json:"level"`
+		}
+		raw, err := ctx.BodyBytes()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			return ctx.BadInput(err)
+		}
+		level, err := logging.ParseLevel(body.Level)
+		if err != nil {
+			return ctx.BadInput(faults.Errors{"level": faults.ErrMustBeOneOf})
+		}
+		logging.SetLevel(level)
+		return ctx.Success(map[string]string{"level": level.String()})
+	})
+
+	g.POST("/shutdown", func(ctx *Context) error {
+		go func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := app.Shutdown(shutdownCtx); err != nil {
+				log.Printf("[admin] shutdown: %v", err)
+			}
+		}()
+		return ctx.Success(map[string]string{"status": "shutting down"})
+	})
+
+	app.mountDiagnostics(g)
+
+	return g
+}
@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+)
+
+type AlertKind string
+
+const (
+	AlertErrorRate AlertKind = "error_rate"
+	AlertPanics    AlertKind = "panics"
+)
+
+// Alert dikirim ke AlertHook ketika ambang batas sebuah route terlampaui.
+type Alert struct {
+	Kind   AlertKind
+	Route  string
+	Count  int64 // jumlah error atau panic dalam window
+	Total  int64 // jumlah request dalam window
+	Rate   float64
+	Window time.Duration
+	At     time.Time
+}
+
+type AlertHook func(Alert)
+
+type AlertConfig struct {
+	Window time.Duration // default 1 menit
+	// ErrorRate adalah rasio response 5xx yang memicu alert. Default 0.5.
+	ErrorRate float64
+	// MinRequests mencegah alert dari sampel kecil. Default 20.
+	MinRequests int64
+	// PanicCount adalah jumlah panic dalam window yang memicu alert. Default 1.
+	PanicCount int64
+	// Cooldown mencegah alert yang sama dikirim berulang. Default 5 menit.
+	Cooldown time.Duration
+	// RedisAlias membuat counter dibagi antar instance; kosong berarti in-process.
+	RedisAlias string
+}
+
+// alertCounter menghitung kejadian per key dengan sliding window dua bucket.
+type alertCounter interface {
+	incr(key string, now time.Time) int64
+}
+
+type alerter struct {
+	cfg     AlertConfig
+	hook    AlertHook
+	counter alertCounter
+
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+// OnAlert memanggil h ketika error rate atau jumlah panic sebuah route
+// melewati ambang dalam window, misalnya untuk notifikasi Slack/PagerDuty.
+func (app *App) OnAlert(cfg AlertConfig, h AlertHook) {
+	if cfg.Window == 0 {
+		cfg.Window = time.Minute
+	}
+	if cfg.ErrorRate == 0 {
+		cfg.ErrorRate = 0.5
+	}
+	if cfg.MinRequests == 0 {
+		cfg.MinRequests = 20
+	}
+	if cfg.PanicCount == 0 {
+		cfg.PanicCount = 1
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = 5 * time.Minute
+	}
+
+	a := &alerter{cfg: cfg, hook: h, sent: make(map[string]time.Time)}
+	if cfg.RedisAlias != "" {
+		a.counter = &redisCounter{alias: cfg.RedisAlias, window: cfg.Window}
+	} else {
+		a.counter = &memoryCounter{window: cfg.Window, buckets: make(map[string]*slidingBucket)}
+	}
+
+	app.AfterResponse(a.afterResponse)
+	app.OnPanic(a.panic)
+}
+
+func alertRoute(ctx *Context) string {
+	if ctx.route == "" {
+		return "unmatched"
+	}
+	return ctx.route
+}
+
+func (a *alerter) afterResponse(ctx *Context, status int, size int64) {
+	now := time.Now()
+	route := alertRoute(ctx)
+
+	total := a.counter.incr(route+"|total", now)
+	if status < 500 {
+		return
+	}
+	errs := a.counter.incr(route+"|error", now)
+
+	if total < a.cfg.MinRequests {
+		return
+	}
+	rate := float64(errs) / float64(total)
+	if rate >= a.cfg.ErrorRate {
+		a.fire(Alert{Kind: AlertErrorRate, Route: route, Count: errs, Total: total, Rate: rate, Window: a.cfg.Window, At: now})
+	}
+}
+
+func (a *alerter) panic(ctx *Context, recovered any, stack []byte) {
+	now := time.Now()
+	route := alertRoute(ctx)
+
+	panics := a.counter.incr(route+"|panic", now)
+	if panics >= a.cfg.PanicCount {
+		a.fire(Alert{Kind: AlertPanics, Route: route, Count: panics, Window: a.cfg.Window, At: now})
+	}
+}
+
+func (a *alerter) fire(alert Alert) {
+	key := string(alert.Kind) + "|" + alert.Route
+
+	a.mu.Lock()
+	if last, ok := a.sent[key]; ok && alert.At.Sub(last) < a.cfg.Cooldown {
+		a.mu.Unlock()
+		return
+	}
+	a.sent[key] = alert.At
+	a.mu.Unlock()
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("[PANIC ALERT HOOK] %v", r)
+			}
+		}()
+		a.hook(alert)
+	}()
+}
+
+type slidingBucket struct {
+	start    time.Time
+	current  int64
+	previous int64
+}
+
+type memoryCounter struct {
+	mu      sync.Mutex
+	window  time.Duration
+	buckets map[string]*slidingBucket
+}
+
+// incr memperkirakan jumlah dalam window terakhir dari bucket saat ini
+// ditambah porsi bucket sebelumnya yang masih masuk window.
+func (c *memoryCounter) incr(key string, now time.Time) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	start := now.Truncate(c.window)
+	b, ok := c.buckets[key]
+	if !ok {
+		b = &slidingBucket{start: start}
+		c.buckets[key] = b
+	}
+
+	switch elapsed := start.Sub(b.start); {
+	case elapsed >= 2*c.window:
+		b.previous, b.current = 0, 0
+	case elapsed >= c.window:
+		b.previous, b.current = b.current, 0
+	}
+	b.start = start
+	b.current++
+
+	return slidingEstimate(b.previous, b.current, now.Sub(start), c.window)
+}
+
+func slidingEstimate(previous, current int64, into, window time.Duration) int64 {
+	weight := 1 - float64(into)/float64(window)
+	return current + int64(float64(previous)*weight)
+}
+
+type redisCounter struct {
+	alias  string
+	window time.Duration
+}
+
+func (c *redisCounter) incr(key string, now time.Time) int64 {
+	client, err := cache.Pool().Get(c.alias)
+	if err != nil {
+		return 0
+	}
+
+	ctx := context.Background()
+	start := now.Truncate(c.window)
+	slot := start.UnixNano() / int64(c.window)
+	currentKey := fmt.Sprintf("alert:%s:%d", key, slot)
+	previousKey := fmt.Sprintf("alert:%s:%d", key, slot-1)
+
+	pipe := client.TxPipeline()
+	cur := pipe.Incr(ctx, currentKey)
+	pipe.Expire(ctx, currentKey, 2*c.window)
+	prev := pipe.Get(ctx, previousKey)
+	pipe.Exec(ctx)
+
+	previous, _ := prev.Int64()
+	return slidingEstimate(previous, cur.Val(), now.Sub(start), c.window)
+}
@@ -1,17 +1,21 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
-	"log"
+	"fmt"
+	"html/template"
 	"mime/multipart"
+	"net"
 	"net/http"
 	"os"
 	"reflect"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/godev90/validator"
 	"github.com/godev90/validator/faults"
 )
 
@@ -20,26 +24,99 @@ type HandlerFunc func(*Context) error
 type MiddlewareFunc func(HandlerFunc) HandlerFunc
 
 type routeEntry struct {
+	pattern    string
+	segments   []string
 	handler    HandlerFunc
 	middleware []MiddlewareFunc
+
+	// chain adalah handler yang sudah dibungkus middleware route dan group;
+	// compiled menambahkan middleware global App di atasnya.
+	chain    HandlerFunc
+	compiled atomic.Pointer[compiledChain]
+
+	retrySafety RetrySafety
+
+	// source adalah file:line tempat route didaftarkan.
+	source string
+
+	// request dan response diisi oleh Handle untuk handler bertipe.
+	request  reflect.Type
+	response reflect.Type
 }
 
 type Router struct {
 	prefix     string
-	routes     map[string]map[string]routeEntry
+	routes     map[string][]*routeEntry
 	middleware []MiddlewareFunc
 }
 
 type App struct {
 	router *Router
+	hosts  []*hostRouter
 	mw     []MiddlewareFunc
+	mwGen  atomic.Uint64
+
+	trailingSlash   TrailingSlashPolicy
+	collapseSlashes bool
+	cleanPath       bool
+	foldCase        bool
+	canonicalCase   bool
+	noAutoOptions   bool
+	optionsHandler  HandlerFunc
+	routeCache      *routeCache
+	accessLog       *accessLogger
+
+	errorHooks []ErrorHook
+	panicHooks []PanicHook
+	afterHooks []AfterResponseHook
+
+	grpc http.Handler
+
+	serverMu sync.Mutex
+	server   *http.Server
+
+	maintenance atomic.Bool
+	adminPrefix string
+
+	warmups []WarmupFunc
+	ready   atomic.Bool
+
+	debug bool
+
+	drainCfg DrainConfig
+	drains   drainRegistry
+
+	container *container
+
+	modules  []Module
+	moduleMu sync.Mutex
+	started  int
+
+	versions []apiVersion
+
+	cookiePolicy *CookiePolicy
+
+	maskRoles func(Session) []string
+
+	budgets *Budgets
+	runtime runtimeState
+
+	diagnostics *diagnostics
+
+	trustedProxies []*net.IPNet
+
+	templates *template.Template
 }
 
-func New() *App {
+func New(opts ...Option) *App {
 	r := &Router{
-		routes: make(map[string]map[string]routeEntry),
+		routes: make(map[string][]*routeEntry),
+	}
+	app := &App{router: r, accessLog: newAccessLogger(AccessLogConfig{})}
+	for _, opt := range opts {
+		opt(app)
 	}
-	return &App{router: r}
+	return app
 }
 
 func (app *App) Route() *Router {
@@ -47,53 +124,161 @@ func (app *App) Route() *Router {
 }
 
 func (app *App) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	ctx := &Context{writer: w, request: r}
+	if app.grpc != nil && isGRPC(r) {
+		app.grpc.ServeHTTP(w, r)
+		return
+	}
+
+	ctx := app.newContext(w, r)
 	method := r.Method
-	path := r.URL.Path
+	path := app.normalizePath(r.URL.Path)
+	start := ctx.startedAt
+
+	router := app.routerFor(r.Host)
+	entry, params := app.find(router, method, path)
+	if entry == nil && app.trailingSlash != TrailingSlashStrict && path != "/" {
+		alt := toggleTrailingSlash(path)
+		if e, p := app.find(router, method, alt); e != nil {
+			if app.trailingSlash == TrailingSlashRedirect {
+				redirectPath(w, r, alt)
+				return
+			}
+			entry, params = e, p
+		}
+	}
 
-	start := time.Now()
+	var head *headWriter
+	if entry == nil && method == http.MethodHead {
+		if e, p := app.find(router, http.MethodGet, path); e != nil {
+			entry, params = e, p
+			head = &headWriter{ResponseWriter: w, status: http.StatusOK}
+		}
+	}
 
-	var entry routeEntry
-	var found bool
-	for route, e := range app.router.routes[method] {
-		if params, ok := matchRoute(route, path); ok {
-			ctx.Params = params
-			entry = e
-			found = true
-			break
+	if head != nil {
+		ctx.rw.ResponseWriter = head
+	}
+
+	if app.inMaintenance(ctx, path) {
+		return
+	}
+
+	var final HandlerFunc
+	if entry != nil {
+		if app.foldCase && app.canonicalCase {
+			if canonical := canonicalPath(entry.segments, path); canonical != path {
+				redirectPath(w, r, canonical)
+				return
+			}
+		}
+		ctx.Params = params
+		ctx.route = entry.pattern
+		ctx.retrySafety = entry.retrySafety
+		ctx.requestType = entry.request
+
+		final = app.compiled(entry)
+	} else if method == http.MethodOptions && !app.noAutoOptions {
+		if allow := router.allowedMethods(path, app.foldCase); len(allow) > 0 {
+			final = app.wrapGlobal(app.autoOptions(allow))
+		}
+	} else if method == http.MethodGet || method == http.MethodHead {
+		if index := app.versionIndex(path); index != nil {
+			final = app.wrapGlobal(index)
 		}
 	}
 
-	if !found {
+	if final == nil {
 		http.NotFound(w, r)
 		return
 	}
 
-	final := entry.handler
-
-	for i := len(entry.middleware) - 1; i >= 0; i-- {
-		final = entry.middleware[i](final)
+	// saat panic, hook AfterResponse tetap berjalan setelah panic dilaporkan;
+	// response yang belum ditulis dicatat 500
+	completed := false
+	defer func() {
+		if !completed {
+			if !ctx.rw.written {
+				ctx.httpStatus = http.StatusInternalServerError
+			}
+			app.runAfterHooks(ctx)
+		}
+	}()
+	defer app.reportPanic(ctx)
+	if head != nil {
+		// saat panic, header yang sudah ditulis handler tetap dikirim
+		// seperti pada GET; finish di jalur normal membuat ini no-op
+		defer func() {
+			if head.wrote {
+				head.finish()
+			}
+		}()
 	}
-	// Apply global app middleware
-	for i := len(app.mw) - 1; i >= 0; i-- {
-		final = app.mw[i](final)
+	if app.budgets != nil {
+		ctx.budget = newBudgetTracker(app.budgets)
+		defer ctx.budget.release()
 	}
 
 	var message = "success"
-	if err := final(ctx); err != nil {
+	err := final(ctx)
+	if err != nil {
 		message = err.Error()
+		if !ctx.Written() {
+			ctx.Error(err)
+		}
+	}
+	if ctx.httpStatus == 0 && ctx.rw.written {
+		ctx.httpStatus = ctx.rw.status
+	}
+	if head != nil {
+		head.finish()
 	}
+	if err != nil {
+		app.reportError(ctx, err)
+	}
+	completed = true
+	app.runAfterHooks(ctx)
 
-	stop := time.Now()
-	log.Printf("%s [%d] %s %s (%s) %d milliseconds", ctx.Request().Method,
-		ctx.httpStatus,
-		ctx.Request().URL.Path,
-		ctx.Request().RemoteAddr,
-		message, stop.Sub(start).Milliseconds())
+	app.accessLog.log(ctx, message, err != nil, time.Since(start))
+}
+
+// newContext membuat Context untuk satu request. ServeHTTP dan
+// App.HTTPMiddleware memakainya supaya Context di luar router punya state
+// yang sama.
+func (app *App) newContext(w http.ResponseWriter, r *http.Request) *Context {
+	ctx := &Context{app: app, startedAt: time.Now()}
+	if parent := batchParent(r.Context()); parent != nil {
+		ctx.session = parent.session
+	}
+	ctx.request = withContext(r, ctx)
+	ctx.rw = &responseWriter{ResponseWriter: w}
+	ctx.writer = ctx.rw
+	return ctx
+}
+
+func (app *App) normalizePath(p string) string {
+	if app.cleanPath {
+		return cleanPath(p)
+	}
+	if app.collapseSlashes {
+		return collapseSlashes(p)
+	}
+	return p
+}
+
+func redirectPath(w http.ResponseWriter, r *http.Request, target string) {
+	code := http.StatusPermanentRedirect
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		code = http.StatusMovedPermanently
+	}
+	if r.URL.RawQuery != "" {
+		target += "?" + r.URL.RawQuery
+	}
+	http.Redirect(w, r, target, code)
 }
 
 func (app *App) Use(mw ...MiddlewareFunc) {
 	app.mw = append(app.mw, mw...)
+	app.mwGen.Add(1)
 }
 
 func (r *Router) Group(prefix string, mws ...MiddlewareFunc) *Router {
@@ -104,47 +289,177 @@ func (r *Router) Group(prefix string, mws ...MiddlewareFunc) *Router {
 	}
 }
 
-func (r *Router) handle(method, path string, h HandlerFunc, mws ...MiddlewareFunc) {
-	if r.routes[method] == nil {
-		r.routes[method] = make(map[string]routeEntry)
+func (r *Router) handle(method, path string, h HandlerFunc, mws ...MiddlewareFunc) *routeEntry {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if segmentKind(seg) == segmentWildcard && i != len(segments)-1 {
+			panic(fmt.Sprintf("netpath: wildcard must be the last segment in %s %s", method, path))
+		}
+	}
+
+	for _, e := range r.routes[method] {
+		if conflicts(e.segments, segments) {
+			panic(fmt.Sprintf("netpath: route %s %s conflicts with %s %s", method, path, method, e.pattern))
+		}
 	}
+
 	// Simpan route dengan middleware chain (router group + route)
 	allMiddleware := append([]MiddlewareFunc{}, r.middleware...)
 	allMiddleware = append(allMiddleware, mws...)
-	r.routes[method][path] = routeEntry{
-		handler:    h,
-		middleware: allMiddleware,
+
+	chain := h
+	for i := len(allMiddleware) - 1; i >= 0; i-- {
+		chain = allMiddleware[i](chain)
 	}
+
+	entry := &routeEntry{
+		pattern:     path,
+		segments:    segments,
+		handler:     h,
+		middleware:  allMiddleware,
+		chain:       chain,
+		retrySafety: retrySafetyOf(allMiddleware),
+		source:      registrationSource(),
+	}
+	entries := append(r.routes[method], entry)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return precedes(entries[i].segments, entries[j].segments)
+	})
+	r.routes[method] = entries
+	return entry
+}
+
+// find mengembalikan route pertama yang match; routes sudah terurut berdasarkan prioritas.
+func (r *Router) find(method, path string, fold bool) (*routeEntry, map[string]string) {
+	for _, e := range r.routes[method] {
+		if params, ok := matchRoute(e.segments, path, fold); ok {
+			return e, params
+		}
+	}
+	return nil, nil
 }
 
 func (r *Router) Use(mws ...MiddlewareFunc) {
 	r.middleware = append(r.middleware, mws...)
 }
 
+// Handle mendaftarkan handler untuk method dan path apapun.
+func (r *Router) Handle(method, path string, h HandlerFunc, mws ...MiddlewareFunc) {
+	r.handle(strings.ToUpper(method), r.prefix+path, h, mws...)
+}
+
 func (r *Router) GET(path string, h HandlerFunc, mws ...MiddlewareFunc) {
 	r.handle("GET", r.prefix+path, h, mws...)
 }
 func (r *Router) POST(path string, h HandlerFunc, mws ...MiddlewareFunc) {
 	r.handle("POST", r.prefix+path, h, mws...)
 }
+func (r *Router) OPTIONS(path string, h HandlerFunc, mws ...MiddlewareFunc) {
+	r.handle("OPTIONS", r.prefix+path, h, mws...)
+}
 
-func matchRoute(pattern, path string) (map[string]string, bool) {
-	parts := strings.Split(pattern, "/")
-	pathParts := strings.Split(path, "/")
-	if len(parts) != len(pathParts) {
-		return nil, false
+const (
+	segmentStatic = iota
+	segmentParam
+	segmentWildcard
+)
+
+func segmentKind(seg string) int {
+	switch {
+	case strings.HasPrefix(seg, ":"):
+		return segmentParam
+	case strings.HasPrefix(seg, "*"):
+		return segmentWildcard
+	}
+	return segmentStatic
+}
+
+// precedes melaporkan apakah pattern a harus dicoba sebelum b:
+// static mengalahkan param, param mengalahkan wildcard. Jika satu pattern
+// adalah prefix pattern lain, yang match persis mengalahkan wildcard yang
+// match nol segmen, sehingga /files didahulukan dari /files/*path.
+func precedes(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ka, kb := segmentKind(a[i]), segmentKind(b[i])
+		if ka != kb {
+			return ka < kb
+		}
+		if ka == segmentStatic && a[i] != b[i] {
+			return a[i] < b[i]
+		}
 	}
+	switch {
+	case len(a) > len(b):
+		return segmentKind(a[len(b)]) != segmentWildcard
+	case len(b) > len(a):
+		return segmentKind(b[len(a)]) == segmentWildcard
+	}
+	return false
+}
+
+// conflicts melaporkan apakah dua pattern akan match dengan path yang persis sama.
+func conflicts(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		ka, kb := segmentKind(a[i]), segmentKind(b[i])
+		if ka != kb {
+			return false
+		}
+		if ka == segmentStatic && a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func matchRoute(parts []string, path string, fold bool) (map[string]string, bool) {
+	pathParts := strings.Split(path, "/")
 	params := make(map[string]string)
 	for i := range parts {
-		if strings.HasPrefix(parts[i], ":") {
+		switch segmentKind(parts[i]) {
+		case segmentWildcard:
+			if i > len(pathParts) {
+				return nil, false
+			}
+			params[parts[i][1:]] = strings.Join(pathParts[i:], "/")
+			return params, true
+		case segmentParam:
+			if i >= len(pathParts) {
+				return nil, false
+			}
 			params[parts[i][1:]] = pathParts[i]
-		} else if parts[i] != pathParts[i] {
-			return nil, false
+		default:
+			if i >= len(pathParts) {
+				return nil, false
+			}
+			if parts[i] != pathParts[i] && !(fold && strings.EqualFold(parts[i], pathParts[i])) {
+				return nil, false
+			}
 		}
 	}
+	if len(parts) != len(pathParts) {
+		return nil, false
+	}
 	return params, true
 }
 
+// canonicalPath membangun ulang path dengan ejaan segmen static dari pattern,
+// sementara nilai param dan wildcard tetap diambil dari request.
+func canonicalPath(parts []string, path string) string {
+	pathParts := strings.Split(path, "/")
+	for i := range parts {
+		if i >= len(pathParts) {
+			break
+		}
+		if segmentKind(parts[i]) == segmentStatic {
+			pathParts[i] = parts[i]
+		}
+	}
+	return strings.Join(pathParts, "/")
+}
+
 var validSession map[SessionType]reflect.Type
 
 type SessionType uint
@@ -158,8 +473,36 @@ type Context struct {
 	locale  faults.LanguageTag
 	Params  map[string]string
 	session Session
+	allowed []string
+
+	sessions  *sessionManager
+	sessionID string
+
+	route string
+	rw    *responseWriter
+	app   *App
+
+	breadcrumbs   []Breadcrumb
+	panicReported bool
+
+	body     []byte
+	bodyRead bool
+	variants map[string]string
+	schema   *Schema
 
 	httpStatus int
+	startedAt  time.Time
+
+	scoped map[reflect.Type]reflect.Value
+
+	retrySafety RetrySafety
+	csrfToken   string
+	flash       *flashState
+	requestType reflect.Type
+
+	localeFallbacks []faults.LanguageTag
+
+	budget *budgetTracker
 }
 
 func RegisterSessionType(session Session) {
@@ -232,8 +575,11 @@ func (c *Context) FetchSession(dst any) error {
 	return nil
 }
 
-func (c *Context) UseLocale(l faults.LanguageTag) {
+// UseLocale mengatur bahasa response. fallbacks dicoba berurutan sebelum
+// rantai global dari SetFallback ketika pesan tidak tersedia di l.
+func (c *Context) UseLocale(l faults.LanguageTag, fallbacks ...faults.LanguageTag) {
 	c.locale = l
+	c.localeFallbacks = fallbacks
 }
 
 func (c *Context) Locale() faults.LanguageTag {
@@ -243,7 +589,20 @@ func (c *Context) Locale() faults.LanguageTag {
 func (c *Context) JSON(code int, data any) error {
 	c.writer.Header().Set("Content-Type", "application/json")
 	c.writer.WriteHeader(code)
-	return json.NewEncoder(c.writer).Encode(data)
+	return json.NewEncoder(c.writer).Encode(c.masked(data))
+}
+
+// Status mengembalikan status HTTP response yang sudah ditulis, atau 0.
+func (c *Context) Status() int {
+	if c.httpStatus == 0 && c.rw != nil && c.rw.written {
+		return c.rw.status
+	}
+	return c.httpStatus
+}
+
+// RoutePattern mengembalikan pattern route yang match, misalnya "/users/:id".
+func (c *Context) RoutePattern() string {
+	return c.route
 }
 
 func (c *Context) Request() *http.Request {
@@ -254,6 +613,12 @@ func (c *Context) Writer() http.ResponseWriter {
 	return c.writer
 }
 
+// SetWriter mengganti writer yang dipakai Context, misalnya untuk middleware
+// yang perlu merekam atau membungkus response.
+func (c *Context) SetWriter(w http.ResponseWriter) {
+	c.writer = w
+}
+
 func (c *Context) Success(data any) error {
 	c.httpStatus = http.StatusOK
 
@@ -270,13 +635,13 @@ func (c *Context) Unauthorized(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusUnauthorized, map[string]any{
 			"code": http.StatusUnauthorized,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if er, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusUnauthorized, map[string]any{
 			"code": er.Code(),
 			"data": map[string]any{
-				"description": er.LocalizedError(c.locale),
+				"description": c.localizeError(er),
 			}})
 	} else {
 		c.JSON(http.StatusUnauthorized, map[string]any{
@@ -295,13 +660,13 @@ func (c *Context) BadInput(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusBadRequest, map[string]any{
 			"code": http.StatusBadRequest,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusBadRequest, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -321,13 +686,13 @@ func (c *Context) NotFound(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusNotFound, map[string]any{
 			"code": http.StatusNotFound,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusNotFound, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -347,13 +712,13 @@ func (c *Context) Forbidden(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusForbidden, map[string]any{
 			"code": http.StatusForbidden,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusForbidden, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -373,13 +738,13 @@ func (c *Context) TooManyRequest(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusTooManyRequests, map[string]any{
 			"code": http.StatusTooManyRequests,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusTooManyRequests, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -394,18 +759,44 @@ func (c *Context) TooManyRequest(err error) error {
 	return err
 }
 
+func (c *Context) PayloadTooLarge(err error) error {
+	c.httpStatus = http.StatusRequestEntityTooLarge
+	if ers, ok := err.(faults.Errors); ok {
+		c.JSON(http.StatusRequestEntityTooLarge, map[string]any{
+			"code": http.StatusRequestEntityTooLarge,
+			"data": c.localizeErrors(ers),
+		})
+	} else if ers, ok := err.(faults.Error); ok {
+		c.JSON(http.StatusRequestEntityTooLarge, map[string]any{
+			"code": ers.Code(),
+			"data": map[string]any{
+				"description": c.localizeError(ers),
+			},
+		})
+	} else {
+		c.JSON(http.StatusRequestEntityTooLarge, map[string]any{
+			"code": http.StatusRequestEntityTooLarge,
+			"data": map[string]any{
+				"description": err.Error(),
+			},
+		})
+	}
+
+	return err
+}
+
 func (c *Context) Conflict(err error) error {
 	c.httpStatus = http.StatusConflict
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusConflict, map[string]any{
 			"code": http.StatusConflict,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusConflict, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -425,13 +816,13 @@ func (c *Context) NotAllowed(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusMethodNotAllowed, map[string]any{
 			"code": http.StatusMethodNotAllowed,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusMethodNotAllowed, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -452,13 +843,13 @@ func (c *Context) BadGateway(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusBadGateway, map[string]any{
 			"code": http.StatusBadGateway,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if ers, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusBadGateway, map[string]any{
 			"code": ers.Code(),
 			"data": map[string]any{
-				"description": ers.LocalizedError(c.locale),
+				"description": c.localizeError(ers),
 			},
 		})
 	} else {
@@ -478,13 +869,13 @@ func (c *Context) Unavailable(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusServiceUnavailable, map[string]any{
 			"code": http.StatusServiceUnavailable,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if er, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusServiceUnavailable, map[string]any{
 			"code": er.Code(),
 			"data": map[string]any{
-				"description": er.LocalizedError(c.locale),
+				"description": c.localizeError(er),
 			}})
 
 		return err
@@ -516,13 +907,13 @@ func (c *Context) ServerError(err error) error {
 	if ers, ok := err.(faults.Errors); ok {
 		c.JSON(http.StatusInternalServerError, map[string]any{
 			"code": http.StatusInternalServerError,
-			"data": ers.LocalizedError(c.locale),
+			"data": c.localizeErrors(ers),
 		})
 	} else if er, ok := err.(faults.Error); ok {
 		c.JSON(http.StatusInternalServerError, map[string]any{
 			"code": er.Code(),
 			"data": map[string]any{
-				"description": er.LocalizedError(c.locale),
+				"description": c.localizeError(er),
 			}})
 
 		return err
@@ -547,17 +938,15 @@ func (c *Context) Query(key string) string {
 }
 
 func (c *Context) Bind(dest any) error {
-
-	defer c.request.Body.Close()
-	if err := json.NewDecoder(c.request.Body).Decode(dest); err != nil {
+	body, err := c.BodyBytes()
+	if err != nil {
 		return err
 	}
-
-	if validate, ok := dest.(validator.Validator); ok {
-		return validate.Validate()
+	if err := json.NewDecoder(bytes.NewReader(body)).Decode(dest); err != nil {
+		return err
 	}
 
-	return validator.ValidateStruct(dest)
+	return validateStruct(dest)
 }
 
 func (c *Context) BindForm(dest any) error {
@@ -567,56 +956,11 @@ func (c *Context) BindForm(dest any) error {
 	return bindFormValues(c.request.Form, dest)
 }
 
-func (c *Context) FormFile(key string) (multipart.File, *multipart.FileHeader, error) {
-	return c.request.FormFile(key)
+// BindQuery mengisi dest dari query string berdasarkan tag `query`.
+func (c *Context) BindQuery(dest any) error {
+	return bindValues(c.request.URL.Query(), dest, "query")
 }
 
-func bindFormValues(values map[string][]string, dest any) error {
-	v := reflect.ValueOf(dest).Elem()
-	t := v.Type()
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		structField := t.Field(i)
-		formKey := structField.Tag.Get("form")
-		if formKey == "" {
-			continue
-		}
-		if val, ok := values[formKey]; ok && len(val) > 0 {
-			switch field.Kind() {
-			case reflect.String:
-				field.SetString(val[0])
-			case reflect.Int, reflect.Int64:
-				i, _ := strconv.ParseInt(val[0], 10, 64)
-				field.SetInt(i)
-			case reflect.Float64:
-				f, _ := strconv.ParseFloat(val[0], 64)
-				field.SetFloat(f)
-			case reflect.Bool:
-				b, _ := strconv.ParseBool(val[0])
-				field.SetBool(b)
-			case reflect.Ptr:
-				ptr := reflect.New(field.Type().Elem())
-				switch field.Type().Elem().Kind() {
-				case reflect.String:
-					ptr.Elem().SetString(val[0])
-				case reflect.Int, reflect.Int64:
-					i, _ := strconv.ParseInt(val[0], 10, 64)
-					ptr.Elem().SetInt(i)
-				case reflect.Float64:
-					f, _ := strconv.ParseFloat(val[0], 64)
-					ptr.Elem().SetFloat(f)
-				case reflect.Bool:
-					b, _ := strconv.ParseBool(val[0])
-					ptr.Elem().SetBool(b)
-				}
-				field.Set(ptr)
-			}
-		}
-	}
-
-	if validate, ok := dest.(validator.Validator); ok {
-		return validate.Validate()
-	}
-
-	return validator.ValidateStruct(dest)
+func (c *Context) FormFile(key string) (multipart.File, *multipart.FileHeader, error) {
+	return c.request.FormFile(key)
 }
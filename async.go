@@ -0,0 +1,279 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// JobState adalah isi status yang disimpan di Redis dan dikembalikan route
+// status.
+type JobState struct {
+	ID        string          `json:"id"`
+	Status    JobStatus       `json:"status"`
+	Progress  int             `json:"progress"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	StatusURL string          `json:"status_url"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type jobRecord struct {
+	JobState
+	Owner string `json:"owner,omitempty"`
+}
+
+// Job diberikan ke JobFunc untuk melaporkan progres.
+type Job struct {
+	ID    string
+	async *Async
+}
+
+// Progress menyimpan progres 0-100.
+func (j *Job) Progress(ctx context.Context, percent int) error {
+	return j.async.update(ctx, j.ID, func(s *jobRecord) {
+		s.Progress = min(max(percent, 0), 100)
+	})
+}
+
+// JobFunc adalah pekerjaan lambat. Hasilnya disimpan sebagai result JSON.
+type JobFunc func(ctx context.Context, job *Job) (any, error)
+
+type AsyncConfig struct {
+	Alias  string        // alias Redis di cache.Pool()
+	Prefix string        // default "async:"
+	TTL    time.Duration // lama status disimpan, default 24 jam
+	// StatusPath adalah prefix route status, default "/jobs".
+	StatusPath string
+	// Timeout membatasi lama satu pekerjaan. 0 berarti tanpa batas.
+	Timeout time.Duration
+	// Enqueue menyerahkan pekerjaan ke antrean, misalnya worker pool atau
+	// job queue. Default dijalankan di goroutine baru yang ikut ditunggu
+	// Shutdown selama DrainConfig.Grace lalu dibatalkan (status failed).
+	// Pekerjaan default hilang jika proses mati; untuk antrean yang tahan
+	// restart dan bisa di-retry, isi Enqueue dengan job queue yang durable.
+	Enqueue func(run func(ctx context.Context)) error
+}
+
+// Async menstandarkan endpoint lambat: request dijawab 202 dengan URL status,
+// pekerjaan berjalan di latar belakang, dan progres/hasilnya dibaca dari Redis.
+type Async struct {
+	cfg    AsyncConfig
+	prefix string // prefix router tempat route status dipasang
+}
+
+func NewAsync(cfg AsyncConfig) *Async {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "async:"
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.StatusPath == "" {
+		cfg.StatusPath = "/jobs"
+	}
+	cfg.StatusPath = strings.TrimSuffix(cfg.StatusPath, "/")
+	return &Async{cfg: cfg}
+}
+
+// Register memasang route GET <StatusPath>/:id di r.
+func (a *Async) Register(r *Router, mws ...MiddlewareFunc) {
+	a.prefix = r.prefix
+	r.GET(a.cfg.StatusPath+"/:id", a.Status, mws...)
+}
+
+// Accept mencatat pekerjaan, menyerahkannya ke antrean, lalu menjawab 202
+// dengan header Location ke route status:
+//
+//	return jobs.Accept(ctx, func(ctx context.Context, job *netpath.Job) (any, error) {
+//		return report.Build(ctx, job.Progress)
+//	})
+//
+// Jika request punya session, status hanya bisa dibaca session yang sama.
+func (a *Async) Accept(ctx *Context, fn JobFunc) error {
+	b := make([]byte, 16)
+	rand.Read(b)
+	now := time.Now()
+	rec := jobRecord{JobState: JobState{
+		ID:        hex.EncodeToString(b),
+		Status:    JobPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}}
+	rec.StatusURL = a.prefix + a.cfg.StatusPath + "/" + rec.ID
+	rec.Owner = jobOwner(ctx.Session())
+
+	if err := a.save(ctx.request.Context(), rec); err != nil {
+		return ctx.ServerError(err)
+	}
+	// hasil disaring dengan peran session pemilik, satu-satunya yang boleh
+	// membaca status
+	mask := ctx.masker()
+	if a.cfg.Enqueue == nil {
+		a.spawn(ctx, rec.ID, fn, mask)
+	} else {
+		err := a.cfg.Enqueue(func(bg context.Context) {
+			a.run(bg, rec.ID, fn, mask)
+		})
+		if err != nil {
+			return ctx.Unavailable(err)
+		}
+	}
+
+	ctx.writer.Header().Set("Location", rec.StatusURL)
+	ctx.httpStatus = http.StatusAccepted
+	return ctx.JSON(http.StatusAccepted, map[string]any{
+		"code": http.StatusAccepted,
+		"data": rec.JobState,
+	})
+}
+
+// asyncJob mendaftarkan pekerjaan default di drain App: Shutdown menunggunya
+// seperti koneksi long-lived dan membatalkannya setelah grace habis.
+type asyncJob struct {
+	cancel context.CancelFunc
+}
+
+func (j *asyncJob) Drain() {}
+
+func (j *asyncJob) Abort() error {
+	j.cancel()
+	return nil
+}
+
+func (a *Async) spawn(ctx *Context, id string, fn JobFunc, mask func(any) any) {
+	bg, cancel := context.WithCancel(context.Background())
+	release := func() {}
+	if ctx.app != nil {
+		release = ctx.Hold(&asyncJob{cancel: cancel})
+	}
+	go func() {
+		defer release()
+		defer cancel()
+		a.run(bg, id, fn, mask)
+	}()
+}
+
+func (a *Async) run(ctx context.Context, id string, fn JobFunc, mask func(any) any) {
+	if a.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.cfg.Timeout)
+		defer cancel()
+	}
+	a.update(ctx, id, func(s *jobRecord) { s.Status = JobRunning })
+
+	result, err := func() (result any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}()
+		return fn(ctx, &Job{ID: id, async: a})
+	}()
+
+	var raw []byte
+	if err == nil {
+		raw, err = json.Marshal(mask(result))
+	}
+	// simpan status akhir walaupun ctx pekerjaan sudah habis
+	a.update(context.WithoutCancel(ctx), id, func(s *jobRecord) {
+		if err != nil {
+			s.Status = JobFailed
+			s.Error = err.Error()
+			return
+		}
+		s.Status = JobSucceeded
+		s.Progress = 100
+		s.Result = raw
+	})
+}
+
+// Get membaca status pekerjaan.
+func (a *Async) Get(ctx context.Context, id string) (JobState, error) {
+	rec, err := a.load(ctx, id)
+	return rec.JobState, err
+}
+
+// Status adalah handler route status.
+func (a *Async) Status(ctx *Context) error {
+	rec, err := a.load(ctx.request.Context(), ctx.Param("id"))
+	if errors.Is(err, redis.Nil) {
+		return ctx.NotFound(faults.ErrNotFound)
+	}
+	if err != nil {
+		return ctx.ServerError(err)
+	}
+	if rec.Owner != "" && jobOwner(ctx.Session()) != rec.Owner {
+		return ctx.NotFound(faults.ErrNotFound)
+	}
+	return ctx.Success(rec.JobState)
+}
+
+func jobOwner(sess Session) string {
+	if sess == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%s", sess.Type(), sess.Identifier())
+}
+
+func (a *Async) key(id string) string {
+	return a.cfg.Prefix + id
+}
+
+func (a *Async) save(ctx context.Context, rec jobRecord) error {
+	client, err := cache.Pool().Get(a.cfg.Alias)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, a.key(rec.ID), raw, a.cfg.TTL).Err()
+}
+
+func (a *Async) load(ctx context.Context, id string) (jobRecord, error) {
+	var rec jobRecord
+	client, err := cache.Pool().Get(a.cfg.Alias)
+	if err != nil {
+		return rec, err
+	}
+	raw, err := client.Get(ctx, a.key(id)).Bytes()
+	if err != nil {
+		return rec, err
+	}
+	err = json.Unmarshal(raw, &rec)
+	return rec, err
+}
+
+// update hanya dipanggil oleh pekerja satu job, sehingga baca-ubah-tulis
+// tidak perlu transaksi.
+func (a *Async) update(ctx context.Context, id string, fn func(*jobRecord)) error {
+	rec, err := a.load(ctx, id)
+	if err != nil {
+		return err
+	}
+	fn(&rec)
+	rec.UpdatedAt = time.Now()
+	return a.save(ctx, rec)
+}
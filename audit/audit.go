@@ -0,0 +1,209 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	path "github.com/godev90/netpath"
+)
+
+type Record struct {
+	Time       time.Time       `json:"time"`
+	Identifier string          `json:"identifier,omitempty"`
+	Method     string          `json:"method"`
+	Route      string          `json:"route"`
+	Path       string          `json:"path"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	Status     int             `json:"status"`
+	RequestID  string          `json:"request_id,omitempty"`
+	// RemoteAddr adalah ctx.ClientIP(), bukan alamat proxy di depan app.
+	RemoteAddr string `json:"remote_addr"`
+}
+
+type Sink interface {
+	Write(ctx context.Context, rec Record) error
+}
+
+type Config struct {
+	Sink Sink
+	// Methods yang dicatat; default POST, PUT, PATCH, DELETE.
+	Methods []string
+	// Redact berisi nama field JSON (tidak case-sensitive, di level manapun)
+	// atau field form yang nilainya diganti "[REDACTED]" sebelum disimpan.
+	// Jika diisi, body selain JSON dan form tidak disimpan isinya.
+	Redact []string
+}
+
+var defaultMethods = []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// Middleware mencatat siapa, apa, kapan dan hasil dari request yang mengubah
+// data ke Sink. Record ditulis setelah handler selesai; kegagalan menulis
+// hanya di-log dan tidak mempengaruhi response.
+func Middleware(cfg Config) path.MiddlewareFunc {
+	methods := cfg.Methods
+	if len(methods) == 0 {
+		methods = defaultMethods
+	}
+	redact := make(map[string]struct{}, len(cfg.Redact))
+	for _, f := range cfg.Redact {
+		redact[strings.ToLower(f)] = struct{}{}
+	}
+
+	return func(next path.HandlerFunc) path.HandlerFunc {
+		return func(ctx *path.Context) error {
+			r := ctx.Request()
+			if !contains(methods, r.Method) {
+				return next(ctx)
+			}
+
+			var payload json.RawMessage
+			if body, err := ctx.BodyBytes(); err == nil && len(body) > 0 {
+				payload = redactPayload(r.Header.Get("Content-Type"), body, redact)
+			}
+
+			err := next(ctx)
+
+			rec := Record{
+				Time:       time.Now(),
+				Method:     r.Method,
+				Route:      ctx.RoutePattern(),
+				Path:       r.URL.Path,
+				Payload:    payload,
+				Status:     status(ctx, err),
+				RequestID:  ctx.RequestID(),
+				RemoteAddr: ctx.ClientIP(),
+			}
+			if sess := ctx.Session(); sess != nil {
+				rec.Identifier = sess.Identifier()
+			}
+
+			if werr := cfg.Sink.Write(context.WithoutCancel(r.Context()), rec); werr != nil {
+				log.Printf("audit: failed to write record for %s %s: %v", rec.Method, rec.Path, werr)
+			}
+
+			return err
+		}
+	}
+}
+
+// status mengembalikan status response. Handler yang mengembalikan error
+// belum menulis response saat record dibuat, jadi statusnya diturunkan dari
+// error seperti yang akan dirender App.
+func status(ctx *path.Context, err error) int {
+	if s := ctx.Status(); s != 0 {
+		return s
+	}
+	if err != nil {
+		return path.ErrorStatus(err)
+	}
+	return http.StatusOK
+}
+
+const redacted = "[REDACTED]"
+
+// redactPayload mengganti nilai field sensitif pada body JSON dan form
+// (urlencoded maupun multipart; form disimpan sebagai object JSON). Body lain
+// disimpan sebagai string apa adanya, atau hanya keterangan jenis dan
+// ukurannya bila ada field yang harus di-redact.
+func redactPayload(contentType string, body []byte, fields map[string]struct{}) json.RawMessage {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return redactForm(values, nil, fields)
+		}
+	case "multipart/form-data":
+		if form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(int64(len(body))); err == nil {
+			defer form.RemoveAll()
+			return redactForm(form.Value, form.File, fields)
+		}
+	default:
+		var v any
+		if err := json.Unmarshal(body, &v); err == nil {
+			if len(fields) == 0 {
+				return body
+			}
+			out, _ := json.Marshal(redactValue(v, fields))
+			return out
+		}
+	}
+
+	if len(fields) > 0 {
+		if mediaType == "" {
+			mediaType = "unknown"
+		}
+		body = []byte(fmt.Sprintf("[%s body, %d bytes]", mediaType, len(body)))
+	}
+	raw, _ := json.Marshal(string(body))
+	return raw
+}
+
+// redactForm menyimpan field form sebagai object JSON: satu nilai sebagai
+// string, lebih dari satu sebagai array. Isi file tidak disimpan.
+func redactForm(values url.Values, files map[string][]*multipart.FileHeader, fields map[string]struct{}) json.RawMessage {
+	out := make(map[string]any, len(values)+len(files))
+	for k, vals := range values {
+		switch {
+		case isRedacted(k, fields):
+			out[k] = redacted
+		case len(vals) == 1:
+			out[k] = vals[0]
+		default:
+			out[k] = vals
+		}
+	}
+	for k, fhs := range files {
+		if isRedacted(k, fields) {
+			out[k] = redacted
+			continue
+		}
+		names := make([]string, len(fhs))
+		for i, fh := range fhs {
+			names[i] = fmt.Sprintf("[file %s, %d bytes]", fh.Filename, fh.Size)
+		}
+		out[k] = names
+	}
+	raw, _ := json.Marshal(out)
+	return raw
+}
+
+func isRedacted(key string, fields map[string]struct{}) bool {
+	_, ok := fields[strings.ToLower(key)]
+	return ok
+}
+
+func redactValue(v any, fields map[string]struct{}) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isRedacted(k, fields) {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactValue(val, fields)
+		}
+	case []any:
+		for i := range t {
+			t[i] = redactValue(t[i], fields)
+		}
+	}
+	return v
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
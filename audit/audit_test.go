@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	path "github.com/godev90/netpath"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (s *memorySink) Write(ctx context.Context, rec Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, rec)
+	return nil
+}
+
+func serveAudited(t *testing.T, cfg Config, r *http.Request, opts ...path.Option) Record {
+	t.Helper()
+	sink := &memorySink{}
+	cfg.Sink = sink
+	app := path.New(opts...)
+	app.Route().POST("/accounts", func(ctx *path.Context) error {
+		return ctx.Success(nil)
+	}, Middleware(cfg))
+
+	app.ServeHTTP(httptest.NewRecorder(), r)
+	if len(sink.records) != 1 {
+		t.Fatalf("got %d records, want 1", len(sink.records))
+	}
+	return sink.records[0]
+}
+
+func payloadMap(t *testing.T, rec Record) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal(rec.Payload, &m); err != nil {
+		t.Fatalf("payload %s: %v", rec.Payload, err)
+	}
+	return m
+}
+
+func TestRedactJSON(t *testing.T) {
+	r := httptest.NewRequest("POST", "/accounts", strings.NewReader(`{"user":"a","Password":"hunter2","card":{"cvv":"123","last4":"4242"}}`))
+	r.Header.Set("Content-Type", "application/json")
+	rec := serveAudited(t, Config{Redact: []string{"password", "cvv"}}, r)
+
+	m := payloadMap(t, rec)
+	card := m["card"].(map[string]any)
+	if m["user"] != "a" || m["Password"] != redacted || card["cvv"] != redacted || card["last4"] != "4242" {
+		t.Fatalf("payload = %s", rec.Payload)
+	}
+	if rec.Status != http.StatusOK || rec.Route != "/accounts" {
+		t.Fatalf("record = %+v", rec)
+	}
+}
+
+func TestRedactURLEncodedForm(t *testing.T) {
+	r := httptest.NewRequest("POST", "/accounts", strings.NewReader("user=a&password=hunter2&tag=x&tag=y"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := serveAudited(t, Config{Redact: []string{"password"}}, r)
+
+	if strings.Contains(string(rec.Payload), "hunter2") {
+		t.Fatalf("password stored: %s", rec.Payload)
+	}
+	m := payloadMap(t, rec)
+	tags, _ := m["tag"].([]any)
+	if m["user"] != "a" || m["password"] != redacted || len(tags) != 2 {
+		t.Fatalf("payload = %s", rec.Payload)
+	}
+}
+
+func TestRedactMultipartForm(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("user", "a")
+	mw.WriteField("password", "hunter2")
+	fw, _ := mw.CreateFormFile("avatar", "me.png")
+	fw.Write([]byte("PNGDATA"))
+	mw.Close()
+
+	r := httptest.NewRequest("POST", "/accounts", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	rec := serveAudited(t, Config{Redact: []string{"password"}}, r)
+
+	if strings.Contains(string(rec.Payload), "hunter2") || strings.Contains(string(rec.Payload), "PNGDATA") {
+		t.Fatalf("sensitive data stored: %s", rec.Payload)
+	}
+	m := payloadMap(t, rec)
+	if m["user"] != "a" || m["password"] != redacted || m["avatar"] == nil {
+		t.Fatalf("payload = %s", rec.Payload)
+	}
+}
+
+func TestRedactUnknownBody(t *testing.T) {
+	r := httptest.NewRequest("POST", "/accounts", strings.NewReader("password=hunter2"))
+	r.Header.Set("Content-Type", "text/plain")
+	rec := serveAudited(t, Config{Redact: []string{"password"}}, r)
+
+	var s string
+	if err := json.Unmarshal(rec.Payload, &s); err != nil || strings.Contains(s, "hunter2") {
+		t.Fatalf("payload = %s", rec.Payload)
+	}
+}
+
+func TestRecordsClientIP(t *testing.T) {
+	r := httptest.NewRequest("POST", "/accounts", strings.NewReader(`{}`))
+	r.RemoteAddr = "10.0.0.1:5000"
+	r.Header.Set("X-Forwarded-For", "203.0.113.5")
+	rec := serveAudited(t, Config{}, r, path.WithTrustedProxies("10.0.0.0/8"))
+
+	if rec.RemoteAddr != "203.0.113.5" {
+		t.Fatalf("RemoteAddr = %q, want client IP", rec.RemoteAddr)
+	}
+}
@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godev90/netpath/cache"
+	tools "github.com/godev90/netpath/database"
+	"github.com/redis/go-redis/v9"
+)
+
+// SQLSink menulis record ke tabel pada alias DB pool. Skema yang diharapkan:
+//
+//	CREATE TABLE audit_trail (
+//		time        TIMESTAMP NOT NULL,
+//		identifier  VARCHAR(128),
+//		method      VARCHAR(10) NOT NULL,
+//		route       VARCHAR(255) NOT NULL,
+//		path        VARCHAR(2048) NOT NULL,
+//		payload     TEXT,
+//		status      INT NOT NULL,
+//		request_id  VARCHAR(128),
+//		remote_addr VARCHAR(64)
+//	);
+type SQLSink struct {
+	Alias  string
+	Driver string
+	Table  string
+}
+
+func NewSQLSink(alias, driver string) *SQLSink {
+	return &SQLSink{Alias: alias, Driver: driver, Table: "audit_trail"}
+}
+
+func (s *SQLSink) Write(ctx context.Context, rec Record) error {
+	db, err := tools.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	values := "?, ?, ?, ?, ?, ?, ?, ?, ?"
+	if s.Driver == "postgres" {
+		values = "$1, $2, $3, $4, $5, $6, $7, $8, $9"
+	}
+	query := fmt.Sprintf("INSERT INTO %s (time, identifier, method, route, path, payload, status, request_id, remote_addr) VALUES (%s)",
+		s.Table, values)
+
+	_, err = db.ExecContext(ctx, query, rec.Time, rec.Identifier, rec.Method, rec.Route, rec.Path,
+		string(rec.Payload), rec.Status, rec.RequestID, rec.RemoteAddr)
+	return err
+}
+
+// RedisSink menambahkan record ke Redis stream pada alias cache pool.
+type RedisSink struct {
+	Alias  string
+	Stream string
+	// MaxLen memangkas stream secara kira-kira (MAXLEN ~); 0 berarti tidak dipangkas.
+	MaxLen int64
+}
+
+func (s *RedisSink) Write(ctx context.Context, rec Record) error {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	return client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.Stream,
+		MaxLen: s.MaxLen,
+		Approx: s.MaxLen > 0,
+		Values: map[string]any{
+			"time":        rec.Time.UnixMilli(),
+			"identifier":  rec.Identifier,
+			"method":      rec.Method,
+			"route":       rec.Route,
+			"path":        rec.Path,
+			"payload":     string(rec.Payload),
+			"status":      rec.Status,
+			"request_id":  rec.RequestID,
+			"remote_addr": rec.RemoteAddr,
+		},
+	}).Err()
+}
@@ -0,0 +1,399 @@
+package autotls
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	LetsEncrypt        = "https://acme-v02.api.letsencrypt.org/directory"
+	LetsEncryptStaging = "https://acme-staging-v02.api.letsencrypt.org/directory"
+)
+
+// Client adalah client ACME (RFC 8555) minimal yang hanya mendukung
+// challenge dns-01. Key akun harus ECDSA P-256.
+type Client struct {
+	DirectoryURL string
+	Key          *ecdsa.PrivateKey
+	Email        string
+	HTTPClient   *http.Client
+
+	mu     sync.Mutex
+	dir    *directory
+	kid    string
+	nonces []string
+}
+
+type directory struct {
+	NewNonce   string `json:"newNonce"`
+	NewAccount string `json:"newAccount"`
+	NewOrder   string `json:"newOrder"`
+}
+
+type order struct {
+	Status         string   `json:"status"`
+	Authorizations []string `json:"authorizations"`
+	Finalize       string   `json:"finalize"`
+	Certificate    string   `json:"certificate"`
+	Error          *Problem `json:"error"`
+}
+
+type authorization struct {
+	Status     string `json:"status"`
+	Identifier struct {
+		Value string `json:"value"`
+	} `json:"identifier"`
+	Challenges []challenge `json:"challenges"`
+	Wildcard   bool        `json:"wildcard"`
+}
+
+type challenge struct {
+	Type   string   `json:"type"`
+	URL    string   `json:"url"`
+	Token  string   `json:"token"`
+	Status string   `json:"status"`
+	Error  *Problem `json:"error"`
+}
+
+// Problem adalah error dari server ACME (RFC 7807).
+type Problem struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+	Status int    `json:"status"`
+}
+
+func (p *Problem) Error() string {
+	return fmt.Sprintf("acme: %s: %s", p.Type, p.Detail)
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) directory(ctx context.Context) (*directory, error) {
+	if c.dir != nil {
+		return c.dir, nil
+	}
+	url := c.DirectoryURL
+	if url == "" {
+		url = LetsEncrypt
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var d directory
+	if err := json.NewDecoder(res.Body).Decode(&d); err != nil {
+		return nil, err
+	}
+	c.dir = &d
+	return c.dir, nil
+}
+
+func (c *Client) nonce(ctx context.Context) (string, error) {
+	if n := len(c.nonces); n > 0 {
+		nonce := c.nonces[n-1]
+		c.nonces = c.nonces[:n-1]
+		return nonce, nil
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodHead, c.dir.NewNonce, nil)
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	res.Body.Close()
+
+	nonce := res.Header.Get("Replay-Nonce")
+	if nonce == "" {
+		return "", errors.New("acme: server did not return a nonce")
+	}
+	return nonce, nil
+}
+
+// post mengirim JWS ke url. payload nil berarti POST-as-GET.
+func (c *Client) post(ctx context.Context, url string, payload any) (*http.Response, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		nonce, err := c.nonce(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		body, err := c.sign(url, nonce, payload)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/jose+json")
+		res, err := c.httpClient().Do(req)
+		if err != nil {
+			return nil, nil, err
+		}
+		raw, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		if nonce := res.Header.Get("Replay-Nonce"); nonce != "" {
+			c.nonces = append(c.nonces, nonce)
+		}
+
+		if res.StatusCode < 400 {
+			return res, raw, nil
+		}
+		var p Problem
+		json.Unmarshal(raw, &p)
+		if p.Type == "urn:ietf:params:acme:error:badNonce" && attempt < 3 {
+			continue
+		}
+		if p.Type == "" {
+			p.Type, p.Detail = "http", res.Status
+		}
+		p.Status = res.StatusCode
+		return nil, nil, &p
+	}
+}
+
+func (c *Client) sign(url, nonce string, payload any) ([]byte, error) {
+	protected := map[string]any{"alg": "ES256", "nonce": nonce, "url": url}
+	if c.kid != "" {
+		protected["kid"] = c.kid
+	} else {
+		protected["jwk"] = jwk(&c.Key.PublicKey)
+	}
+
+	rawProtected, err := json.Marshal(protected)
+	if err != nil {
+		return nil, err
+	}
+	var rawPayload []byte
+	if payload != nil {
+		if rawPayload, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	b64 := base64.RawURLEncoding
+	signingInput := b64.EncodeToString(rawProtected) + "." + b64.EncodeToString(rawPayload)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, c.Key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	return json.Marshal(map[string]string{
+		"protected": b64.EncodeToString(rawProtected),
+		"payload":   b64.EncodeToString(rawPayload),
+		"signature": b64.EncodeToString(sig),
+	})
+}
+
+func jwk(pub *ecdsa.PublicKey) map[string]string {
+	b64 := base64.RawURLEncoding
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	pub.X.FillBytes(x)
+	pub.Y.FillBytes(y)
+	return map[string]string{"crv": "P-256", "kty": "EC", "x": b64.EncodeToString(x), "y": b64.EncodeToString(y)}
+}
+
+// thumbprint adalah JWK thumbprint (RFC 7638) dari key akun.
+func thumbprint(pub *ecdsa.PublicKey) string {
+	k := jwk(pub)
+	raw := fmt.Sprintf(`{"crv":"%s","kty":"%s","x":"%s","y":"%s"}`, k["crv"], k["kty"], k["x"], k["y"])
+	sum := sha256.Sum256([]byte(raw))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (c *Client) register(ctx context.Context) error {
+	if c.kid != "" {
+		return nil
+	}
+	payload := map[string]any{"termsOfServiceAgreed": true}
+	if c.Email != "" {
+		payload["contact"] = []string{"mailto:" + c.Email}
+	}
+
+	res, _, err := c.post(ctx, c.dir.NewAccount, payload)
+	if err != nil {
+		return err
+	}
+	c.kid = res.Header.Get("Location")
+	if c.kid == "" {
+		return errors.New("acme: account location missing")
+	}
+	return nil
+}
+
+// Obtain meminta sertifikat untuk domains (boleh wildcard seperti
+// "*.example.com") dengan challenge dns-01 lewat provider. Hasilnya adalah
+// chain sertifikat dalam PEM.
+func (c *Client) Obtain(ctx context.Context, provider DNSProvider, certKey crypto.Signer, domains []string, propagation time.Duration) ([]byte, error) {
+	if c.Key == nil || c.Key.Curve != elliptic.P256() {
+		return nil, errors.New("acme: account key must be ECDSA P-256")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.directory(ctx); err != nil {
+		return nil, err
+	}
+	if err := c.register(ctx); err != nil {
+		return nil, err
+	}
+
+	ids := make([]map[string]string, len(domains))
+	for i, d := range domains {
+		ids[i] = map[string]string{"type": "dns", "value": d}
+	}
+	res, raw, err := c.post(ctx, c.dir.NewOrder, map[string]any{"identifiers": ids})
+	if err != nil {
+		return nil, err
+	}
+	orderURL := res.Header.Get("Location")
+	var o order
+	if err := json.Unmarshal(raw, &o); err != nil {
+		return nil, err
+	}
+
+	for _, authzURL := range o.Authorizations {
+		if err := c.authorize(ctx, provider, authzURL, propagation); err != nil {
+			return nil, err
+		}
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: domains[0]},
+		DNSNames: domains,
+	}, certKey)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := c.post(ctx, o.Finalize, map[string]string{"csr": base64.RawURLEncoding.EncodeToString(csr)}); err != nil {
+		return nil, err
+	}
+
+	if err := c.poll(ctx, orderURL, &o, func() (bool, error) {
+		switch o.Status {
+		case "valid":
+			return true, nil
+		case "invalid":
+			if o.Error != nil {
+				return false, o.Error
+			}
+			return false, errors.New("acme: order is invalid")
+		}
+		return false, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	_, chain, err := c.post(ctx, o.Certificate, nil)
+	return chain, err
+}
+
+func (c *Client) authorize(ctx context.Context, provider DNSProvider, url string, propagation time.Duration) error {
+	var authz authorization
+	_, raw, err := c.post(ctx, url, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw, &authz); err != nil {
+		return err
+	}
+	if authz.Status == "valid" {
+		return nil
+	}
+
+	var chal *challenge
+	for i := range authz.Challenges {
+		if authz.Challenges[i].Type == "dns-01" {
+			chal = &authz.Challenges[i]
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("acme: no dns-01 challenge for %s", authz.Identifier.Value)
+	}
+
+	keyAuth := chal.Token + "." + thumbprint(&c.Key.PublicKey)
+	sum := sha256.Sum256([]byte(keyAuth))
+	value := base64.RawURLEncoding.EncodeToString(sum[:])
+	fqdn := "_acme-challenge." + strings.TrimPrefix(authz.Identifier.Value, "*.")
+
+	if err := provider.Present(ctx, fqdn, value); err != nil {
+		return fmt.Errorf("acme: present %s: %w", fqdn, err)
+	}
+	defer provider.CleanUp(context.WithoutCancel(ctx), fqdn, value)
+
+	if err := waitTXT(ctx, fqdn, value, propagation); err != nil {
+		return err
+	}
+
+	if _, _, err := c.post(ctx, chal.URL, struct{}{}); err != nil {
+		return err
+	}
+
+	return c.poll(ctx, url, &authz, func() (bool, error) {
+		switch authz.Status {
+		case "valid":
+			return true, nil
+		case "invalid", "deactivated", "expired", "revoked":
+			for _, ch := range authz.Challenges {
+				if ch.Error != nil {
+					return false, ch.Error
+				}
+			}
+			return false, fmt.Errorf("acme: authorization for %s is %s", authz.Identifier.Value, authz.Status)
+		}
+		return false, nil
+	})
+}
+
+// poll mengulang POST-as-GET ke url sampai done mengembalikan true atau error.
+func (c *Client) poll(ctx context.Context, url string, dst any, done func() (bool, error)) error {
+	for {
+		_, raw, err := c.post(ctx, url, nil)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return err
+		}
+		if ok, err := done(); ok || err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package autotls
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const cloudflareAPI = "https://api.cloudflare.com/client/v4"
+
+// Cloudflare adalah DNSProvider untuk Cloudflare. Token butuh izin
+// Zone:Read dan DNS:Edit pada zone terkait.
+type Cloudflare struct {
+	APIToken string
+	// ZoneID opsional; jika kosong zone dicari dari nama domain.
+	ZoneID     string
+	HTTPClient *http.Client
+
+	mu      sync.Mutex
+	records map[string]string // fqdn|value -> record ID
+}
+
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result json.RawMessage `json:"result"`
+}
+
+func (p *Cloudflare) Present(ctx context.Context, fqdn, value string) error {
+	zone, err := p.zone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	var rec struct {
+		ID string `json:"id"`
+	}
+	err = p.do(ctx, http.MethodPost, "/zones/"+zone+"/dns_records", map[string]any{
+		"type":    "TXT",
+		"name":    fqdn,
+		"content": value,
+		"ttl":     120,
+	}, &rec)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	if p.records == nil {
+		p.records = make(map[string]string)
+	}
+	p.records[fqdn+"|"+value] = rec.ID
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *Cloudflare) CleanUp(ctx context.Context, fqdn, value string) error {
+	p.mu.Lock()
+	id, ok := p.records[fqdn+"|"+value]
+	delete(p.records, fqdn+"|"+value)
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	zone, err := p.zone(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+	return p.do(ctx, http.MethodDelete, "/zones/"+zone+"/dns_records/"+id, nil, nil)
+}
+
+// zone mencari zone dengan memotong label dari kiri sampai ditemukan.
+func (p *Cloudflare) zone(ctx context.Context, fqdn string) (string, error) {
+	if p.ZoneID != "" {
+		return p.ZoneID, nil
+	}
+
+	labels := strings.Split(fqdn, ".")
+	for i := 0; i < len(labels)-1; i++ {
+		var zones []struct {
+			ID string `json:"id"`
+		}
+		name := strings.Join(labels[i:], ".")
+		if err := p.do(ctx, http.MethodGet, "/zones?name="+url.QueryEscape(name), nil, &zones); err != nil {
+			return "", err
+		}
+		if len(zones) > 0 {
+			return zones[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("cloudflare: no zone found for %s", fqdn)
+}
+
+func (p *Cloudflare) do(ctx context.Context, method, path string, body, dst any) error {
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, cloudflareAPI+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := p.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var out cloudflareResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return fmt.Errorf("cloudflare: %s", res.Status)
+	}
+	if !out.Success {
+		msgs := make([]string, len(out.Errors))
+		for i, e := range out.Errors {
+			msgs[i] = e.Message
+		}
+		return errors.New("cloudflare: " + strings.Join(msgs, "; "))
+	}
+	if dst != nil {
+		return json.Unmarshal(out.Result, dst)
+	}
+	return nil
+}
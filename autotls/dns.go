@@ -0,0 +1,40 @@
+package autotls
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+)
+
+// DNSProvider membuat dan menghapus record TXT untuk challenge dns-01.
+// fqdn selalu berbentuk "_acme-challenge.<domain>" tanpa titik di akhir.
+type DNSProvider interface {
+	Present(ctx context.Context, fqdn, value string) error
+	CleanUp(ctx context.Context, fqdn, value string) error
+}
+
+// waitTXT menunggu record TXT terlihat lewat resolver sampai timeout. Jika
+// timeout habis challenge tetap dilanjutkan, karena resolver lokal bisa
+// saja masih menyimpan cache negatif sementara server ACME sudah melihatnya.
+func waitTXT(ctx context.Context, fqdn, value string, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = 2 * time.Minute
+	}
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		records, _ := net.DefaultResolver.LookupTXT(ctx, fqdn)
+		if slices.Contains(records, value) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("acme: waiting for %s: %w", fqdn, ctx.Err())
+		case <-time.After(min(5*time.Second, time.Until(deadline))):
+		}
+	}
+	return nil
+}
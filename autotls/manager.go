@@ -0,0 +1,292 @@
+package autotls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Manager mengambil dan memperbarui sertifikat lewat ACME dns-01, termasuk
+// sertifikat wildcard untuk routing berbasis host (App.Host("*.tenant.example.com")).
+type Manager struct {
+	// Domains adalah daftar sertifikat; setiap entri satu sertifikat,
+	// misalnya "api.example.com" atau "*.tenant.example.com".
+	Domains  []string
+	Provider DNSProvider
+
+	DirectoryURL string // default LetsEncrypt
+	Email        string
+	// Dir menyimpan key akun dan sertifikat. Default "acme".
+	Dir string
+	// RenewBefore memperbarui sertifikat sebelum kedaluwarsa. Default 30 hari.
+	RenewBefore time.Duration
+	// Propagation adalah batas waktu menunggu record TXT terlihat. Default 2 menit.
+	Propagation time.Duration
+
+	once     sync.Once
+	initErr  error
+	client   *Client
+	mu       sync.RWMutex
+	certs    map[string]*tls.Certificate
+	locks    map[string]*sync.Mutex // satu ACME order per nama
+	renewing map[string]bool
+}
+
+var ErrHostNotAllowed = errors.New("autotls: host not in Domains")
+
+// TLSConfig mengembalikan tls.Config yang memakai GetCertificate milik Manager.
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: m.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+}
+
+// Start memuat sertifikat dari Dir, meminta sertifikat yang belum ada, lalu
+// memeriksa perpanjangan di background sampai ctx selesai. Panggil sebelum
+// server menerima koneksi:
+//
+//	if err := m.Start(ctx); err != nil {
+//		log.Fatal(err)
+//	}
+//	app.Run(":443", netpath.ServerConfig{TLS: m.TLSConfig()})
+func (m *Manager) Start(ctx context.Context) error {
+	if err := m.init(); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(m.Domains))
+	for i, d := range m.Domains {
+		if !m.due(m.cert(d)) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = m.issue(ctx, d)
+		}()
+	}
+	wg.Wait()
+
+	go m.renewLoop(ctx)
+	return errors.Join(errs...)
+}
+
+// GetCertificate memilih sertifikat untuk SNI, baik yang cocok persis
+// maupun lewat wildcard satu label. Hanya membaca cache: sertifikat yang
+// belum ada atau mendekati kedaluwarsa diminta di background, sehingga
+// handshake tidak pernah menunggu ACME.
+func (m *Manager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if err := m.init(); err != nil {
+		return nil, err
+	}
+
+	name := m.match(strings.ToLower(strings.TrimSuffix(hello.ServerName, ".")))
+	if name == "" {
+		return nil, ErrHostNotAllowed
+	}
+
+	cert := m.cert(name)
+	if m.due(cert) {
+		m.renewAsync(name)
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("autotls: certificate for %s is not ready", name)
+	}
+	return cert, nil
+}
+
+func (m *Manager) cert(name string) *tls.Certificate {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.certs[name]
+}
+
+// due melaporkan apakah sertifikat perlu diminta atau diperbarui.
+func (m *Manager) due(cert *tls.Certificate) bool {
+	return cert == nil || time.Until(cert.Leaf.NotAfter) < m.RenewBefore
+}
+
+func (m *Manager) match(host string) string {
+	for _, d := range m.Domains {
+		if d == host {
+			return d
+		}
+		if suffix, ok := strings.CutPrefix(d, "*"); ok && strings.HasSuffix(host, suffix) {
+			if label := strings.TrimSuffix(host, suffix); label != "" && !strings.Contains(label, ".") {
+				return d
+			}
+		}
+	}
+	return ""
+}
+
+func (m *Manager) init() error {
+	m.once.Do(func() {
+		if m.Provider == nil {
+			m.initErr = errors.New("autotls: Provider is required")
+			return
+		}
+		if m.Dir == "" {
+			m.Dir = "acme"
+		}
+		if m.RenewBefore == 0 {
+			m.RenewBefore = 30 * 24 * time.Hour
+		}
+		m.certs = make(map[string]*tls.Certificate)
+		m.locks = make(map[string]*sync.Mutex)
+		m.renewing = make(map[string]bool)
+		for _, d := range m.Domains {
+			m.locks[d] = &sync.Mutex{}
+		}
+
+		if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+			m.initErr = err
+			return
+		}
+		key, err := loadOrCreateKey(filepath.Join(m.Dir, "account.pem"))
+		if err != nil {
+			m.initErr = err
+			return
+		}
+		m.client = &Client{DirectoryURL: m.DirectoryURL, Key: key, Email: m.Email}
+
+		for _, d := range m.Domains {
+			if cert, err := tls.LoadX509KeyPair(m.certPath(d), m.certPath(d)); err == nil {
+				cert.Leaf, _ = x509.ParseCertificate(cert.Certificate[0])
+				if cert.Leaf != nil {
+					m.certs[d] = &cert
+				}
+			}
+		}
+	})
+	return m.initErr
+}
+
+func (m *Manager) certPath(name string) string {
+	return filepath.Join(m.Dir, strings.ReplaceAll(name, "*", "_wildcard")+".pem")
+}
+
+// renewLoop memeriksa semua domain dua kali sehari.
+func (m *Manager) renewLoop(ctx context.Context) {
+	ticker := time.NewTicker(12 * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, d := range m.Domains {
+				if m.due(m.cert(d)) {
+					m.renewAsync(d)
+				}
+			}
+		}
+	}
+}
+
+// renewAsync meminta sertifikat di background, paling banyak satu per nama.
+// Context-nya terlepas dari handshake yang memicunya, sehingga client yang
+// memutus koneksi tidak membatalkan order ACME.
+func (m *Manager) renewAsync(name string) {
+	m.mu.Lock()
+	if m.renewing[name] {
+		m.mu.Unlock()
+		return
+	}
+	m.renewing[name] = true
+	m.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		if err := m.issue(ctx, name); err != nil {
+			log.Printf("[autotls] renew %s failed: %v", name, err)
+		}
+		m.mu.Lock()
+		delete(m.renewing, name)
+		m.mu.Unlock()
+	}()
+}
+
+// issue menjalankan order ACME untuk name dengan lock per nama; nama lain
+// tetap bisa diproses bersamaan. Jika goroutine lain sudah memperbarui
+// sertifikat selama menunggu lock, tidak ada order baru.
+func (m *Manager) issue(ctx context.Context, name string) error {
+	lock := m.locks[name]
+	lock.Lock()
+	defer lock.Unlock()
+
+	if !m.due(m.cert(name)) {
+		return nil
+	}
+	_, err := m.obtain(ctx, name)
+	return err
+}
+
+// obtain meminta sertifikat baru lalu menyimpannya ke Dir dan memori.
+func (m *Manager) obtain(ctx context.Context, name string) (*tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := m.client.Obtain(ctx, m.Provider, key, []string{name}, m.Propagation)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	cert, err := tls.X509KeyPair(chain, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("autotls: invalid certificate for %s: %w", name, err)
+	}
+	cert.Leaf, _ = x509.ParseCertificate(cert.Certificate[0])
+
+	if err := os.WriteFile(m.certPath(name), append(keyPEM, chain...), 0o600); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.certs[name] = &cert
+	m.mu.Unlock()
+	return &cert, nil
+}
+
+func loadOrCreateKey(path string) (*ecdsa.PrivateKey, error) {
+	if raw, err := os.ReadFile(path); err == nil {
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			return nil, fmt.Errorf("autotls: invalid key in %s", path)
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600)
+}
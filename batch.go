@@ -0,0 +1,217 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/godev90/validator/faults"
+)
+
+// BatchRequest adalah satu sub-request di body endpoint batch.
+type BatchRequest struct {
+	ID      string            `json:"id,omitempty"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// BatchResponse adalah hasil satu sub-request. Body berisi JSON apa adanya,
+// atau string jika response bukan JSON.
+type BatchResponse struct {
+	ID      string            `json:"id,omitempty"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+type BatchConfig struct {
+	MaxItems    int // default 20
+	Concurrency int // default 4
+}
+
+type batchParentKey struct{}
+
+// Batch mengembalikan handler yang menerima array BatchRequest dan
+// menjalankannya lewat router, termasuk middleware setiap route:
+//
+//	app.Route().POST("/batch", app.Batch(netpath.BatchConfig{MaxItems: 50}))
+//
+// Header request batch (misalnya Authorization dan Cookie) diteruskan ke
+// setiap sub-request kecuali header yang hanya berlaku untuk request batch,
+// seperti Idempotency-Key dan If-Match. Session yang sudah dipasang
+// middleware route batch dipakai bersama. Urutan response sama dengan urutan
+// request; item yang panic dijawab status 500 tanpa menggagalkan item lain.
+func (app *App) Batch(cfg BatchConfig) HandlerFunc {
+	if cfg.MaxItems <= 0 {
+		cfg.MaxItems = 20
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+
+	return func(ctx *Context) error {
+		if batchParent(ctx.request.Context()) != nil {
+			// batch di dalam batch tidak diizinkan
+			return ctx.BadInput(faults.ErrBadRequest)
+		}
+
+		body, err := ctx.BodyBytes()
+		if err != nil {
+			return ctx.BadInput(err)
+		}
+		var items []BatchRequest
+		if err := json.Unmarshal(body, &items); err != nil {
+			return ctx.BadInput(faults.ErrBadRequest)
+		}
+		if len(items) > cfg.MaxItems {
+			return ctx.BadInput(faults.Errors{"requests": faults.ErrLengthAboveMaximum.Render(cfg.MaxItems)})
+		}
+		errs := faults.Errors{}
+		for i, item := range items {
+			if item.Method == "" || !strings.HasPrefix(item.Path, "/") {
+				key := "requests." + strconv.Itoa(i)
+				errs[key] = faults.ErrInvalidParameter.Render(key)
+			}
+		}
+		if len(errs) > 0 {
+			return ctx.BadInput(errs)
+		}
+
+		results := make([]BatchResponse, len(items))
+		slots := make(chan struct{}, cfg.Concurrency)
+		var wg sync.WaitGroup
+		for i, item := range items {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func() {
+				defer func() {
+					<-slots
+					wg.Done()
+				}()
+				// panic satu item tidak boleh mematikan proses; hook OnPanic
+				// sudah dijalankan ServeHTTP item sebelum panic diteruskan
+				defer func() {
+					if p := recover(); p != nil {
+						if p != http.ErrAbortHandler {
+							log.Printf("[batch] panic serving %s %s: %v\n%s", item.Method, item.Path, p, debug.Stack())
+						}
+						results[i] = BatchResponse{ID: item.ID, Status: http.StatusInternalServerError}
+					}
+				}()
+				results[i] = app.serveBatchItem(ctx, item)
+			}()
+		}
+		wg.Wait()
+
+		return ctx.Success(results)
+	}
+}
+
+func (app *App) serveBatchItem(parent *Context, item BatchRequest) BatchResponse {
+	res := BatchResponse{ID: item.ID}
+
+	reqCtx := context.WithValue(parent.request.Context(), batchParentKey{}, parent)
+	r, err := http.NewRequestWithContext(reqCtx, strings.ToUpper(item.Method), item.Path, bytes.NewReader(item.Body))
+	if err != nil {
+		res.Status = http.StatusBadRequest
+		return res
+	}
+	r.Host = parent.request.Host
+	r.RemoteAddr = parent.request.RemoteAddr
+	for k, v := range parent.request.Header {
+		if _, skip := batchSkipHeaders[k]; !skip {
+			r.Header[k] = v
+		}
+	}
+	if len(item.Body) > 0 {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range item.Headers {
+		r.Header.Set(k, v)
+	}
+
+	w := &batchWriter{header: make(http.Header)}
+	app.ServeHTTP(w, r)
+
+	res.Status = w.status
+	if res.Status == 0 {
+		res.Status = http.StatusOK
+	}
+	if len(w.header) > 0 {
+		res.Headers = make(map[string]string, len(w.header))
+		for k := range w.header {
+			res.Headers[k] = w.header.Get(k)
+		}
+	}
+	if raw := bytes.TrimSpace(w.body.Bytes()); len(raw) > 0 {
+		if json.Valid(raw) {
+			res.Body = raw
+		} else {
+			res.Body, _ = json.Marshal(string(raw))
+		}
+	}
+	return res
+}
+
+// batchSkipHeaders tidak diteruskan dari request batch karena hanya berlaku
+// untuk request batch itu sendiri: header body, hop-by-hop, kondisional, dan
+// Idempotency-Key (setiap item yang butuh idempotensi mengirim key sendiri).
+var batchSkipHeaders = map[string]struct{}{
+	"Content-Length":      {},
+	"Content-Type":        {},
+	"Content-Encoding":    {},
+	"Content-Digest":      {},
+	"Connection":          {},
+	"Keep-Alive":          {},
+	"Proxy-Connection":    {},
+	"Te":                  {},
+	"Trailer":             {},
+	"Transfer-Encoding":   {},
+	"Upgrade":             {},
+	"Expect":              {},
+	"If-Match":            {},
+	"If-None-Match":       {},
+	"If-Modified-Since":   {},
+	"If-Unmodified-Since": {},
+	"Range":               {},
+	"Idempotency-Key":     {},
+}
+
+// batchParent mengembalikan Context request batch jika ctx berasal dari
+// sub-request.
+func batchParent(ctx context.Context) *Context {
+	parent, _ := ctx.Value(batchParentKey{}).(*Context)
+	return parent
+}
+
+// batchWriter menampung response sub-request di memori.
+type batchWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *batchWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *batchWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(p)
+}
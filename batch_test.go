@@ -0,0 +1,67 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBatchIsolatesPanickingItem(t *testing.T) {
+	app := New()
+	app.Route().GET("/ok/:n", func(ctx *Context) error {
+		return ctx.Success(ctx.Param("n"))
+	})
+	app.Route().GET("/boom", func(ctx *Context) error {
+		panic("boom")
+	})
+	app.Route().POST("/batch", app.Batch(BatchConfig{Concurrency: 2}))
+
+	body := `[
+		{"id":"a","method":"GET","path":"/ok/1"},
+		{"id":"b","method":"GET","path":"/boom"},
+		{"id":"c","method":"GET","path":"/ok/3"}
+	]`
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("batch status = %d, want 200", w.Code)
+	}
+
+	var res struct {
+		Data []BatchResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Data) != 3 {
+		t.Fatalf("got %d results, want 3", len(res.Data))
+	}
+
+	want := []struct {
+		id     string
+		status int
+		data   string
+	}{
+		{"a", http.StatusOK, "1"},
+		{"b", http.StatusInternalServerError, ""},
+		{"c", http.StatusOK, "3"},
+	}
+	for i, tc := range want {
+		got := res.Data[i]
+		if got.ID != tc.id || got.Status != tc.status {
+			t.Errorf("item %d = %s/%d, want %s/%d", i, got.ID, got.Status, tc.id, tc.status)
+			continue
+		}
+		if tc.data == "" {
+			continue
+		}
+		var item struct {
+			Data string `json:"data"`
+		}
+		if err := json.Unmarshal(got.Body, &item); err != nil || item.Data != tc.data {
+			t.Errorf("item %d body = %s, want data %q", i, got.Body, tc.data)
+		}
+	}
+}
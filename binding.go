@@ -0,0 +1,181 @@
+package app
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/godev90/validator/faults"
+)
+
+type fieldSetter func(field reflect.Value, raw string) error
+
+// bindConverters menyimpan converter yang didaftarkan lewat RegisterBindType.
+var bindConverters sync.Map
+
+// RegisterBindType mendaftarkan converter string ke T untuk BindForm dan
+// BindQuery, misalnya uuid.UUID atau decimal.Decimal. Field bertipe T dan *T
+// memakai converter ini; error converter dilaporkan sebagai input tidak valid.
+// Daftarkan converter saat init, sebelum request pertama.
+func RegisterBindType[T any](convert func(raw string) (T, error)) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	bindConverters.Store(t, fieldSetter(func(field reflect.Value, raw string) error {
+		v, err := convert(raw)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(v))
+		return nil
+	}))
+	bindPlans.Clear()
+}
+
+type bindField struct {
+	index      int
+	key        string
+	set        fieldSetter
+	def        string
+	hasDefault bool
+	required   bool
+}
+
+type bindPlan struct {
+	fields []bindField
+}
+
+// bindPlans menyimpan bindPlan per reflect.Type dan tag, supaya struct yang
+// sama tidak perlu dipindai ulang dengan reflection di setiap request.
+var bindPlans sync.Map
+
+type bindPlanKey struct {
+	typ reflect.Type
+	tag string
+}
+
+func planFor(t reflect.Type, tag string) *bindPlan {
+	key := bindPlanKey{typ: t, tag: tag}
+	if p, ok := bindPlans.Load(key); ok {
+		return p.(*bindPlan)
+	}
+
+	plan := &bindPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tagValue := structField.Tag.Get(tag)
+		if tagValue == "" {
+			continue
+		}
+
+		set := setterFor(structField.Type)
+		if set == nil {
+			continue
+		}
+
+		f := bindField{index: i, set: set}
+		parseBindTag(tagValue, &f)
+		plan.fields = append(plan.fields, f)
+	}
+
+	p, _ := bindPlans.LoadOrStore(key, plan)
+	return p.(*bindPlan)
+}
+
+// parseBindTag membaca tag seperti `form:"page,default=1"` atau
+// `query:"limit,default=20,required"`. Nilai default tidak boleh mengandung koma.
+func parseBindTag(tag string, f *bindField) {
+	parts := strings.Split(tag, ",")
+	f.key = strings.TrimSpace(parts[0])
+	for _, opt := range parts[1:] {
+		opt = strings.TrimSpace(opt)
+		switch {
+		case opt == "required":
+			f.required = true
+		case strings.HasPrefix(opt, "default="):
+			f.def = strings.TrimPrefix(opt, "default=")
+			f.hasDefault = true
+		}
+	}
+}
+
+func setterFor(t reflect.Type) fieldSetter {
+	if conv, ok := bindConverters.Load(t); ok {
+		return conv.(fieldSetter)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return func(field reflect.Value, raw string) error {
+			field.SetString(raw)
+			return nil
+		}
+	case reflect.Int, reflect.Int64:
+		return func(field reflect.Value, raw string) error {
+			i, _ := strconv.ParseInt(raw, 10, 64)
+			field.SetInt(i)
+			return nil
+		}
+	case reflect.Float64:
+		return func(field reflect.Value, raw string) error {
+			f, _ := strconv.ParseFloat(raw, 64)
+			field.SetFloat(f)
+			return nil
+		}
+	case reflect.Bool:
+		return func(field reflect.Value, raw string) error {
+			b, _ := strconv.ParseBool(raw)
+			field.SetBool(b)
+			return nil
+		}
+	case reflect.Ptr:
+		elemType := t.Elem()
+		elemSet := setterFor(elemType)
+		return func(field reflect.Value, raw string) error {
+			ptr := reflect.New(elemType)
+			if elemSet != nil {
+				if err := elemSet(ptr.Elem(), raw); err != nil {
+					return err
+				}
+			}
+			field.Set(ptr)
+			return nil
+		}
+	}
+	return nil
+}
+
+func bindFormValues(values map[string][]string, dest any) error {
+	return bindValues(values, dest, "form")
+}
+
+func bindValues(values map[string][]string, dest any, tag string) error {
+	if errs := assignValues(values, dest, tag); len(errs) > 0 {
+		return errs
+	}
+	return validateStruct(dest)
+}
+
+// assignValues mengisi field bertag tanpa menjalankan validasi struct.
+func assignValues(values map[string][]string, dest any, tag string) faults.Errors {
+	v := reflect.ValueOf(dest).Elem()
+	plan := planFor(v.Type(), tag)
+
+	errs := faults.Errors{}
+	for _, f := range plan.fields {
+		val, ok := values[f.key]
+		present := ok && len(val) > 0
+
+		var err error
+		if present && (val[0] != "" || !(f.hasDefault || f.required)) {
+			err = f.set(v.Field(f.index), val[0])
+		} else if f.hasDefault {
+			err = f.set(v.Field(f.index), f.def)
+		} else if f.required {
+			errs[f.key] = faults.ErrRequired
+		}
+		if err != nil {
+			errs[f.key] = faults.ErrInvalidParameter.Render(f.key)
+		}
+	}
+	return errs
+}
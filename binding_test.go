@@ -0,0 +1,63 @@
+package app
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type benchSignup struct {
+	Email    string `json:"email" form:"email" query:"email" validation:"required,email"`
+	Name     string `json:"name" form:"name" query:"name" validation:"required,name,maxlen=64"`
+	Age      int    `json:"age" form:"age" query:"age" validation:"min=17"`
+	Page     int    `form:"page,default=1" query:"page,default=1"`
+	Referrer string `json:"referrer" form:"referrer" query:"referrer" validation:"maxlen=32"`
+}
+
+// BenchmarkBind mengukur Bind, BindQuery, dan BindForm dengan plan binding
+// dan validasi yang sudah ter-cache setelah iterasi pertama.
+func BenchmarkBind(b *testing.B) {
+	values := url.Values{
+		"email":    {"buyer@example.com"},
+		"name":     {"Buyer"},
+		"age":      {"30"},
+		"referrer": {"newsletter"},
+	}
+
+	b.Run("json", func(b *testing.B) {
+		body := []byte(`{"email":"buyer@example.com","name":"Buyer","age":30,"referrer":"newsletter"}`)
+		ctx := &Context{request: httptest.NewRequest("POST", "/signup", nil), body: body, bodyRead: true}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dest benchSignup
+			if err := ctx.Bind(&dest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("query", func(b *testing.B) {
+		ctx := &Context{request: httptest.NewRequest("GET", "/signup?"+values.Encode(), nil)}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var dest benchSignup
+			if err := ctx.BindQuery(&dest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("form", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			r := httptest.NewRequest("POST", "/signup", strings.NewReader(values.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			ctx := &Context{request: r}
+			var dest benchSignup
+			if err := ctx.BindForm(&dest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
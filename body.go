@@ -0,0 +1,98 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/godev90/validator/faults"
+)
+
+// BodyBytes membaca seluruh body request sekali lalu menyimpannya, sehingga
+// middleware (misalnya verifikasi signature) dan Bind bisa sama-sama membacanya.
+// Setelah dipanggil, Request().Body bisa dibaca ulang dari awal.
+func (c *Context) BodyBytes() ([]byte, error) {
+	if c.bodyRead {
+		c.resetBody()
+		return c.body, nil
+	}
+
+	if c.request.Body == nil {
+		c.bodyRead = true
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(c.request.Body)
+	c.request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	c.body = body
+	c.bodyRead = true
+	c.resetBody()
+	return c.body, nil
+}
+
+func (c *Context) resetBody() {
+	c.request.Body = io.NopCloser(bytes.NewReader(c.body))
+}
+
+// postFormValue membaca satu field form dari body lewat BodyBytes, sehingga
+// batas WithMaxBody berlaku dan body tetap bisa dibaca ulang oleh handler,
+// tidak seperti Request().PostFormValue yang menghabiskan body. Content type
+// selain form menghasilkan string kosong.
+func (c *Context) postFormValue(name string) (string, error) {
+	if c.request.PostForm != nil {
+		return c.request.PostForm.Get(name), nil
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(c.request.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		body, err := c.BodyBytes()
+		if err != nil {
+			return "", err
+		}
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", err
+		}
+		return values.Get(name), nil
+
+	case "multipart/form-data":
+		body, err := c.BodyBytes()
+		if err != nil {
+			return "", err
+		}
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				return "", nil
+			}
+			if err != nil {
+				return "", err
+			}
+			if part.FormName() == name && part.FileName() == "" {
+				value, err := io.ReadAll(part)
+				return string(value), err
+			}
+		}
+	}
+	return "", nil
+}
+
+// bodyError menjawab error saat membaca body: 413 jika melewati
+// WithMaxBody, selain itu 400.
+func (c *Context) bodyError(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return c.PayloadTooLarge(faults.ErrPayloadTooLarge)
+	}
+	return c.BadInput(faults.ErrBadRequest)
+}
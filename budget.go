@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	tools "github.com/godev90/netpath/database"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrBudgetExceeded dikembalikan ketika satu dependency sudah menghabiskan
+// jatah waktunya di request ini. Error ini dirender sebagai 504.
+var ErrBudgetExceeded = errors.New("netpath: dependency budget exceeded")
+
+// Budgets adalah jatah waktu per alias dalam satu request, misalnya
+// DB: {"orders": 800 * time.Millisecond}. Jatah dihitung kumulatif untuk
+// semua pemanggilan alias tersebut dan tidak pernah melewati deadline request.
+type Budgets struct {
+	DB    map[string]time.Duration
+	Cache map[string]time.Duration
+}
+
+// WithBudgets memasang jatah waktu yang ditegakkan ctx.DB dan ctx.Cache.
+// Alias tanpa jatah hanya dibatasi deadline request.
+func WithBudgets(b Budgets) Option {
+	return func(app *App) {
+		app.budgets = &b
+	}
+}
+
+// budgetTracker mencatat waktu yang sudah dipakai setiap alias selama request.
+type budgetTracker struct {
+	mu      sync.Mutex
+	limits  *Budgets
+	spent   map[string]time.Duration
+	cancels []context.CancelFunc
+}
+
+func newBudgetTracker(limits *Budgets) *budgetTracker {
+	return &budgetTracker{limits: limits, spent: make(map[string]time.Duration)}
+}
+
+// release membatalkan semua context turunan setelah request selesai.
+func (t *budgetTracker) release() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, cancel := range t.cancels {
+		cancel()
+	}
+	t.cancels = nil
+}
+
+// derive mengembalikan context dengan sisa jatah kind/alias, atau
+// ErrBudgetExceeded jika jatah sudah habis.
+func (t *budgetTracker) derive(parent context.Context, kind, alias string) (context.Context, error) {
+	if t == nil {
+		return parent, nil
+	}
+	limits := t.limits.DB
+	if kind == "cache" {
+		limits = t.limits.Cache
+	}
+	budget, ok := limits[alias]
+	if !ok {
+		return parent, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	remaining := budget - t.spent[kind+":"+alias]
+	if remaining <= 0 {
+		return nil, &budgetError{kind: kind, alias: alias}
+	}
+	ctx, cancel := context.WithTimeout(parent, remaining)
+	t.cancels = append(t.cancels, cancel)
+	return ctx, nil
+}
+
+func (t *budgetTracker) charge(kind, alias string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.spent[kind+":"+alias] += d
+	t.mu.Unlock()
+}
+
+type budgetError struct {
+	kind, alias string
+	err         error
+}
+
+func (e *budgetError) Error() string {
+	return fmt.Sprintf("%s: %s %q", ErrBudgetExceeded, e.kind, e.alias)
+}
+
+func (e *budgetError) Unwrap() []error {
+	errs := []error{ErrBudgetExceeded, faults.ErrGatewayTimeout}
+	if e.err != nil {
+		errs = append(errs, e.err)
+	}
+	return errs
+}
+
+// dependency menjalankan fn dengan context berjatah dan mencatat durasinya.
+// Timeout karena jatah (bukan karena request selesai) diubah menjadi
+// ErrBudgetExceeded.
+func (c *Context) dependency(kind, alias string, fn func(ctx context.Context) error) error {
+	parent := c.request.Context()
+	ctx, err := c.budget.derive(parent, kind, alias)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	err = fn(ctx)
+	c.budget.charge(kind, alias, time.Since(start))
+
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && parent.Err() == nil {
+		return &budgetError{kind: kind, alias: alias, err: err}
+	}
+	return err
+}
+
+// DBAccess menjalankan query ke satu alias database dengan jatah waktunya.
+type DBAccess struct {
+	c     *Context
+	alias string
+}
+
+// DB mengembalikan akses ke alias database yang dibatasi jatah WithBudgets.
+//
+//	rows, err := ctx.DB("orders").Query("SELECT id FROM orders WHERE user_id = ?", uid)
+func (c *Context) DB(alias string) DBAccess {
+	return DBAccess{c: c, alias: alias}
+}
+
+// Exec memanggil tools.Exec (termasuk transaksi WithTransaction).
+func (d DBAccess) Exec(query string, args ...any) (res sql.Result, err error) {
+	err = d.c.dependency("db", d.alias, func(ctx context.Context) error {
+		res, err = tools.Exec(ctx, d.alias, query, args...)
+		return err
+	})
+	return res, err
+}
+
+// Query memanggil tools.Query. Rows harus selesai dibaca sebelum jatah habis.
+func (d DBAccess) Query(query string, args ...any) (rows *tools.Rows, err error) {
+	err = d.c.dependency("db", d.alias, func(ctx context.Context) error {
+		rows, err = tools.Query(ctx, d.alias, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// Do memberi fn context berjatah untuk helper database lain.
+func (d DBAccess) Do(fn func(ctx context.Context) error) error {
+	return d.c.dependency("db", d.alias, fn)
+}
+
+// CacheAccess menjalankan perintah ke satu alias Redis dengan jatah waktunya.
+type CacheAccess struct {
+	c     *Context
+	alias string
+}
+
+// Cache mengembalikan akses ke alias Redis yang dibatasi jatah WithBudgets.
+//
+//	err := ctx.Cache("main").Do(func(ctx context.Context, rdb *redis.Client) error {
+//		return rdb.Set(ctx, key, value, time.Minute).Err()
+//	})
+func (c *Context) Cache(alias string) CacheAccess {
+	return CacheAccess{c: c, alias: alias}
+}
+
+func (a CacheAccess) Do(fn func(ctx context.Context, client *redis.Client) error) error {
+	client, err := cache.Pool().Get(a.alias)
+	if err != nil {
+		return err
+	}
+	return a.c.dependency("cache", a.alias, func(ctx context.Context) error {
+		return fn(ctx, client)
+	})
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache adalah kontrak minimal backend cache key/value. Key yang tidak ada
+// mengembalikan ErrMiss.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type redisCache struct {
+	alias string
+}
+
+// Redis mengembalikan Cache di atas koneksi Redis pada alias.
+func Redis(alias string) Cache {
+	return redisCache{alias: alias}
+}
+
+func (c redisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	client, err := Pool().Get(c.alias)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return val, err
+}
+
+func (c redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	client, err := Pool().Get(c.alias)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c redisCache) Delete(ctx context.Context, key string) error {
+	client, err := Pool().Get(c.alias)
+	if err != nil {
+		return err
+	}
+	return client.Del(ctx, key).Err()
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+type LayeredConfig struct {
+	Alias string // alias Redis
+
+	// LocalSize adalah jumlah key maksimum di LRU lokal. Default 10000.
+	LocalSize int
+	// LocalTTL membatasi umur key di LRU lokal. Default 5 detik.
+	LocalTTL time.Duration
+	// Channel pub/sub untuk invalidasi antar instance. Default "cache:invalidate".
+	Channel string
+}
+
+// Layered adalah Cache dua tingkat: LRU in-process di depan Redis. Set dan
+// Delete menyebarkan invalidasi ke instance lain lewat Redis pub/sub.
+type Layered struct {
+	cfg    LayeredConfig
+	remote Cache
+	id     string
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+
+	cancel context.CancelFunc
+}
+
+type localEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func NewLayered(cfg LayeredConfig) (*Layered, error) {
+	if cfg.LocalSize == 0 {
+		cfg.LocalSize = 10000
+	}
+	if cfg.LocalTTL == 0 {
+		cfg.LocalTTL = 5 * time.Second
+	}
+	if cfg.Channel == "" {
+		cfg.Channel = "cache:invalidate"
+	}
+
+	client, err := Pool().Get(cfg.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Layered{
+		cfg:    cfg,
+		remote: Redis(cfg.Alias),
+		id:     hex.EncodeToString(id),
+		ll:     list.New(),
+		items:  make(map[string]*list.Element),
+		cancel: cancel,
+	}
+
+	sub := client.Subscribe(ctx, cfg.Channel)
+	go func() {
+		defer sub.Close()
+		for msg := range sub.Channel() {
+			// format pesan: "<instance id>|<key>"
+			from, key, ok := strings.Cut(msg.Payload, "|")
+			if !ok || from == c.id {
+				continue
+			}
+			c.evict(key)
+		}
+	}()
+
+	return c, nil
+}
+
+func (c *Layered) Get(ctx context.Context, key string) ([]byte, error) {
+	if v, ok := c.local(key); ok {
+		return v, nil
+	}
+
+	v, err := c.remote.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, v)
+	return v, nil
+}
+
+func (c *Layered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.remote.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.store(key, value)
+	return c.publish(ctx, key)
+}
+
+func (c *Layered) Delete(ctx context.Context, key string) error {
+	c.evict(key)
+	if err := c.remote.Delete(ctx, key); err != nil {
+		return err
+	}
+	return c.publish(ctx, key)
+}
+
+// Close menghentikan langganan invalidasi.
+func (c *Layered) Close() {
+	c.cancel()
+}
+
+func (c *Layered) publish(ctx context.Context, key string) error {
+	client, err := Pool().Get(c.cfg.Alias)
+	if err != nil {
+		return err
+	}
+	if err := client.Publish(ctx, c.cfg.Channel, c.id+"|"+key).Err(); err != nil {
+		log.Printf("[cache] failed to publish invalidation for %s: %v", key, err)
+	}
+	return nil
+}
+
+func (c *Layered) local(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*localEntry)
+	if time.Now().After(entry.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *Layered) store(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(c.cfg.LocalTTL)
+	if el, ok := c.items[key]; ok {
+		entry := el.Value.(*localEntry)
+		entry.value, entry.expires = value, expires
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&localEntry{key: key, value: value, expires: expires})
+	if c.ll.Len() > c.cfg.LocalSize {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*localEntry).key)
+	}
+}
+
+func (c *Layered) evict(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.ll.Remove(el)
+		delete(c.items, key)
+	}
+}
@@ -0,0 +1,251 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type MemcachedConfig struct {
+	Servers []string // host:port
+
+	Timeout time.Duration
+	// MaxIdle adalah jumlah koneksi idle per server. Default 4.
+	MaxIdle int
+	// Replicas adalah jumlah virtual node per server pada ring. Default 160.
+	Replicas int
+}
+
+// Memcached mengimplementasikan Cache di atas protokol teks memcached dengan
+// consistent hashing antar node, sehingga menambah atau melepas satu node
+// hanya memindahkan sebagian kecil key.
+type Memcached struct {
+	cfg   MemcachedConfig
+	ring  []uint32
+	nodes map[uint32]*mcNode
+}
+
+type mcNode struct {
+	addr string
+	idle chan net.Conn
+}
+
+var errMemcachedKey = errors.New("memcached: invalid key")
+
+const maxRelativeExpiry = 30 * 24 * time.Hour
+
+func NewMemcached(cfg MemcachedConfig) (*Memcached, error) {
+	if len(cfg.Servers) == 0 {
+		return nil, errors.New("memcached: no servers")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 500 * time.Millisecond
+	}
+	if cfg.MaxIdle == 0 {
+		cfg.MaxIdle = 4
+	}
+	if cfg.Replicas == 0 {
+		cfg.Replicas = 160
+	}
+
+	m := &Memcached{cfg: cfg, nodes: make(map[uint32]*mcNode)}
+	for _, addr := range cfg.Servers {
+		node := &mcNode{addr: addr, idle: make(chan net.Conn, cfg.MaxIdle)}
+		for i := 0; i < cfg.Replicas; i++ {
+			h := crc32.ChecksumIEEE([]byte(addr + "#" + strconv.Itoa(i)))
+			m.ring = append(m.ring, h)
+			m.nodes[h] = node
+		}
+	}
+	sort.Slice(m.ring, func(i, j int) bool { return m.ring[i] < m.ring[j] })
+	return m, nil
+}
+
+func (m *Memcached) nodeFor(key string) *mcNode {
+	h := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(m.ring), func(i int) bool { return m.ring[i] >= h })
+	if i == len(m.ring) {
+		i = 0
+	}
+	return m.nodes[m.ring[i]]
+}
+
+func validKey(key string) bool {
+	if key == "" || len(key) > 250 {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// do menjalankan fn pada koneksi ke node pemilik key. Koneksi dikembalikan
+// ke pool hanya jika fn berhasil (atau miss), agar state protokol yang rusak
+// tidak terpakai ulang.
+func (m *Memcached) do(ctx context.Context, key string, fn func(rw *bufio.ReadWriter) error) error {
+	if !validKey(key) {
+		return errMemcachedKey
+	}
+	node := m.nodeFor(key)
+
+	var conn net.Conn
+	select {
+	case conn = <-node.idle:
+	default:
+		d := net.Dialer{Timeout: m.cfg.Timeout}
+		c, err := d.DialContext(ctx, "tcp", node.addr)
+		if err != nil {
+			return err
+		}
+		conn = c
+	}
+
+	deadline := time.Now().Add(m.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetDeadline(deadline)
+
+	rw := bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn))
+	if err := fn(rw); err != nil {
+		if errors.Is(err, ErrMiss) {
+			node.put(conn)
+		} else {
+			conn.Close()
+		}
+		return err
+	}
+	node.put(conn)
+	return nil
+}
+
+func (n *mcNode) put(conn net.Conn) {
+	select {
+	case n.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (m *Memcached) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := m.do(ctx, key, func(rw *bufio.ReadWriter) error {
+		fmt.Fprintf(rw, "get %s\r\n", key)
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+
+		line, err := readLine(rw.Reader)
+		if err != nil {
+			return err
+		}
+		if line == "END" {
+			return ErrMiss
+		}
+
+		// VALUE <key> <flags> <bytes>
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[0] != "VALUE" {
+			return fmt.Errorf("memcached: unexpected response %q", line)
+		}
+		size, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return err
+		}
+
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(rw, buf); err != nil {
+			return err
+		}
+		value = buf[:size]
+
+		if line, err = readLine(rw.Reader); err != nil {
+			return err
+		}
+		if line != "END" {
+			return fmt.Errorf("memcached: unexpected response %q", line)
+		}
+		return nil
+	})
+
+	return value, err
+}
+
+// memcachedExpiry mengubah ttl menjadi exptime memcached. Sisa di bawah satu
+// detik dibulatkan ke atas karena 0 berarti tidak pernah kedaluwarsa, dan
+// ttl lebih dari 30 hari dikirim sebagai unix time absolut.
+func memcachedExpiry(ttl time.Duration, now time.Time) int64 {
+	switch {
+	case ttl == 0:
+		return 0
+	case ttl < 0:
+		return -1
+	}
+	if ttl > maxRelativeExpiry {
+		return now.Add(ttl + time.Second - 1).Unix()
+	}
+	return int64((ttl + time.Second - 1) / time.Second)
+}
+
+func (m *Memcached) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	exp := memcachedExpiry(ttl, time.Now())
+
+	return m.do(ctx, key, func(rw *bufio.ReadWriter) error {
+		fmt.Fprintf(rw, "set %s 0 %d %d\r\n", key, exp, len(value))
+		rw.Write(value)
+		rw.WriteString("\r\n")
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+		return expect(rw.Reader, "STORED")
+	})
+}
+
+func (m *Memcached) Delete(ctx context.Context, key string) error {
+	return m.do(ctx, key, func(rw *bufio.ReadWriter) error {
+		fmt.Fprintf(rw, "delete %s\r\n", key)
+		if err := rw.Flush(); err != nil {
+			return err
+		}
+
+		line, err := readLine(rw.Reader)
+		if err != nil {
+			return err
+		}
+		if line != "DELETED" && line != "NOT_FOUND" {
+			return fmt.Errorf("memcached: unexpected response %q", line)
+		}
+		return nil
+	})
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(line, "\r\n")), nil
+}
+
+func expect(r *bufio.Reader, want string) error {
+	line, err := readLine(r)
+	if err != nil {
+		return err
+	}
+	if line != want {
+		return fmt.Errorf("memcached: unexpected response %q", line)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemcachedExpiry(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		ttl  time.Duration
+		want int64
+	}{
+		{0, 0},
+		{-time.Second, -1},
+		{-500 * time.Millisecond, -1},
+		{time.Millisecond, 1},
+		{500 * time.Millisecond, 1},
+		{time.Second, 1},
+		{1500 * time.Millisecond, 2},
+		{time.Hour, 3600},
+		{maxRelativeExpiry, int64(maxRelativeExpiry / time.Second)},
+		{maxRelativeExpiry + time.Second, now.Add(maxRelativeExpiry + time.Second).Unix()},
+		{90 * 24 * time.Hour, now.Add(90 * 24 * time.Hour).Unix()},
+	}
+	for _, tc := range tests {
+		if got := memcachedExpiry(tc.ttl, now); got != tc.want {
+			t.Errorf("memcachedExpiry(%v) = %d, want %d", tc.ttl, got, tc.want)
+		}
+	}
+}
+
+// fakeMemcached menerima satu perintah set dan mengirim baris perintahnya ke lines.
+func fakeMemcached(t *testing.T) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	lines := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		line, _ := r.ReadString('\n')
+		fields := strings.Fields(line)
+		if len(fields) == 5 {
+			n, _ := strconv.Atoi(fields[4])
+			io.CopyN(io.Discard, r, int64(n+2))
+		}
+		lines <- strings.TrimSpace(line)
+		io.WriteString(conn, "STORED\r\n")
+	}()
+	return ln.Addr().String(), lines
+}
+
+func TestMemcachedSetSubSecondTTL(t *testing.T) {
+	addr, lines := fakeMemcached(t)
+	m, err := NewMemcached(MemcachedConfig{Servers: []string{addr}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Set(context.Background(), "k", []byte("value"), 300*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-lines; got != "set k 0 1 5" {
+		t.Fatalf("command = %q, want exptime 1", got)
+	}
+}
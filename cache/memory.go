@@ -0,0 +1,872 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryServer adalah server RESP2 in-process untuk RedisConfig.Memory.
+// Client go-redis terhubung ke server ini lewat loopback, sehingga semua kode
+// yang memakai Pool().Get tetap berjalan tanpa Redis. Perintah yang didukung
+// mencakup string, counter, expiry, set, sorted set, stream (XADD), pub/sub,
+// dan MULTI/EXEC. Lua (EVAL/EVALSHA) tidak didukung.
+type memoryServer struct {
+	ln net.Listener
+
+	mu   sync.Mutex
+	data map[string]*memEntry
+
+	subMu sync.Mutex
+	subs  map[*memConn]struct{}
+
+	done chan struct{}
+}
+
+type memEntry struct {
+	str     []byte
+	set     map[string]struct{}
+	zset    map[string]float64
+	stream  []memStreamEntry
+	expires time.Time
+}
+
+type memStreamEntry struct {
+	id     string
+	fields []string
+}
+
+type memConn struct {
+	net.Conn
+	wmu      sync.Mutex
+	w        *bufio.Writer
+	channels map[string]bool
+	patterns map[string]bool
+	multi    [][]string
+	inMulti  bool
+}
+
+// respStatus dikirim sebagai simple string, respError sebagai error.
+type (
+	respStatus string
+	respError  string
+)
+
+var errWrongType = respError("WRONGTYPE Operation against a key holding the wrong kind of value")
+
+func newMemoryServer() (*memoryServer, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &memoryServer{
+		ln:   ln,
+		data: make(map[string]*memEntry),
+		subs: make(map[*memConn]struct{}),
+		done: make(chan struct{}),
+	}
+	go s.serve()
+	go s.sweep()
+	return s, nil
+}
+
+func (s *memoryServer) Addr() string { return s.ln.Addr().String() }
+
+func (s *memoryServer) Close() error {
+	close(s.done)
+	return s.ln.Close()
+}
+
+func (s *memoryServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(&memConn{Conn: conn, w: bufio.NewWriter(conn)})
+	}
+}
+
+// sweep membuang key kedaluwarsa yang tidak pernah dibaca lagi.
+func (s *memoryServer) sweep() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for k, e := range s.data {
+				if !e.expires.IsZero() && !now.Before(e.expires) {
+					delete(s.data, k)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *memoryServer) handle(c *memConn) {
+	defer func() {
+		s.subMu.Lock()
+		delete(s.subs, c)
+		s.subMu.Unlock()
+		c.Close()
+	}()
+
+	r := bufio.NewReader(c)
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		name := strings.ToUpper(args[0])
+		if name == "QUIT" {
+			c.reply(respStatus("OK"))
+			return
+		}
+		s.dispatch(c, name, args[1:])
+	}
+}
+
+func (s *memoryServer) dispatch(c *memConn, name string, args []string) {
+	switch name {
+	case "SUBSCRIBE", "PSUBSCRIBE", "UNSUBSCRIBE", "PUNSUBSCRIBE":
+		s.subscribe(c, name, args)
+		return
+	case "PING":
+		if len(c.channels)+len(c.patterns) > 0 {
+			msg := ""
+			if len(args) > 0 {
+				msg = args[0]
+			}
+			c.reply([]any{"pong", msg})
+			return
+		}
+	case "MULTI":
+		c.inMulti, c.multi = true, nil
+		c.reply(respStatus("OK"))
+		return
+	case "DISCARD":
+		c.inMulti, c.multi = false, nil
+		c.reply(respStatus("OK"))
+		return
+	case "EXEC":
+		if !c.inMulti {
+			c.reply(respError("ERR EXEC without MULTI"))
+			return
+		}
+		queued := c.multi
+		c.inMulti, c.multi = false, nil
+		s.mu.Lock()
+		results := make([]any, len(queued))
+		for i, cmd := range queued {
+			results[i] = s.exec(strings.ToUpper(cmd[0]), cmd[1:])
+		}
+		s.mu.Unlock()
+		c.reply(results)
+		return
+	}
+
+	if c.inMulti {
+		c.multi = append(c.multi, append([]string{name}, args...))
+		c.reply(respStatus("QUEUED"))
+		return
+	}
+	if name == "PUBLISH" {
+		if len(args) != 2 {
+			c.reply(arityError(name))
+			return
+		}
+		c.reply(s.publish(args[0], args[1]))
+		return
+	}
+
+	s.mu.Lock()
+	res := s.exec(name, args)
+	s.mu.Unlock()
+	c.reply(res)
+}
+
+// exec menjalankan perintah data; s.mu harus sudah dikunci.
+func (s *memoryServer) exec(name string, args []string) any {
+	if n, ok := memArity[name]; ok && len(args) < n {
+		return arityError(name)
+	}
+	now := time.Now()
+
+	switch name {
+	case "PING":
+		if len(args) > 0 {
+			return args[0]
+		}
+		return respStatus("PONG")
+	case "ECHO":
+		return args[0]
+	case "HELLO":
+		// go-redis kembali ke RESP2 jika HELLO ditolak
+		return respError("ERR unknown command 'HELLO'")
+	case "CLIENT", "SELECT", "AUTH":
+		return respStatus("OK")
+	case "FLUSHDB", "FLUSHALL":
+		s.data = make(map[string]*memEntry)
+		return respStatus("OK")
+	case "DBSIZE":
+		n := int64(0)
+		for k := range s.data {
+			if s.get(k, now) != nil {
+				n++
+			}
+		}
+		return n
+	case "KEYS":
+		var keys []any
+		for k := range s.data {
+			if ok, _ := path.Match(args[0], k); ok && s.get(k, now) != nil {
+				keys = append(keys, k)
+			}
+		}
+		return keys
+
+	case "GET":
+		e := s.get(args[0], now)
+		if e == nil {
+			return nil
+		}
+		if e.str == nil {
+			return errWrongType
+		}
+		return e.str
+	case "MGET":
+		res := make([]any, len(args))
+		for i, k := range args {
+			if e := s.get(k, now); e != nil && e.str != nil {
+				res[i] = e.str
+			}
+		}
+		return res
+	case "SET":
+		return s.set(args, now)
+	case "SETNX":
+		if s.get(args[0], now) != nil {
+			return int64(0)
+		}
+		s.data[args[0]] = &memEntry{str: []byte(args[1])}
+		return int64(1)
+	case "INCR", "DECR", "INCRBY", "DECRBY":
+		delta := int64(1)
+		if name == "INCRBY" || name == "DECRBY" {
+			if len(args) < 2 {
+				return arityError(name)
+			}
+			d, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return respError("ERR value is not an integer or out of range")
+			}
+			delta = d
+		}
+		if name == "DECR" || name == "DECRBY" {
+			delta = -delta
+		}
+		e := s.get(args[0], now)
+		if e == nil {
+			e = &memEntry{str: []byte("0")}
+			s.data[args[0]] = e
+		}
+		if e.str == nil {
+			return errWrongType
+		}
+		n, err := strconv.ParseInt(string(e.str), 10, 64)
+		if err != nil {
+			return respError("ERR value is not an integer or out of range")
+		}
+		n += delta
+		e.str = []byte(strconv.FormatInt(n, 10))
+		return n
+	case "DEL", "UNLINK", "EXISTS":
+		n := int64(0)
+		for _, k := range args {
+			if s.get(k, now) != nil {
+				n++
+				if name != "EXISTS" {
+					delete(s.data, k)
+				}
+			}
+		}
+		return n
+	case "EXPIRE", "PEXPIRE":
+		e := s.get(args[0], now)
+		if e == nil {
+			return int64(0)
+		}
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return respError("ERR value is not an integer or out of range")
+		}
+		unit := time.Second
+		if name == "PEXPIRE" {
+			unit = time.Millisecond
+		}
+		expires := now.Add(time.Duration(n) * unit)
+		// key tanpa TTL dianggap TTL tak hingga untuk GT dan LT
+		for _, opt := range args[2:] {
+			var ok bool
+			switch strings.ToUpper(opt) {
+			case "NX":
+				ok = e.expires.IsZero()
+			case "XX":
+				ok = !e.expires.IsZero()
+			case "GT":
+				ok = !e.expires.IsZero() && expires.After(e.expires)
+			case "LT":
+				ok = e.expires.IsZero() || expires.Before(e.expires)
+			default:
+				return respError("ERR Unsupported option " + opt)
+			}
+			if !ok {
+				return int64(0)
+			}
+		}
+		e.expires = expires
+		return int64(1)
+	case "PERSIST":
+		e := s.get(args[0], now)
+		if e == nil || e.expires.IsZero() {
+			return int64(0)
+		}
+		e.expires = time.Time{}
+		return int64(1)
+	case "TTL", "PTTL":
+		e := s.get(args[0], now)
+		switch {
+		case e == nil:
+			return int64(-2)
+		case e.expires.IsZero():
+			return int64(-1)
+		case name == "PTTL":
+			return e.expires.Sub(now).Milliseconds()
+		default:
+			// dibulatkan ke atas seperti Redis
+			return int64((e.expires.Sub(now) + time.Second - 1) / time.Second)
+		}
+
+	case "SADD", "SREM":
+		e := s.get(args[0], now)
+		if e == nil {
+			if name == "SREM" {
+				return int64(0)
+			}
+			e = &memEntry{set: make(map[string]struct{})}
+			s.data[args[0]] = e
+		}
+		if e.set == nil {
+			return errWrongType
+		}
+		n := int64(0)
+		for _, m := range args[1:] {
+			_, exists := e.set[m]
+			switch {
+			case name == "SADD" && !exists:
+				e.set[m] = struct{}{}
+				n++
+			case name == "SREM" && exists:
+				delete(e.set, m)
+				n++
+			}
+		}
+		if len(e.set) == 0 {
+			delete(s.data, args[0])
+		}
+		return n
+	case "SMEMBERS":
+		e := s.get(args[0], now)
+		if e == nil {
+			return []any{}
+		}
+		if e.set == nil {
+			return errWrongType
+		}
+		members := make([]string, 0, len(e.set))
+		for m := range e.set {
+			members = append(members, m)
+		}
+		sort.Strings(members)
+		res := make([]any, len(members))
+		for i, m := range members {
+			res[i] = m
+		}
+		return res
+	case "SISMEMBER":
+		e := s.get(args[0], now)
+		if e == nil || e.set == nil {
+			return int64(0)
+		}
+		if _, ok := e.set[args[1]]; ok {
+			return int64(1)
+		}
+		return int64(0)
+	case "SCARD":
+		e := s.get(args[0], now)
+		if e == nil || e.set == nil {
+			return int64(0)
+		}
+		return int64(len(e.set))
+
+	case "ZADD":
+		return s.zadd(args, now)
+	case "ZREM":
+		e := s.get(args[0], now)
+		if e == nil || e.zset == nil {
+			return int64(0)
+		}
+		n := int64(0)
+		for _, m := range args[1:] {
+			if _, ok := e.zset[m]; ok {
+				delete(e.zset, m)
+				n++
+			}
+		}
+		if len(e.zset) == 0 {
+			delete(s.data, args[0])
+		}
+		return n
+	case "ZCARD":
+		e := s.get(args[0], now)
+		if e == nil || e.zset == nil {
+			return int64(0)
+		}
+		return int64(len(e.zset))
+	case "ZSCORE":
+		e := s.get(args[0], now)
+		if e == nil || e.zset == nil {
+			return nil
+		}
+		score, ok := e.zset[args[1]]
+		if !ok {
+			return nil
+		}
+		return strconv.FormatFloat(score, 'g', -1, 64)
+	case "ZRANGE":
+		return s.zrange(args, now)
+
+	case "XADD":
+		return s.xadd(args, now)
+	case "XLEN":
+		e := s.get(args[0], now)
+		if e == nil {
+			return int64(0)
+		}
+		return int64(len(e.stream))
+
+	case "EVAL", "EVALSHA", "SCRIPT":
+		return respError("ERR scripting is not supported by the in-memory backend")
+	}
+	return respError(fmt.Sprintf("ERR unknown command '%s'", strings.ToLower(name)))
+}
+
+// memArity adalah jumlah argumen minimum per perintah.
+var memArity = map[string]int{
+	"ECHO": 1, "KEYS": 1, "GET": 1, "MGET": 1, "SET": 2, "SETNX": 2,
+	"INCR": 1, "DECR": 1, "INCRBY": 1, "DECRBY": 1,
+	"DEL": 1, "UNLINK": 1, "EXISTS": 1, "EXPIRE": 2, "PEXPIRE": 2, "PERSIST": 1, "TTL": 1, "PTTL": 1,
+	"SADD": 2, "SREM": 2, "SMEMBERS": 1, "SISMEMBER": 2, "SCARD": 1,
+	"ZADD": 3, "ZREM": 2, "ZCARD": 1, "ZSCORE": 2, "ZRANGE": 3, "XADD": 4, "XLEN": 1,
+}
+
+func arityError(name string) respError {
+	return respError(fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name)))
+}
+
+// get mengembalikan entry yang belum kedaluwarsa.
+func (s *memoryServer) get(key string, now time.Time) *memEntry {
+	e, ok := s.data[key]
+	if !ok {
+		return nil
+	}
+	if !e.expires.IsZero() && !now.Before(e.expires) {
+		delete(s.data, key)
+		return nil
+	}
+	return e
+}
+
+// set menangani SET key value [EX s|PX ms|KEEPTTL] [NX|XX] [GET].
+func (s *memoryServer) set(args []string, now time.Time) any {
+	key, value := args[0], args[1]
+	var expires time.Time
+	var nx, xx, keepTTL, get bool
+	for i := 2; i < len(args); i++ {
+		switch opt := strings.ToUpper(args[i]); opt {
+		case "NX":
+			nx = true
+		case "XX":
+			xx = true
+		case "KEEPTTL":
+			keepTTL = true
+		case "GET":
+			get = true
+		case "EX", "PX":
+			if i+1 >= len(args) {
+				return respError("ERR syntax error")
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return respError("ERR invalid expire time in 'set' command")
+			}
+			unit := time.Second
+			if opt == "PX" {
+				unit = time.Millisecond
+			}
+			expires = now.Add(time.Duration(n) * unit)
+			i++
+		default:
+			return respError("ERR syntax error")
+		}
+	}
+
+	old := s.get(key, now)
+	var prev any
+	if old != nil {
+		if get && old.str == nil {
+			return errWrongType
+		}
+		prev = old.str
+	}
+	if (nx && old != nil) || (xx && old == nil) {
+		if get {
+			return prev
+		}
+		return nil
+	}
+	if keepTTL && old != nil {
+		expires = old.expires
+	}
+	s.data[key] = &memEntry{str: []byte(value), expires: expires}
+	if get {
+		return prev
+	}
+	return respStatus("OK")
+}
+
+// zadd menangani ZADD key [NX|XX] [CH] score member [score member ...].
+func (s *memoryServer) zadd(args []string, now time.Time) any {
+	key := args[0]
+	var nx, xx, ch bool
+	i := 1
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i]) {
+		case "NX":
+			nx = true
+			continue
+		case "XX":
+			xx = true
+			continue
+		case "CH":
+			ch = true
+			continue
+		}
+		break
+	}
+	pairs := args[i:]
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return respError("ERR syntax error")
+	}
+
+	e := s.get(key, now)
+	if e == nil {
+		e = &memEntry{zset: make(map[string]float64)}
+	}
+	if e.zset == nil {
+		return errWrongType
+	}
+	n := int64(0)
+	for j := 0; j < len(pairs); j += 2 {
+		score, err := strconv.ParseFloat(pairs[j], 64)
+		if err != nil {
+			return respError("ERR value is not a valid float")
+		}
+		member := pairs[j+1]
+		old, exists := e.zset[member]
+		if (nx && exists) || (xx && !exists) {
+			continue
+		}
+		e.zset[member] = score
+		if !exists || (ch && old != score) {
+			n++
+		}
+	}
+	if len(e.zset) > 0 {
+		s.data[key] = e
+	}
+	return n
+}
+
+// zrange menangani ZRANGE key start stop [REV] [WITHSCORES] berbasis indeks.
+func (s *memoryServer) zrange(args []string, now time.Time) any {
+	start, err1 := strconv.Atoi(args[1])
+	stop, err2 := strconv.Atoi(args[2])
+	if err1 != nil || err2 != nil {
+		return respError("ERR value is not an integer or out of range")
+	}
+	var rev, withScores bool
+	for _, opt := range args[3:] {
+		switch strings.ToUpper(opt) {
+		case "REV":
+			rev = true
+		case "WITHSCORES":
+			withScores = true
+		default:
+			return respError("ERR syntax error")
+		}
+	}
+
+	e := s.get(args[0], now)
+	if e == nil {
+		return []any{}
+	}
+	if e.zset == nil {
+		return errWrongType
+	}
+	members := make([]string, 0, len(e.zset))
+	for m := range e.zset {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		a, b := e.zset[members[i]], e.zset[members[j]]
+		if a != b {
+			return a < b
+		}
+		return members[i] < members[j]
+	})
+	if rev {
+		for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+			members[i], members[j] = members[j], members[i]
+		}
+	}
+
+	n := len(members)
+	if start < 0 {
+		start = max(n+start, 0)
+	}
+	if stop < 0 {
+		stop = n + stop
+	}
+	stop = min(stop, n-1)
+	res := []any{}
+	for i := start; i <= stop; i++ {
+		res = append(res, members[i])
+		if withScores {
+			res = append(res, strconv.FormatFloat(e.zset[members[i]], 'g', -1, 64))
+		}
+	}
+	return res
+}
+
+// xadd menangani XADD key [NOMKSTREAM] [MAXLEN|MINID [=|~] n [LIMIT c]] *|id field value ...
+func (s *memoryServer) xadd(args []string, now time.Time) any {
+	key := args[0]
+	maxLen, noMk := -1, false
+	i := 1
+loop:
+	for i < len(args) {
+		switch strings.ToUpper(args[i]) {
+		case "NOMKSTREAM":
+			noMk = true
+			i++
+		case "MAXLEN", "MINID":
+			minID := strings.ToUpper(args[i]) == "MINID"
+			i++
+			if i < len(args) && (args[i] == "=" || args[i] == "~") {
+				i++
+			}
+			if i >= len(args) {
+				return respError("ERR syntax error")
+			}
+			if !minID {
+				n, err := strconv.Atoi(args[i])
+				if err != nil || n < 0 {
+					return respError("ERR value is not an integer or out of range")
+				}
+				maxLen = n
+			}
+			i++
+			if i+1 < len(args) && strings.ToUpper(args[i]) == "LIMIT" {
+				i += 2
+			}
+		default:
+			break loop
+		}
+	}
+	if i >= len(args) || (len(args)-i-1)%2 != 0 || len(args)-i-1 == 0 {
+		return arityError("XADD")
+	}
+
+	e := s.get(key, now)
+	if e == nil {
+		if noMk {
+			return nil
+		}
+		e = &memEntry{stream: []memStreamEntry{}}
+		s.data[key] = e
+	}
+	if e.stream == nil {
+		return errWrongType
+	}
+
+	id := args[i]
+	if id == "*" {
+		ms, seq := now.UnixMilli(), int64(0)
+		if n := len(e.stream); n > 0 {
+			lastMs, lastSeq, _ := strings.Cut(e.stream[n-1].id, "-")
+			pm, _ := strconv.ParseInt(lastMs, 10, 64)
+			ps, _ := strconv.ParseInt(lastSeq, 10, 64)
+			if pm >= ms {
+				ms, seq = pm, ps+1
+			}
+		}
+		id = fmt.Sprintf("%d-%d", ms, seq)
+	}
+	e.stream = append(e.stream, memStreamEntry{id: id, fields: append([]string(nil), args[i+1:]...)})
+	if maxLen >= 0 && len(e.stream) > maxLen {
+		e.stream = append([]memStreamEntry(nil), e.stream[len(e.stream)-maxLen:]...)
+	}
+	return id
+}
+
+func (s *memoryServer) subscribe(c *memConn, name string, args []string) {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	if c.channels == nil {
+		c.channels, c.patterns = map[string]bool{}, map[string]bool{}
+	}
+	target, kind := c.channels, strings.ToLower(name)
+	if strings.HasPrefix(name, "P") {
+		target = c.patterns
+	}
+
+	unsub := strings.Contains(name, "UNSUB")
+	if unsub && len(args) == 0 {
+		for ch := range target {
+			args = append(args, ch)
+		}
+		sort.Strings(args)
+		if len(args) == 0 {
+			c.reply([]any{kind, nil, int64(len(c.channels) + len(c.patterns))})
+		}
+	}
+	for _, ch := range args {
+		if unsub {
+			delete(target, ch)
+		} else {
+			target[ch] = true
+		}
+		c.reply([]any{kind, ch, int64(len(c.channels) + len(c.patterns))})
+	}
+	if len(c.channels)+len(c.patterns) > 0 {
+		s.subs[c] = struct{}{}
+	} else {
+		delete(s.subs, c)
+	}
+}
+
+func (s *memoryServer) publish(channel, message string) int64 {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	n := int64(0)
+	for c := range s.subs {
+		if c.channels[channel] {
+			c.reply([]any{"message", channel, message})
+			n++
+		}
+		for p := range c.patterns {
+			if ok, _ := path.Match(p, channel); ok {
+				c.reply([]any{"pmessage", p, channel, message})
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// reply menulis v dalam format RESP2. Aman dipanggil dari goroutine lain
+// (publish).
+func (c *memConn) reply(v any) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	writeReply(c.w, v)
+	c.w.Flush()
+}
+
+func writeReply(w *bufio.Writer, v any) {
+	switch v := v.(type) {
+	case nil:
+		w.WriteString("$-1\r\n")
+	case respStatus:
+		w.WriteString("+" + string(v) + "\r\n")
+	case respError:
+		w.WriteString("-" + string(v) + "\r\n")
+	case int64:
+		w.WriteString(":" + strconv.FormatInt(v, 10) + "\r\n")
+	case string:
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(v), v)
+	case []byte:
+		fmt.Fprintf(w, "$%d\r\n", len(v))
+		w.Write(v)
+		w.WriteString("\r\n")
+	case []any:
+		fmt.Fprintf(w, "*%d\r\n", len(v))
+		for _, item := range v {
+			writeReply(w, item)
+		}
+	}
+}
+
+// readCommand membaca satu perintah RESP (array bulk string) atau perintah
+// inline.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, errors.New("cache: invalid multibulk length")
+	}
+	args := make([]string, 0, n)
+	for range n {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, errors.New("cache: expected bulk string")
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, errors.New("cache: invalid bulk length")
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args = append(args, string(buf[:size]))
+	}
+	return args, nil
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultAlias dipakai Namespace jika alias tidak diganti lewat WithAlias.
+const DefaultAlias = "default"
+
+// ErrMiss dikembalikan jika key tidak ada di cache.
+var ErrMiss = errors.New("cache miss")
+
+// Space mengelompokkan key di bawah satu namespace dengan nomor versi.
+// Invalidate cukup menaikkan versi sehingga semua key lama tidak terbaca
+// lagi dan habis dengan TTL-nya sendiri, tanpa SCAN/DEL.
+type Space struct {
+	name  string
+	alias string
+}
+
+// Namespace membuat Space, misalnya cache.Namespace("catalog").
+func Namespace(name string) *Space {
+	return &Space{name: name, alias: DefaultAlias}
+}
+
+// WithAlias mengembalikan salinan Space yang memakai koneksi Redis lain.
+func (s *Space) WithAlias(alias string) *Space {
+	return &Space{name: s.name, alias: alias}
+}
+
+func (s *Space) client() (*redis.Client, error) {
+	return Pool().Get(s.alias)
+}
+
+func (s *Space) versionKey() string {
+	return "ns:" + s.name + ":version"
+}
+
+func (s *Space) tagKey(tag string) string {
+	return "ns:" + s.name + ":tag:" + tag
+}
+
+// Key mengembalikan key Redis lengkap untuk versi namespace saat ini.
+func (s *Space) Key(ctx context.Context, key string) (string, error) {
+	client, err := s.client()
+	if err != nil {
+		return "", err
+	}
+
+	version, err := client.Get(ctx, s.versionKey()).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", err
+	}
+	return fmt.Sprintf("%s:v%d:%s", s.name, version, key), nil
+}
+
+func (s *Space) Get(ctx context.Context, key string) ([]byte, error) {
+	client, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	full, err := s.Key(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := client.Get(ctx, full).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return val, err
+}
+
+// Set menyimpan value; tags opsional dipakai InvalidateTag.
+func (s *Space) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+	full, err := s.Key(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	// TTL tag set hanya boleh diperpanjang: key lain di tag yang umurnya lebih
+	// panjang (atau tanpa TTL) tidak boleh lepas dari tag-nya lebih dulu.
+	var tagTTLs []*redis.DurationCmd
+	if len(tags) > 0 && ttl > 0 {
+		pipe := client.Pipeline()
+		for _, tag := range tags {
+			tagTTLs = append(tagTTLs, pipe.PTTL(ctx, s.tagKey(tag)))
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	pipe := client.TxPipeline()
+	pipe.Set(ctx, full, value, ttl)
+	for i, tag := range tags {
+		tagKey := s.tagKey(tag)
+		pipe.SAdd(ctx, tagKey, full)
+		switch {
+		case ttl <= 0:
+			pipe.Persist(ctx, tagKey)
+		case tagTTLs[i].Val() == -2: // tag set baru
+			pipe.ExpireNX(ctx, tagKey, ttl)
+		case tagTTLs[i].Val() != -1: // -1: tag set tanpa TTL dibiarkan
+			pipe.ExpireGT(ctx, tagKey, ttl)
+		}
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (s *Space) Delete(ctx context.Context, key string) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+	full, err := s.Key(ctx, key)
+	if err != nil {
+		return err
+	}
+	return client.Del(ctx, full).Err()
+}
+
+// Invalidate membuang semua key di namespace dengan menaikkan versinya.
+func (s *Space) Invalidate(ctx context.Context) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+	return client.Incr(ctx, s.versionKey()).Err()
+}
+
+// InvalidateTag menghapus semua key yang disimpan dengan tag tersebut.
+func (s *Space) InvalidateTag(ctx context.Context, tags ...string) error {
+	client, err := s.client()
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		keys, err := client.SMembers(ctx, s.tagKey(tag)).Result()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, s.tagKey(tag))
+		if err := client.Del(ctx, keys...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
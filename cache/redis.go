@@ -21,11 +21,19 @@ type RedisConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	PoolSize     int
+
+	// Memory menjalankan backend in-memory kompatibel Redis di dalam proses
+	// sebagai ganti server Redis di Addr, untuk development lokal dan CI.
+	// Data hilang saat proses berhenti dan Lua script (EVAL) tidak
+	// didukung, sehingga fitur seperti LoginGuard dan quota tetap butuh
+	// Redis sungguhan.
+	Memory bool
 }
 
 type cachePool struct {
-	pool map[string]*redis.Client
-	mu   sync.RWMutex
+	pool   map[string]*redis.Client
+	memory map[string]*memoryServer
+	mu     sync.RWMutex
 }
 
 var (
@@ -36,7 +44,8 @@ var (
 func Pool() *cachePool {
 	once.Do(func() {
 		pool = &cachePool{
-			pool: make(map[string]*redis.Client),
+			pool:   make(map[string]*redis.Client),
+			memory: make(map[string]*memoryServer),
 		}
 	})
 
@@ -64,6 +73,15 @@ func (rc *cachePool) Connect(alias string, cfg RedisConfig) error {
 		cfg.PoolSize = 10
 	}
 
+	var mem *memoryServer
+	if cfg.Memory {
+		var err error
+		if mem, err = newMemoryServer(); err != nil {
+			return fmt.Errorf("failed to start in-memory Redis [%s]: %w", alias, err)
+		}
+		cfg.Addr, cfg.Username, cfg.Password = mem.Addr(), "", ""
+	}
+
 	client := redis.NewClient(&redis.Options{
 		Addr:         cfg.Addr,
 		Username:     cfg.Username,
@@ -76,10 +94,19 @@ func (rc *cachePool) Connect(alias string, cfg RedisConfig) error {
 	})
 
 	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		if mem != nil {
+			mem.Close()
+		}
 		return fmt.Errorf("failed to connect to Redis [%s]: %w", alias, err)
 	}
 
 	rc.pool[alias] = client
+	if mem != nil {
+		rc.memory[alias] = mem
+		log.Printf("Connected to in-memory Redis [%s]", alias)
+		return nil
+	}
 	log.Printf("Connected to Redis [%s]", alias)
 	return nil
 }
@@ -94,3 +121,33 @@ func (rc *cachePool) Get(alias string) (*redis.Client, error) {
 	}
 	return client, nil
 }
+
+// Disconnect menutup client alias dan menghapusnya dari pool.
+func (rc *cachePool) Disconnect(alias string) error {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	client, ok := rc.pool[alias]
+	if !ok {
+		return nil
+	}
+	delete(rc.pool, alias)
+	err := client.Close()
+	if mem := rc.memory[alias]; mem != nil {
+		delete(rc.memory, alias)
+		mem.Close()
+	}
+	return err
+}
+
+// Stats mengembalikan statistik connection pool untuk setiap alias.
+func (rc *cachePool) Stats() map[string]*redis.PoolStats {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	stats := make(map[string]*redis.PoolStats, len(rc.pool))
+	for alias, client := range rc.pool {
+		stats[alias] = client.PoolStats()
+	}
+	return stats
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheDirective adalah directive tambahan untuk Cache-Control.
+type CacheDirective string
+
+const (
+	CachePublic          CacheDirective = "public"
+	CachePrivate         CacheDirective = "private"
+	CacheNoCache         CacheDirective = "no-cache"
+	CacheNoStore         CacheDirective = "no-store"
+	CacheMustRevalidate  CacheDirective = "must-revalidate"
+	CacheImmutable       CacheDirective = "immutable"
+	CacheNoTransform     CacheDirective = "no-transform"
+	CacheProxyRevalidate CacheDirective = "proxy-revalidate"
+)
+
+// StaleWhileRevalidate membolehkan cache memakai response basi selama d
+// sambil memperbarui di latar belakang.
+func StaleWhileRevalidate(d time.Duration) CacheDirective {
+	return CacheDirective("stale-while-revalidate=" + strconv.Itoa(int(d.Seconds())))
+}
+
+func cacheControlValue(maxAge time.Duration, directives []CacheDirective) string {
+	parts := make([]string, 0, len(directives)+1)
+	for _, d := range directives {
+		parts = append(parts, string(d))
+	}
+	if maxAge > 0 && !contains(parts, string(CacheNoStore)) {
+		parts = append(parts, "max-age="+strconv.Itoa(int(maxAge.Seconds())))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CacheControl mengisi header Cache-Control, misalnya
+// ctx.CacheControl(time.Hour, CachePublic). maxAge 0 tidak menulis max-age.
+func (c *Context) CacheControl(maxAge time.Duration, directives ...CacheDirective) {
+	c.writer.Header().Set("Cache-Control", cacheControlValue(maxAge, directives))
+}
+
+// WithCachePolicy memasang Cache-Control default untuk route atau group.
+// Handler yang mengisi Cache-Control sendiri tetap diutamakan.
+func WithCachePolicy(maxAge time.Duration, directives ...CacheDirective) MiddlewareFunc {
+	value := cacheControlValue(maxAge, directives)
+
+	mw := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			ctx.BeforeWrite(func(status int, header http.Header) {
+				if header.Get("Cache-Control") == "" {
+					header.Set("Cache-Control", value)
+				}
+			})
+			return next(ctx)
+		}
+	}
+	return describe(mw, func(m *RouteMeta) { m.CacheControl = value })
+}
+
+// NoStore adalah kebijakan untuk group yang membutuhkan autentikasi:
+// response tidak boleh disimpan cache mana pun.
+func NoStore() MiddlewareFunc {
+	return WithCachePolicy(0, CacheNoStore, CachePrivate)
+}
+
+// PublicCache adalah kebijakan untuk konten statis atau publik.
+func PublicCache(maxAge time.Duration) MiddlewareFunc {
+	return WithCachePolicy(maxAge, CachePublic)
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+)
+
+// HandlerVersion adalah salah satu implementasi handler untuk sebuah route.
+// Versi dengan When dicek lebih dulu sesuai urutan; jika tidak ada yang cocok,
+// versi dipilih berdasarkan Weight.
+type HandlerVersion struct {
+	Name    string
+	Handler HandlerFunc
+	Weight  int
+	When    func(*Context) bool
+}
+
+// Versions menggabungkan beberapa versi handler menjadi satu HandlerFunc untuk
+// rollout bertahap:
+//
+//	r.GET("/orders", netpath.Versions(
+//		netpath.HandlerVersion{Name: "v2", Handler: listOrdersV2, When: netpath.HeaderIs("X-Canary", "1")},
+//		netpath.HandlerVersion{Name: "v1", Handler: listOrders, Weight: 90},
+//		netpath.HandlerVersion{Name: "v2", Handler: listOrdersV2, Weight: 10},
+//	))
+//
+// Jika request membawa session, pilihan berbobot konsisten untuk Identifier yang sama.
+func Versions(versions ...HandlerVersion) HandlerFunc {
+	var (
+		weighted []HandlerVersion
+		total    int
+	)
+	for _, v := range versions {
+		if v.When == nil && v.Weight > 0 {
+			weighted = append(weighted, v)
+			total += v.Weight
+		}
+	}
+
+	return func(ctx *Context) error {
+		for _, v := range versions {
+			if v.When != nil && v.When(ctx) {
+				ctx.AddBreadcrumb("version", v.Name)
+				return v.Handler(ctx)
+			}
+		}
+		if total == 0 {
+			return ctx.NotFound(errNoHandlerVersion)
+		}
+
+		var n int
+		if sess := ctx.Session(); sess != nil {
+			h := fnv.New32a()
+			h.Write([]byte(sess.Identifier()))
+			n = int(h.Sum32() % uint32(total))
+		} else {
+			n = rand.IntN(total)
+		}
+
+		for _, v := range weighted {
+			if n < v.Weight {
+				ctx.AddBreadcrumb("version", v.Name)
+				return v.Handler(ctx)
+			}
+			n -= v.Weight
+		}
+		return nil
+	}
+}
+
+// HeaderIs adalah predicate When yang cocok jika header request bernilai value.
+func HeaderIs(name, value string) func(*Context) bool {
+	return func(ctx *Context) bool {
+		return ctx.request.Header.Get(name) == value
+	}
+}
+
+// SessionIs adalah predicate When berdasarkan session yang sedang aktif.
+func SessionIs(match func(Session) bool) func(*Context) bool {
+	return func(ctx *Context) bool {
+		return ctx.session != nil && match(ctx.session)
+	}
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeCaptcha struct {
+	remoteIPs []string
+}
+
+func (p *fakeCaptcha) Name() string  { return "fake" }
+func (p *fakeCaptcha) Field() string { return "captcha-response" }
+
+func (p *fakeCaptcha) Verify(ctx context.Context, token, remoteIP string) (CaptchaResult, error) {
+	p.remoteIPs = append(p.remoteIPs, remoteIP)
+	return CaptchaResult{Success: token == "good"}, nil
+}
+
+func TestCaptcha(t *testing.T) {
+	provider := &fakeCaptcha{}
+	app := New(WithTrustedProxies("10.0.0.0/8"))
+	app.Route().POST("/signup", func(ctx *Context) error {
+		return ctx.Success(nil)
+	}, WithCaptcha(CaptchaConfig{Provider: provider}))
+
+	tests := []struct {
+		name    string
+		header  string
+		form    string
+		want    int
+		checked bool
+	}{
+		{name: "missing token", want: http.StatusBadRequest},
+		{name: "rejected token", header: "bad", want: http.StatusBadRequest, checked: true},
+		{name: "header token", header: "good", want: http.StatusOK, checked: true},
+		{name: "form token", form: "captcha-response=good&name=a", want: http.StatusOK, checked: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			provider.remoteIPs = nil
+			r := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(tc.form))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.RemoteAddr = "10.0.0.1:5000"
+			r.Header.Set("X-Forwarded-For", "203.0.113.5")
+			if tc.header != "" {
+				r.Header.Set("X-Captcha-Token", tc.header)
+			}
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.want {
+				t.Fatalf("status %d, want %d", w.Code, tc.want)
+			}
+			if !tc.checked {
+				if len(provider.remoteIPs) != 0 {
+					t.Fatal("provider called without a token")
+				}
+				return
+			}
+			if len(provider.remoteIPs) != 1 || provider.remoteIPs[0] != "203.0.113.5" {
+				t.Fatalf("provider saw remote IPs %v, want the client behind the proxy", provider.remoteIPs)
+			}
+		})
+	}
+}
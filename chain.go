@@ -0,0 +1,26 @@
+package app
+
+type compiledChain struct {
+	gen     uint64
+	handler HandlerFunc
+}
+
+// compiled mengembalikan chain lengkap untuk route. Chain disusun sekali dan
+// disimpan di routeEntry, lalu disusun ulang hanya jika App.Use dipanggil lagi.
+func (app *App) compiled(e *routeEntry) HandlerFunc {
+	gen := app.mwGen.Load()
+	if c := e.compiled.Load(); c != nil && c.gen == gen {
+		return c.handler
+	}
+
+	h := app.wrapGlobal(e.chain)
+	e.compiled.Store(&compiledChain{gen: gen, handler: h})
+	return h
+}
+
+func (app *App) wrapGlobal(h HandlerFunc) HandlerFunc {
+	for i := len(app.mw) - 1; i >= 0; i-- {
+		h = app.mw[i](h)
+	}
+	return h
+}
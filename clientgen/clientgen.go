@@ -0,0 +1,263 @@
+// Package clientgen membuat client Go dan TypeScript serta dokumen OpenAPI dari
+// tabel route App. Hanya route yang didaftarkan lewat netpath.Handle (yang tipe
+// request dan response-nya diketahui) yang dibuatkan method client.
+//
+// Generator dijalankan dari program kecil milik aplikasi, misalnya:
+//
+//	//go:generate go run ./cmd/genclient
+//
+//	func main() {
+//		app := server.NewApp()
+//		src, err := clientgen.Go(app.Routes(), clientgen.Options{Package: "orderclient"})
+//		...
+//		os.WriteFile("orderclient/client.go", src, 0o644)
+//	}
+package clientgen
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	path "github.com/godev90/netpath"
+)
+
+type Options struct {
+	// Package adalah nama package Go yang dihasilkan. Default "client".
+	Package string
+}
+
+type endpoint struct {
+	name     string
+	method   string
+	pattern  string
+	segments []segment
+	request  reflect.Type
+	response reflect.Type
+	hasBody  bool
+	query    []field
+}
+
+type segment struct {
+	static string
+	field  *field // parameter path
+	rest   bool   // wildcard *name
+}
+
+type field struct {
+	goName string
+	key    string
+}
+
+// endpoints mengubah route bertipe menjadi daftar endpoint terurut.
+func endpoints(routes []path.RouteInfo) ([]endpoint, error) {
+	var list []endpoint
+	seen := map[string]string{}
+	for _, r := range routes {
+		if r.Request == nil || r.Response == nil {
+			continue
+		}
+
+		ep := endpoint{
+			name:     methodName(r.Method, r.Pattern),
+			method:   r.Method,
+			pattern:  r.Pattern,
+			request:  r.Request,
+			response: r.Response,
+			query:    taggedFields(r.Request, "query"),
+		}
+		switch r.Method {
+		case "GET", "HEAD", "DELETE":
+		default:
+			ep.hasBody = true
+		}
+		if prev, ok := seen[ep.name]; ok {
+			return nil, fmt.Errorf("clientgen: %s %s and %s map to the same method %s", r.Method, r.Pattern, prev, ep.name)
+		}
+		seen[ep.name] = r.Method + " " + r.Pattern
+
+		pathFields := taggedFields(r.Request, "path")
+		for _, seg := range strings.Split(strings.Trim(r.Pattern, "/"), "/") {
+			if seg == "" {
+				continue
+			}
+			if seg[0] != ':' && seg[0] != '*' {
+				ep.segments = append(ep.segments, segment{static: seg})
+				continue
+			}
+
+			name := seg[1:]
+			var f *field
+			for i := range pathFields {
+				if pathFields[i].key == name {
+					f = &pathFields[i]
+				}
+			}
+			if f == nil {
+				return nil, fmt.Errorf("clientgen: %s %s: %s has no field tagged path:%q", r.Method, r.Pattern, r.Request, name)
+			}
+			ep.segments = append(ep.segments, segment{field: f, rest: seg[0] == '*'})
+		}
+		list = append(list, ep)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	return list, nil
+}
+
+// pathExpr menyusun ekspresi string path, misalnya "/users/" + id. Segmen
+// static yang berurutan digabung; param menghasilkan ekspresi segmen parameter.
+func pathExpr(segments []segment, param func(segment) string) string {
+	var parts []string
+	static := ""
+	for _, seg := range segments {
+		static += "/"
+		if seg.field == nil {
+			static += seg.static
+			continue
+		}
+		parts = append(parts, strconv.Quote(static), param(seg))
+		static = ""
+	}
+	if static != "" || len(parts) == 0 {
+		if static == "" {
+			static = "/"
+		}
+		parts = append(parts, strconv.Quote(static))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func taggedFields(t reflect.Type, tag string) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if key != "" {
+			fields = append(fields, field{goName: sf.Name, key: key})
+		}
+	}
+	return fields
+}
+
+var initialisms = map[string]string{"id": "ID", "url": "URL", "api": "API", "http": "HTTP", "uuid": "UUID", "json": "JSON"}
+
+// methodName membentuk nama seperti GetUsersByID dari GET /users/:id.
+func methodName(method, pattern string) string {
+	var b strings.Builder
+	b.WriteString(exportName(strings.ToLower(method)))
+	for _, seg := range strings.Split(pattern, "/") {
+		if seg == "" {
+			continue
+		}
+		if seg[0] == ':' || seg[0] == '*' {
+			b.WriteString("By")
+			seg = seg[1:]
+		}
+		b.WriteString(exportName(seg))
+	}
+	return b.String()
+}
+
+// exportName mengubah "order-items" atau "order_items" menjadi "OrderItems".
+func exportName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, w := range words {
+		if up, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(up)
+			continue
+		}
+		runes := []rune(w)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// lowerFirst dipakai untuk nama method TypeScript.
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 0 {
+		runes[0] = unicode.ToLower(runes[0])
+	}
+	return string(runes)
+}
+
+// jsonName mengembalikan nama field di JSON, "" jika field tidak dikirim,
+// dan apakah field boleh kosong (omitempty).
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		if sf.Tag.Get("path") != "" || sf.Tag.Get("query") != "" {
+			return "", false
+		}
+		name = sf.Name
+	}
+	return name, strings.Contains(opts, "omitempty")
+}
+
+// typeID adalah nama tipe yang aman dipakai sebagai identifier, termasuk
+// untuk tipe generic seperti Page[main.Order].
+func typeID(t reflect.Type) string {
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		args := name[i+1 : len(name)-1]
+		name = name[:i]
+		for _, arg := range strings.Split(args, ",") {
+			if j := strings.LastIndexByte(arg, '.'); j >= 0 {
+				arg = arg[j+1:]
+			}
+			name += exportName(arg)
+		}
+	}
+	return name
+}
+
+// isStdlib melaporkan apakah tipe berasal dari standard library, yang
+// direferensikan langsung alih-alih didefinisikan ulang. Package dicari di
+// GOROOT, sehingga tipe dari package main atau module tanpa titik (misalnya
+// "myapp/models") tetap didefinisikan ulang.
+func isStdlib(t reflect.Type) bool {
+	pkg := t.PkgPath()
+	if pkg == "" || pkg == "main" {
+		return false
+	}
+	if v, ok := stdlibPkgs.Load(pkg); ok {
+		return v.(bool)
+	}
+
+	var std bool
+	if goroot := filepath.Join(build.Default.GOROOT, "src"); dirExists(goroot) {
+		std = dirExists(filepath.Join(goroot, filepath.FromSlash(pkg)))
+	} else {
+		// generator berjalan tanpa GOROOT: tebak dari path tanpa domain
+		first, _, _ := strings.Cut(pkg, "/")
+		std = !strings.Contains(first, ".")
+	}
+	stdlibPkgs.Store(pkg, std)
+	return std
+}
+
+var stdlibPkgs sync.Map
+
+func dirExists(dir string) bool {
+	fi, err := os.Stat(dir)
+	return err == nil && fi.IsDir()
+}
@@ -0,0 +1,286 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"sort"
+	"strings"
+
+	path "github.com/godev90/netpath"
+)
+
+type goGen struct {
+	imports map[string]bool
+	defined map[reflect.Type]string
+	queue   []reflect.Type
+	names   map[string]reflect.Type
+}
+
+// Go menghasilkan source client Go yang sudah di-gofmt. Tipe request dan
+// response didefinisikan ulang di package hasil dengan tag json, path, dan
+// query yang sama; tipe standard library seperti time.Time dipakai langsung.
+func Go(routes []path.RouteInfo, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		opts.Package = "client"
+	}
+	eps, err := endpoints(routes)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &goGen{
+		imports: map[string]bool{},
+		defined: map[reflect.Type]string{},
+		names:   map[string]reflect.Type{},
+	}
+
+	var methods bytes.Buffer
+	for _, ep := range eps {
+		if err := g.method(&methods, ep); err != nil {
+			return nil, err
+		}
+	}
+
+	var types bytes.Buffer
+	for len(g.queue) > 0 {
+		t := g.queue[0]
+		g.queue = g.queue[1:]
+		g.define(&types, t)
+	}
+
+	for _, pkg := range []string{"bytes", "context", "encoding/json", "fmt", "net/http", "net/url", "reflect"} {
+		g.imports[pkg] = true
+	}
+	pkgs := make([]string, 0, len(g.imports))
+	for pkg := range g.imports {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by netpath clientgen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", opts.Package)
+	for _, pkg := range pkgs {
+		fmt.Fprintf(&out, "\t%q\n", pkg)
+	}
+	out.WriteString(")\n")
+	out.WriteString(goRuntime)
+	out.Write(methods.Bytes())
+	out.Write(types.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("clientgen: format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *goGen) method(w *bytes.Buffer, ep endpoint) error {
+	reqType := g.typeName(ep.request)
+	resType := g.typeName(ep.response)
+
+	fmt.Fprintf(w, "\n// %s memanggil %s %s.\n", ep.name, ep.method, ep.pattern)
+	fmt.Fprintf(w, "func (c *Client) %s(ctx context.Context, req %s) (%s, error) {\n", ep.name, reqType, resType)
+	fmt.Fprintf(w, "\tvar res %s\n", resType)
+
+	path := pathExpr(ep.segments, func(seg segment) string {
+		if seg.rest {
+			return "fmt.Sprint(req." + seg.field.goName + ")"
+		}
+		return "url.PathEscape(fmt.Sprint(req." + seg.field.goName + "))"
+	})
+	fmt.Fprintf(w, "\tpath := %s\n", path)
+	w.WriteString("\tquery := url.Values{}\n")
+	for _, q := range ep.query {
+		fmt.Fprintf(w, "\taddQuery(query, %q, req.%s)\n", q.key, q.goName)
+	}
+
+	body := "nil"
+	if ep.hasBody {
+		body = "req"
+	}
+	fmt.Fprintf(w, "\terr := c.do(ctx, %q, path, query, %s, &res)\n\treturn res, err\n}\n", ep.method, body)
+	return nil
+}
+
+// typeName mengembalikan ekspresi tipe Go untuk t dan mengantrikan definisi
+// tipe bernama milik aplikasi.
+func (g *goGen) typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		switch {
+		case t.PkgPath() == "":
+			return t.Name()
+		case isStdlib(t):
+			g.imports[t.PkgPath()] = true
+			pkg := t.PkgPath()[strings.LastIndexByte(t.PkgPath(), '/')+1:]
+			return pkg + "." + t.Name()
+		}
+		if name, ok := g.defined[t]; ok {
+			return name
+		}
+		name := typeID(t)
+		if other, ok := g.names[name]; ok && other != t {
+			name += fmt.Sprint(len(g.defined))
+		}
+		g.names[name] = t
+		g.defined[t] = name
+		g.queue = append(g.queue, t)
+		return name
+	}
+	return g.underlying(t)
+}
+
+func (g *goGen) underlying(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return "*" + g.typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + g.typeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), g.typeName(t.Elem()))
+	case reflect.Map:
+		return "map[" + g.typeName(t.Key()) + "]" + g.typeName(t.Elem())
+	case reflect.Interface:
+		return "any"
+	case reflect.Struct:
+		var b strings.Builder
+		b.WriteString("struct {\n")
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			if sf.Anonymous {
+				fmt.Fprintf(&b, "\t%s\n", g.typeName(sf.Type))
+				continue
+			}
+			fmt.Fprintf(&b, "\t%s %s%s\n", sf.Name, g.typeName(sf.Type), fieldTag(sf))
+		}
+		b.WriteString("}")
+		return b.String()
+	}
+	return t.Kind().String()
+}
+
+func (g *goGen) define(w *bytes.Buffer, t reflect.Type) {
+	fmt.Fprintf(w, "\ntype %s %s\n", g.defined[t], g.underlying(t))
+}
+
+// fieldTag menyalin tag json, path, dan query; field yang hanya untuk path
+// atau query tidak ikut dikirim di body.
+func fieldTag(sf reflect.StructField) string {
+	var parts []string
+	jsonTag, hasJSON := sf.Tag.Lookup("json")
+	for _, key := range []string{"path", "query"} {
+		if v, ok := sf.Tag.Lookup(key); ok {
+			parts = append(parts, fmt.Sprintf("%s:%q", key, v))
+			if !hasJSON {
+				jsonTag, hasJSON = "-", true
+			}
+		}
+	}
+	if hasJSON {
+		parts = append([]string{fmt.Sprintf("json:%q", jsonTag)}, parts...)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " `" + strings.Join(parts, " ") + "`"
+}
+
+const goRuntime = `
+// Client memanggil service netpath. Header dikirim di setiap request,
+// misalnya Authorization.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Header     http.Header
+}
+
+func New(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient, Header: http.Header{}}
+}
+
+// Error adalah response non-2xx dari service.
+type Error struct {
+	Status int             ` + "`json:\"-\"`" + `
+	Code   int             ` + "`json:\"code\"`" + `
+	Data   json.RawMessage ` + "`json:\"data\"`" + `
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("netpath: status %d: %s", e.Status, e.Data)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader *bytes.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(raw)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var env struct {
+		Code int             ` + "`json:\"code\"`" + `
+		Data json.RawMessage ` + "`json:\"data\"`" + `
+	}
+	if err := json.NewDecoder(res.Body).Decode(&env); err != nil && res.StatusCode < 300 {
+		return err
+	}
+	if res.StatusCode >= 300 {
+		return &Error{Status: res.StatusCode, Code: env.Code, Data: env.Data}
+	}
+	if len(env.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(env.Data, out)
+}
+
+func addQuery(q url.Values, key string, v any) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.IsZero() {
+		return
+	}
+	for rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Slice {
+		for i := 0; i < rv.Len(); i++ {
+			q.Add(key, fmt.Sprint(rv.Index(i).Interface()))
+		}
+		return
+	}
+	q.Add(key, fmt.Sprint(rv.Interface()))
+}
+`
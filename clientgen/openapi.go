@@ -0,0 +1,267 @@
+package clientgen
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	path "github.com/godev90/netpath"
+)
+
+type OpenAPIOptions struct {
+	Title   string // default "API"
+	Version string // default "1.0.0"
+	// SecuritySchemes mengisi components.securitySchemes per nama skema
+	// RequireAuth, misalnya {"bearer": {"type": "http", "scheme": "bearer"}}.
+	SecuritySchemes map[string]any
+}
+
+type openapiGen struct {
+	schemas map[string]any
+	defined map[reflect.Type]string
+}
+
+// OpenAPI menghasilkan dokumen OpenAPI 3 (JSON) dari tabel route. Semua
+// route dimasukkan; schema request dan response hanya tersedia untuk route
+// netpath.Handle. Metadata route (netpath.RouteMeta) ditulis sebagai
+// extension supaya konfigurasi gateway bisa dibuat dari dokumen ini:
+//
+//	x-netpath-cache:       {"cache-control": "public, max-age=60"}
+//	x-netpath-compression: ["br", "gzip"]
+//	x-netpath-rate-limit:  [{"name": "search", "limit": 100, "per": "1m0s", "per_seconds": 60}]
+//	x-netpath-auth:        ["bearer"]
+//
+// Skema auth juga ditulis sebagai security standar OpenAPI.
+func OpenAPI(routes []path.RouteInfo, opts OpenAPIOptions) ([]byte, error) {
+	if opts.Title == "" {
+		opts.Title = "API"
+	}
+	if opts.Version == "" {
+		opts.Version = "1.0.0"
+	}
+	g := &openapiGen{schemas: map[string]any{}, defined: map[reflect.Type]string{}}
+
+	sorted := append([]path.RouteInfo(nil), routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Pattern != sorted[j].Pattern {
+			return sorted[i].Pattern < sorted[j].Pattern
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	paths := map[string]map[string]any{}
+	schemes := map[string]bool{}
+	for _, r := range sorted {
+		p := openapiPath(r.Pattern)
+		if paths[p] == nil {
+			paths[p] = map[string]any{}
+		}
+		paths[p][strings.ToLower(r.Method)] = g.operation(r)
+		for _, s := range r.Meta.Auth {
+			schemes[s] = true
+		}
+	}
+
+	components := map[string]any{}
+	if len(g.schemas) > 0 {
+		components["schemas"] = g.schemas
+	}
+	if len(schemes) > 0 {
+		security := map[string]any{}
+		for s := range schemes {
+			switch def, ok := opts.SecuritySchemes[s]; {
+			case ok:
+				security[s] = def
+			case s == "basic" || s == "bearer":
+				security[s] = map[string]any{"type": "http", "scheme": s}
+			default:
+				security[s] = map[string]any{"type": "apiKey", "in": "header", "name": "Authorization"}
+			}
+		}
+		components["securitySchemes"] = security
+	}
+
+	doc := map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]any{"title": opts.Title, "version": opts.Version},
+		"paths":   paths,
+	}
+	if len(components) > 0 {
+		doc["components"] = components
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// openapiPath mengubah /users/:id menjadi /users/{id}.
+func openapiPath(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	for i, seg := range segments {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (g *openapiGen) operation(r path.RouteInfo) map[string]any {
+	op := map[string]any{"operationId": methodName(r.Method, r.Pattern)}
+
+	var params []any
+	for _, seg := range strings.Split(r.Pattern, "/") {
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			params = append(params, map[string]any{
+				"name": seg[1:], "in": "path", "required": true,
+				"schema": g.pathParamSchema(r.Request, seg[1:]),
+			})
+		}
+	}
+
+	response := map[string]any{"description": "OK"}
+	if r.Request != nil && r.Response != nil {
+		for _, q := range taggedFields(r.Request, "query") {
+			sf, _ := r.Request.FieldByName(q.goName)
+			params = append(params, map[string]any{"name": q.key, "in": "query", "schema": g.schema(sf.Type)})
+		}
+		switch r.Method {
+		case "GET", "HEAD", "DELETE":
+		default:
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": g.schema(r.Request)}},
+			}
+		}
+		// response dibungkus envelope ctx.Success
+		envelope := map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code": map[string]any{"type": "integer"},
+				"data": g.schema(r.Response),
+			},
+		}
+		response["content"] = map[string]any{"application/json": map[string]any{"schema": envelope}}
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	op["responses"] = map[string]any{"200": response}
+
+	meta := r.Meta
+	if meta.CacheControl != "" {
+		op["x-netpath-cache"] = map[string]any{"cache-control": meta.CacheControl}
+	}
+	if len(meta.Compression) > 0 {
+		op["x-netpath-compression"] = meta.Compression
+	}
+	if len(meta.RateLimits) > 0 {
+		limits := make([]any, len(meta.RateLimits))
+		for i, l := range meta.RateLimits {
+			limit := map[string]any{"limit": l.Limit, "per": l.Per.String(), "per_seconds": l.Per.Seconds()}
+			if l.Name != "" {
+				limit["name"] = l.Name
+			}
+			limits[i] = limit
+		}
+		op["x-netpath-rate-limit"] = limits
+	}
+	if len(meta.Auth) > 0 {
+		op["x-netpath-auth"] = meta.Auth
+		// beberapa skema berarti salah satu cukup
+		security := make([]any, len(meta.Auth))
+		for i, s := range meta.Auth {
+			security[i] = map[string]any{s: []string{}}
+		}
+		op["security"] = security
+	}
+	return op
+}
+
+func (g *openapiGen) pathParamSchema(req reflect.Type, name string) any {
+	if req != nil {
+		for _, f := range taggedFields(req, "path") {
+			if f.key == name {
+				sf, _ := req.FieldByName(f.goName)
+				return g.schema(sf.Type)
+			}
+		}
+	}
+	return map[string]any{"type": "string"}
+}
+
+func (g *openapiGen) schema(t reflect.Type) any {
+	if t.PkgPath() == "time" && t.Name() == "Time" {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if t.Name() != "" && t.PkgPath() != "" && !isStdlib(t) {
+		name, ok := g.defined[t]
+		if !ok {
+			name = typeID(t)
+			g.defined[t] = name
+			g.schemas[name] = g.underlying(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return g.underlying(t)
+}
+
+func (g *openapiGen) underlying(t reflect.Type) any {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Struct:
+		props := map[string]any{}
+		var required []string
+		g.fields(t, props, &required)
+		s := map[string]any{"type": "object", "properties": props}
+		if len(required) > 0 {
+			sort.Strings(required)
+			s["required"] = required
+		}
+		return s
+	}
+	return map[string]any{}
+}
+
+func (g *openapiGen) fields(t reflect.Type, props map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(embedded, props, required)
+				continue
+			}
+		}
+
+		name, optional := jsonName(sf)
+		if name == "" {
+			continue
+		}
+		props[name] = g.schema(sf.Type)
+		if !optional && sf.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	path "github.com/godev90/netpath"
+)
+
+type tsGen struct {
+	defined map[reflect.Type]string
+	queue   []reflect.Type
+}
+
+// TypeScript menghasilkan client TypeScript berbasis fetch beserta
+// interface untuk tipe request dan response.
+func TypeScript(routes []path.RouteInfo) ([]byte, error) {
+	eps, err := endpoints(routes)
+	if err != nil {
+		return nil, err
+	}
+	g := &tsGen{defined: map[reflect.Type]string{}}
+
+	var methods bytes.Buffer
+	for _, ep := range eps {
+		g.method(&methods, ep)
+	}
+
+	var types bytes.Buffer
+	for len(g.queue) > 0 {
+		t := g.queue[0]
+		g.queue = g.queue[1:]
+		g.define(&types, t)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by netpath clientgen. DO NOT EDIT.\n")
+	out.WriteString(tsRuntime)
+	out.WriteString("\nexport class Client {\n")
+	out.WriteString("  constructor(private baseURL: string, private headers: Record<string, string> = {}) {}\n")
+	out.WriteString(tsDo)
+	out.Write(methods.Bytes())
+	out.WriteString("}\n")
+	out.Write(types.Bytes())
+	return out.Bytes(), nil
+}
+
+func (g *tsGen) method(w *bytes.Buffer, ep endpoint) {
+	reqType := g.typeName(ep.request)
+	resType := g.typeName(ep.response)
+
+	fmt.Fprintf(w, "\n  // %s %s\n", ep.method, ep.pattern)
+	fmt.Fprintf(w, "  %s(req: %s): Promise<%s> {\n", lowerFirst(ep.name), reqType, resType)
+
+	path := pathExpr(ep.segments, func(seg segment) string {
+		key := fmt.Sprintf("req[%q]", tsKey(ep.request, seg.field.goName))
+		if seg.rest {
+			return "String(" + key + ")"
+		}
+		return "encodeURIComponent(String(" + key + "))"
+	})
+	fmt.Fprintf(w, "    const path = %s;\n", path)
+	w.WriteString("    const query = new URLSearchParams();\n")
+	for _, q := range ep.query {
+		fmt.Fprintf(w, "    addQuery(query, %q, req[%q]);\n", q.key, tsKey(ep.request, q.goName))
+	}
+
+	body := "undefined"
+	if ep.hasBody {
+		body = "req"
+	}
+	fmt.Fprintf(w, "    return this.do<%s>(%q, path, query, %s);\n  }\n", resType, ep.method, body)
+}
+
+// tsKey adalah nama properti TypeScript untuk field Go.
+func tsKey(t reflect.Type, goName string) string {
+	sf, _ := t.FieldByName(goName)
+	if name, _ := jsonName(sf); name != "" {
+		return name
+	}
+	for _, tag := range []string{"path", "query"} {
+		if key, _, _ := strings.Cut(sf.Tag.Get(tag), ","); key != "" {
+			return key
+		}
+	}
+	return goName
+}
+
+func (g *tsGen) typeName(t reflect.Type) string {
+	if t.PkgPath() == "time" && t.Name() == "Time" {
+		return "string"
+	}
+	if t.Name() != "" && t.PkgPath() != "" && !isStdlib(t) {
+		if name, ok := g.defined[t]; ok {
+			return name
+		}
+		name := typeID(t)
+		g.defined[t] = name
+		g.queue = append(g.queue, t)
+		return name
+	}
+	return g.underlying(t)
+}
+
+func (g *tsGen) underlying(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.typeName(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string"
+		}
+		return g.typeName(t.Elem()) + "[]"
+	case reflect.Map:
+		return "Record<string, " + g.typeName(t.Elem()) + ">"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Struct:
+		var b strings.Builder
+		b.WriteString("{\n")
+		g.fields(&b, t, "  ")
+		b.WriteString("}")
+		return b.String()
+	}
+	return "unknown"
+}
+
+func (g *tsGen) fields(b *strings.Builder, t reflect.Type, indent string) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			embedded := sf.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(b, embedded, indent)
+				continue
+			}
+		}
+
+		name, optional := jsonName(sf)
+		if name == "" {
+			name = tsKey(t, sf.Name)
+			optional = true
+		}
+		if sf.Type.Kind() == reflect.Ptr {
+			optional = true
+		}
+		mark := ""
+		if optional {
+			mark = "?"
+		}
+		fmt.Fprintf(b, "%s%q%s: %s;\n", indent, name, mark, g.typeName(sf.Type))
+	}
+}
+
+func (g *tsGen) define(w *bytes.Buffer, t reflect.Type) {
+	name := g.defined[t]
+	if t.Kind() == reflect.Struct {
+		var b strings.Builder
+		g.fields(&b, t, "  ")
+		fmt.Fprintf(w, "\nexport interface %s {\n%s}\n", name, b.String())
+		return
+	}
+
+	// tipe bernama non-struct, misalnya type Status string
+	fmt.Fprintf(w, "\nexport type %s = %s;\n", name, g.underlying(t))
+}
+
+const tsRuntime = `
+export class APIError extends Error {
+  constructor(public status: number, public code: number, public data: unknown) {
+    super("netpath: status " + status);
+  }
+}
+
+function addQuery(query: URLSearchParams, key: string, value: unknown): void {
+  if (value === undefined || value === null || value === "" || value === 0 || value === false) {
+    return;
+  }
+  if (Array.isArray(value)) {
+    value.forEach((v) => query.append(key, String(v)));
+    return;
+  }
+  query.append(key, String(value));
+}
+`
+
+const tsDo = `
+  private async do<T>(method: string, path: string, query: URLSearchParams, body?: unknown): Promise<T> {
+    const qs = query.toString();
+    const headers: Record<string, string> = { ...this.headers };
+    if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+    }
+    const res = await fetch(this.baseURL + path + (qs ? "?" + qs : ""), {
+      method,
+      headers,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    const env = await res.json().catch(() => ({ code: res.status, data: null }));
+    if (!res.ok) {
+      throw new APIError(res.status, env.code, env.data);
+    }
+    return env.data as T;
+  }
+`
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NotModified mengisi ETag dari version dan Last-Modified dari updatedAt,
+// lalu membandingkannya dengan If-None-Match / If-Modified-Since. Jika salinan
+// client masih sama, 304 langsung ditulis dan NotModified mengembalikan true
+// sehingga handler bisa berhenti sebelum data di-encode:
+//
+//	if ctx.NotModified(strconv.Itoa(item.Version), item.UpdatedAt) {
+//		return nil
+//	}
+//	return ctx.Success(item)
+//
+// version atau updatedAt yang kosong diabaikan. Hanya GET dan HEAD yang
+// dijawab 304.
+func (c *Context) NotModified(version string, updatedAt time.Time) bool {
+	h := c.writer.Header()
+	etag := formatETag(version)
+	if etag != "" {
+		h.Set("ETag", etag)
+	}
+	if !updatedAt.IsZero() {
+		h.Set("Last-Modified", updatedAt.UTC().Format(http.TimeFormat))
+	}
+
+	if c.request.Method != http.MethodGet && c.request.Method != http.MethodHead {
+		return false
+	}
+	if !isFresh(c.request.Header, etag, updatedAt) {
+		return false
+	}
+
+	h.Del("Content-Type")
+	h.Del("Content-Length")
+	c.httpStatus = http.StatusNotModified
+	c.writer.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// Conditional menjawab 304 jika salinan client masih sama; jika tidak, load
+// dipanggil dan hasilnya ditulis dengan Success. Cocok untuk endpoint sync
+// yang versinya murah dibaca tetapi datanya mahal dimuat.
+func (c *Context) Conditional(version string, updatedAt time.Time, load func() (any, error)) error {
+	if c.NotModified(version, updatedAt) {
+		return nil
+	}
+	data, err := load()
+	if err != nil {
+		return err
+	}
+	return c.Success(data)
+}
+
+// formatETag mengubah version menjadi entity-tag. Nilai yang sudah berbentuk
+// "..." atau W/"..." dipakai apa adanya.
+func formatETag(version string) string {
+	if version == "" {
+		return ""
+	}
+	if strings.HasPrefix(version, `W/"`) || (strings.HasPrefix(version, `"`) && len(version) > 1 && strings.HasSuffix(version, `"`)) {
+		return version
+	}
+	return `"` + version + `"`
+}
+
+// isFresh mengikuti RFC 9110 13.2.2: If-None-Match didahulukan, dan
+// If-Modified-Since hanya dipakai jika If-None-Match tidak ada.
+func isFresh(header http.Header, etag string, updatedAt time.Time) bool {
+	if inm := header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagMatch(inm, etag)
+	}
+
+	ims := header.Get("If-Modified-Since")
+	if ims == "" || updatedAt.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	// Last-Modified hanya berpresisi detik
+	return !updatedAt.Truncate(time.Second).After(since)
+}
+
+// etagMatch memakai perbandingan lemah seperti yang disyaratkan untuk
+// If-None-Match.
+func etagMatch(list, etag string) bool {
+	if strings.TrimSpace(list) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lifetime menentukan berapa lama hasil provider dipakai ulang.
+type Lifetime int
+
+const (
+	// Singleton dibuat sekali untuk seluruh App, saat pertama kali dibutuhkan.
+	Singleton Lifetime = iota
+	// PerRequest dibuat sekali per request dan boleh bergantung pada *Context.
+	PerRequest
+)
+
+var (
+	contextType    = reflect.TypeOf((*Context)(nil))
+	stdContextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+type provider struct {
+	fn       reflect.Value
+	out      reflect.Type
+	in       []reflect.Type
+	lifetime Lifetime
+
+	mu    sync.Mutex
+	built bool
+	value reflect.Value
+}
+
+type container struct {
+	mu        sync.RWMutex
+	providers map[reflect.Type]*provider
+}
+
+// Provide mendaftarkan constructor berbentuk func(deps...) T atau
+// func(deps...) (T, error). Parameter constructor di-resolve dari provider
+// lain; *Context dan context.Context hanya tersedia untuk PerRequest.
+// Provide panic jika bentuk constructor tidak valid atau T sudah terdaftar.
+func (app *App) Provide(constructor any, lifetime Lifetime) {
+	fn := reflect.ValueOf(constructor)
+	t := fn.Type()
+	if t.Kind() != reflect.Func {
+		panic(fmt.Sprintf("netpath: Provide expects a function, got %s", t))
+	}
+	if t.NumOut() == 0 || t.NumOut() > 2 || (t.NumOut() == 2 && t.Out(1) != errorType) {
+		panic(fmt.Sprintf("netpath: constructor %s must return T or (T, error)", t))
+	}
+
+	p := &provider{fn: fn, out: t.Out(0), lifetime: lifetime}
+	for i := 0; i < t.NumIn(); i++ {
+		in := t.In(i)
+		if lifetime == Singleton && (in == contextType || in == stdContextType) {
+			panic(fmt.Sprintf("netpath: singleton %s cannot depend on %s", p.out, in))
+		}
+		p.in = append(p.in, in)
+	}
+
+	if app.container == nil {
+		app.container = &container{providers: make(map[reflect.Type]*provider)}
+	}
+	c := app.container
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.providers[p.out]; ok {
+		panic(fmt.Sprintf("netpath: provider for %s already registered", p.out))
+	}
+	c.providers[p.out] = p
+}
+
+// Resolve mengembalikan nilai T dari container App milik ctx.
+func Resolve[T any](ctx *Context) (T, error) {
+	var zero T
+	v, err := ctx.resolve(reflect.TypeOf((*T)(nil)).Elem(), nil)
+	if err != nil {
+		return zero, err
+	}
+	val, _ := v.Interface().(T)
+	return val, nil
+}
+
+// Inject mengubah handler berbentuk func(*Context, deps...) error menjadi
+// HandlerFunc. Dependency di-resolve pada setiap request; panic jika bentuk
+// handler tidak valid.
+func Inject(handler any) HandlerFunc {
+	fn := reflect.ValueOf(handler)
+	t := fn.Type()
+	if t.Kind() != reflect.Func || t.NumIn() == 0 || t.In(0) != contextType ||
+		t.NumOut() != 1 || t.Out(0) != errorType {
+		panic(fmt.Sprintf("netpath: Inject expects func(*Context, ...) error, got %s", t))
+	}
+
+	return func(ctx *Context) error {
+		args := make([]reflect.Value, t.NumIn())
+		args[0] = reflect.ValueOf(ctx)
+		for i := 1; i < t.NumIn(); i++ {
+			v, err := ctx.resolve(t.In(i), nil)
+			if err != nil {
+				return err
+			}
+			args[i] = v
+		}
+
+		out := fn.Call(args)
+		if err, _ := out[0].Interface().(error); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func (c *Context) resolve(t reflect.Type, stack []reflect.Type) (reflect.Value, error) {
+	switch t {
+	case contextType:
+		return reflect.ValueOf(c), nil
+	case stdContextType:
+		return reflect.ValueOf(c.request.Context()), nil
+	}
+
+	p, err := c.app.provider(t, stack)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if p.lifetime == Singleton {
+		return c.app.singleton(p, stack)
+	}
+
+	if v, ok := c.scoped[t]; ok {
+		return v, nil
+	}
+	v, err := p.build(stack, func(dep reflect.Type, stack []reflect.Type) (reflect.Value, error) {
+		return c.resolve(dep, stack)
+	})
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if c.scoped == nil {
+		c.scoped = make(map[reflect.Type]reflect.Value)
+	}
+	c.scoped[t] = v
+	return v, nil
+}
+
+func (app *App) provider(t reflect.Type, stack []reflect.Type) (*provider, error) {
+	for _, s := range stack {
+		if s == t {
+			return nil, fmt.Errorf("netpath: dependency cycle on %s", t)
+		}
+	}
+	if app == nil || app.container == nil {
+		return nil, fmt.Errorf("netpath: no provider for %s", t)
+	}
+
+	app.container.mu.RLock()
+	p, ok := app.container.providers[t]
+	app.container.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("netpath: no provider for %s", t)
+	}
+	return p, nil
+}
+
+func (app *App) singleton(p *provider, stack []reflect.Type) (reflect.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.built {
+		return p.value, nil
+	}
+
+	v, err := p.build(stack, func(dep reflect.Type, stack []reflect.Type) (reflect.Value, error) {
+		dp, err := app.provider(dep, stack)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if dp.lifetime != Singleton {
+			return reflect.Value{}, fmt.Errorf("netpath: singleton %s cannot depend on per-request %s", p.out, dep)
+		}
+		return app.singleton(dp, stack)
+	})
+	if err != nil {
+		// constructor yang gagal dicoba lagi pada resolve berikutnya
+		return reflect.Value{}, err
+	}
+	p.value, p.built = v, true
+	return v, nil
+}
+
+func (p *provider) build(stack []reflect.Type, resolve func(reflect.Type, []reflect.Type) (reflect.Value, error)) (reflect.Value, error) {
+	stack = append(stack, p.out)
+	args := make([]reflect.Value, len(p.in))
+	for i, in := range p.in {
+		v, err := resolve(in, stack)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		args[i] = v
+	}
+
+	out := p.fn.Call(args)
+	if len(out) == 2 {
+		if err, _ := out[1].Interface().(error); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return out[0], nil
+}
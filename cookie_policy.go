@@ -0,0 +1,58 @@
+package app
+
+import "net/http"
+
+// CookiePolicy mengatur atribut cookie secara terpusat untuk semua cookie
+// yang ditulis lewat Context.SetCookie, termasuk cookie session dan CSRF.
+type CookiePolicy struct {
+	SameSite http.SameSite
+	// Insecure mematikan atribut Secure, hanya untuk development tanpa TLS.
+	Insecure bool
+	// Domain dipakai untuk cookie yang tidak menentukan Domain sendiri.
+	Domain string
+}
+
+// CookiePolicyFor mengembalikan policy bawaan untuk environment:
+// "development", "dev", dan "local" tanpa Secure; selain itu Secure dengan SameSite=Lax.
+func CookiePolicyFor(env string) CookiePolicy {
+	switch env {
+	case "development", "dev", "local":
+		return CookiePolicy{SameSite: http.SameSiteLaxMode, Insecure: true}
+	}
+	return CookiePolicy{SameSite: http.SameSiteLaxMode}
+}
+
+// WithCookiePolicy memasang policy cookie untuk seluruh App. Policy menimpa
+// atribut Secure cookie yang ditulis lewat Context.SetCookie.
+func WithCookiePolicy(p CookiePolicy) Option {
+	return func(app *App) {
+		app.cookiePolicy = &p
+	}
+}
+
+// SetCookie menulis cookie setelah menerapkan CookiePolicy App. Tanpa
+// policy, cookie tanpa SameSite mendapat SameSite=Lax.
+func (c *Context) SetCookie(ck *http.Cookie) {
+	var p *CookiePolicy
+	if c.app != nil {
+		p = c.app.cookiePolicy
+	}
+
+	if ck.SameSite == 0 {
+		ck.SameSite = http.SameSiteLaxMode
+		if p != nil && p.SameSite != 0 {
+			ck.SameSite = p.SameSite
+		}
+	}
+	if p != nil {
+		ck.Secure = !p.Insecure
+		if ck.Domain == "" {
+			ck.Domain = p.Domain
+		}
+	}
+	// browser menolak SameSite=None tanpa Secure
+	if ck.SameSite == http.SameSiteNoneMode && !ck.Secure {
+		ck.SameSite = http.SameSiteLaxMode
+	}
+	http.SetCookie(c.writer, ck)
+}
@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BulkMode menentukan perlakuan baris yang bentrok dengan unique key.
+type BulkMode int
+
+const (
+	// BulkInsertOnly gagal pada baris yang bentrok.
+	BulkInsertOnly BulkMode = iota
+	// BulkIgnore melewati baris yang bentrok (ON CONFLICT DO NOTHING / INSERT IGNORE).
+	BulkIgnore
+	// BulkUpsert menimpa baris yang bentrok (ON CONFLICT DO UPDATE /
+	// ON DUPLICATE KEY UPDATE).
+	BulkUpsert
+)
+
+// maxParams adalah batas placeholder per statement untuk postgres dan mysql.
+const maxParams = 65535
+
+type BulkOptions struct {
+	// BatchSize adalah jumlah baris per statement, default 1000. Nilainya
+	// diturunkan otomatis agar placeholder tidak melebihi batas driver.
+	BatchSize int
+	Mode      BulkMode
+	// ConflictColumns adalah target ON CONFLICT untuk postgres, default
+	// kolom pk. MySQL memakai semua unique key tabel.
+	ConflictColumns []string
+	// UpdateColumns adalah kolom yang ditimpa pada BulkUpsert, default semua
+	// kolom kecuali ConflictColumns dan pk. Kolom version dinaikkan, bukan
+	// ditimpa.
+	UpdateColumns []string
+}
+
+// BulkInsert menyimpan rows (struct atau pointer struct dengan tag `db`
+// seperti Repository) dengan INSERT multi-baris per batch:
+//
+//	n, err := tools.BulkInsert(ctx, "main", "prices", prices, tools.BulkOptions{
+//		Mode:            tools.BulkUpsert,
+//		ConflictColumns: []string{"sku"},
+//	})
+//
+// Kolom pk dilewati jika bernilai zero di semua baris (auto increment).
+// Lebih dari satu batch dijalankan dalam satu transaksi, kecuali ctx sudah
+// membawa transaksi alias. Hasilnya jumlah baris terpengaruh menurut driver;
+// MySQL menghitung baris yang di-update pada upsert sebagai 2.
+func BulkInsert[T any](ctx context.Context, alias, table string, rows []T, opts ...BulkOptions) (int64, error) {
+	var opt BulkOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("tools: bulk insert type %s must be a struct", t)
+	}
+	meta := metaFor(t)
+
+	values := make([]reflect.Value, len(rows))
+	autoPK := meta.pk != nil
+	for i := range rows {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		values[i] = v
+		if meta.pk != nil && !v.Field(meta.pk.index).IsZero() {
+			autoPK = false
+		}
+	}
+
+	var columns []column
+	for _, c := range meta.columns {
+		if autoPK && c.index == meta.pk.index {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return 0, fmt.Errorf("tools: bulk insert type %s has no `db` columns", t)
+	}
+	if meta.pk != nil && !autoPK {
+		for _, v := range values {
+			if v.Field(meta.pk.index).IsZero() {
+				return 0, errors.New("tools: bulk insert rows mix empty and set primary keys")
+			}
+		}
+	}
+
+	driver := Pool().Driver(alias)
+	prefix, suffix, err := bulkClauses(driver, table, meta, columns, opt)
+	if err != nil {
+		return 0, err
+	}
+
+	batch := opt.BatchSize
+	if batch <= 0 {
+		batch = 1000
+	}
+	batch = min(batch, maxParams/len(columns))
+
+	if len(values) > batch && TxFrom(ctx, alias) == nil {
+		db, err := Pool().Get(alias)
+		if err != nil {
+			return 0, err
+		}
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		defer tx.Rollback()
+
+		n, err := bulkExec(WithTx(ctx, alias, tx), alias, driver, prefix, suffix, meta, columns, values, batch)
+		if err != nil {
+			return 0, err
+		}
+		return n, tx.Commit()
+	}
+	return bulkExec(ctx, alias, driver, prefix, suffix, meta, columns, values, batch)
+}
+
+// bulkClauses menyusun "INSERT INTO t (a, b) VALUES " dan klausa konflik
+// sesuai driver dan mode.
+func bulkClauses(driver, table string, meta *tableMeta, columns []column, opt BulkOptions) (prefix, suffix string, err error) {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+
+	conflict := opt.ConflictColumns
+	if len(conflict) == 0 && meta.pk != nil {
+		conflict = []string{meta.pk.name}
+	}
+	update := opt.UpdateColumns
+	if len(update) == 0 {
+		skip := map[string]bool{}
+		for _, c := range conflict {
+			skip[c] = true
+		}
+		if meta.pk != nil {
+			skip[meta.pk.name] = true
+		}
+		for _, n := range names {
+			if !skip[n] {
+				update = append(update, n)
+			}
+		}
+	}
+	mode := opt.Mode
+	if mode == BulkUpsert && len(update) == 0 {
+		mode = BulkIgnore
+	}
+
+	insert := "INSERT"
+	switch {
+	case mode == BulkIgnore && driver == "mysql":
+		insert = "INSERT IGNORE"
+	case mode == BulkIgnore:
+		suffix = " ON CONFLICT DO NOTHING"
+	case mode == BulkUpsert && driver == "mysql":
+		sets := make([]string, len(update))
+		for i, c := range update {
+			sets[i] = fmt.Sprintf("%s = VALUES(%s)", c, c)
+			if meta.version != nil && c == meta.version.name {
+				sets[i] = fmt.Sprintf("%s = %s + 1", c, c)
+			}
+		}
+		suffix = " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	case mode == BulkUpsert:
+		if len(conflict) == 0 {
+			return "", "", errors.New("tools: bulk upsert needs ConflictColumns or a pk column")
+		}
+		sets := make([]string, len(update))
+		for i, c := range update {
+			sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", c, c)
+			if meta.version != nil && c == meta.version.name {
+				sets[i] = fmt.Sprintf("%s = %s.%s + 1", c, table, c)
+			}
+		}
+		suffix = fmt.Sprintf(" ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(conflict, ", "), strings.Join(sets, ", "))
+	}
+
+	prefix = fmt.Sprintf("%s INTO %s (%s) VALUES ", insert, table, strings.Join(names, ", "))
+	return prefix, suffix, nil
+}
+
+func bulkExec(ctx context.Context, alias, driver, prefix, suffix string, meta *tableMeta, columns []column, values []reflect.Value, batch int) (int64, error) {
+	var total int64
+	for start := 0; start < len(values); start += batch {
+		chunk := values[start:min(start+batch, len(values))]
+
+		var b strings.Builder
+		b.WriteString(prefix)
+		args := make([]any, 0, len(chunk)*len(columns))
+		for i, v := range chunk {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString("(")
+			b.WriteString(Placeholders(driver, len(args)+1, len(columns)))
+			b.WriteString(")")
+			for _, c := range columns {
+				f := v.Field(c.index)
+				if meta.version != nil && c.index == meta.version.index && f.IsZero() && f.CanSet() {
+					setInt(f, 1)
+				}
+				args = append(args, f.Interface())
+			}
+		}
+		b.WriteString(suffix)
+
+		res, err := Exec(ctx, alias, b.String(), args...)
+		if err != nil {
+			return total, err
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			total += n
+		}
+	}
+	return total, nil
+}
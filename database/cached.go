@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+)
+
+// CachedQuery menjalankan query dan menyimpan hasilnya di DBConfig.QueryCache
+// alias selama ttl. Selama key masih ada di cache, database tidak disentuh:
+//
+//	products, err := tools.CachedQuery[Product](ctx, "main", "products:featured", 5*time.Minute,
+//		"SELECT id, name, price FROM products WHERE featured = ?", true)
+//
+// Struct T diisi berdasarkan tag `db` yang cocok dengan nama kolom hasil;
+// kolom tanpa field dilewati. T bukan struct (misalnya int64) untuk query
+// satu kolom. Hasil disimpan sebagai JSON. Tanpa QueryCache, atau jika ctx
+// membawa transaksi alias, query selalu dijalankan langsung. Error cache
+// tidak menggagalkan query.
+func CachedQuery[T any](ctx context.Context, alias, key string, ttl time.Duration, query string, args ...any) ([]T, error) {
+	store := Pool().queryCache(alias)
+	if store == nil || TxFrom(ctx, alias) != nil {
+		return queryAll[T](ctx, alias, query, args...)
+	}
+
+	cacheKey := queryCacheKey(alias, key)
+	if raw, err := store.Get(ctx, cacheKey); err == nil {
+		var items []T
+		if json.Unmarshal(raw, &items) == nil {
+			return items, nil
+		}
+	}
+
+	items, err := queryAll[T](ctx, alias, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	if raw, err := json.Marshal(items); err == nil {
+		store.Set(ctx, cacheKey, raw, ttl)
+	}
+	return items, nil
+}
+
+// InvalidateQuery menghapus hasil CachedQuery untuk keys, biasanya setelah
+// data yang mendasarinya berubah.
+func InvalidateQuery(ctx context.Context, alias string, keys ...string) error {
+	store := Pool().queryCache(alias)
+	if store == nil {
+		return nil
+	}
+	var errs []error
+	for _, key := range keys {
+		if err := store.Delete(ctx, queryCacheKey(alias, key)); err != nil && !errors.Is(err, cache.ErrMiss) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+func queryCacheKey(alias, key string) string {
+	return "query:" + alias + ":" + key
+}
+
+// queryAll menjalankan query dan memindai setiap baris ke T.
+func queryAll[T any](ctx context.Context, alias, query string, args ...any) ([]T, error) {
+	rows, err := Query(ctx, alias, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	var fields []int // indeks field per kolom, -1 untuk kolom yang dilewati
+	if t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(scannerType) && t != timeType {
+		byName := map[string]int{}
+		for _, c := range metaFor(t).columns {
+			byName[c.name] = c.index
+		}
+		fields = make([]int, len(names))
+		for i, name := range names {
+			fields[i] = -1
+			if idx, ok := byName[name]; ok {
+				fields[i] = idx
+			}
+		}
+	}
+
+	items := []T{}
+	for rows.Next() {
+		var item T
+		dest := []any{&item}
+		if fields != nil {
+			v := reflect.ValueOf(&item).Elem()
+			dest = make([]any, len(fields))
+			for i, idx := range fields {
+				if idx < 0 {
+					dest[i] = new(any)
+					continue
+				}
+				dest[i] = v.Field(idx).Addr().Interface()
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
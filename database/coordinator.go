@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	logging "github.com/godev90/netpath/helpers/logging"
+)
+
+// ErrPartialCommit berarti sebagian participant sudah commit, participant
+// lain gagal, dan kompensasi tidak berhasil atau tidak tersedia. Data antar
+// database tidak konsisten dan perlu ditangani manual.
+var ErrPartialCommit = errors.New("tools: partial commit across databases")
+
+// Participant adalah satu alias dalam Coordinator.
+type Participant struct {
+	Alias string
+	Opts  *sql.TxOptions
+	// Prepare dijalankan di dalam transaksi setelah fn selesai dan sebelum
+	// commit mana pun, misalnya untuk mengunci baris atau memeriksa saldo.
+	// Error dari satu participant me-rollback semuanya.
+	Prepare func(ctx context.Context, tx *sql.Tx) error
+	// Compensate membatalkan efek participant yang sudah commit ketika
+	// participant sesudahnya gagal commit.
+	Compensate func(ctx context.Context) error
+}
+
+// Coordinator menjalankan satu operasi pada beberapa alias dengan pola
+// two-phase commit best-effort: semua transaksi dibuka, fn dan Prepare
+// dijalankan, lalu transaksi di-commit berurutan. Commit yang gagal di
+// tengah jalan memicu Compensate (urutan terbalik) untuk participant yang
+// sudah commit. Taruh participant yang paling sulit dikompensasi di akhir.
+type Coordinator struct {
+	Name         string // nama operasi untuk log
+	Participants []Participant
+	// OnPartialFailure dipanggil setiap kali sebagian participant sudah
+	// commit, misalnya untuk membuat tiket atau alert.
+	OnPartialFailure func(PartialFailure)
+	// Timeout membatasi umur transaksi. Transaksi tidak ikut dibatalkan ctx
+	// agar pembatalan request di tengah commit tidak me-rollback participant
+	// sisanya; tanpa Timeout, transaksi hanya berakhir lewat commit atau
+	// rollback.
+	Timeout time.Duration
+}
+
+// PartialFailure dicatat sebagai JSON di log level error.
+type PartialFailure struct {
+	Operation          string            `json:"operation"`
+	Committed          []string          `json:"committed"`
+	Failed             string            `json:"failed"`
+	Error              string            `json:"error"`
+	RolledBack         []string          `json:"rolled_back,omitempty"`
+	Compensated        []string          `json:"compensated,omitempty"`
+	CompensationErrors map[string]string `json:"compensation_errors,omitempty"`
+	// Resolved bernilai true jika semua participant yang sudah commit
+	// berhasil dikompensasi.
+	Resolved bool      `json:"resolved"`
+	At       time.Time `json:"at"`
+}
+
+// Run membuka transaksi untuk setiap participant dan memanggil fn dengan
+// context berisi transaksi tersebut, sehingga Exec, Query, dan Repository
+// untuk alias-alias itu otomatis ikut transaksi.
+//
+//	err := tools.Coordinator{
+//		Name: "transfer",
+//		Participants: []tools.Participant{
+//			{Alias: "ledger", Compensate: reverseLedger},
+//			{Alias: "wallet"},
+//		},
+//	}.Run(ctx, func(ctx context.Context) error { ... })
+//
+// Error dari fn, Prepare, atau commit participant pertama me-rollback semua
+// transaksi. Kegagalan setelah ada yang commit mengembalikan error commit
+// jika kompensasi berhasil, atau ErrPartialCommit jika tidak.
+func (c Coordinator) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	if len(c.Participants) == 0 {
+		return fn(ctx)
+	}
+
+	txs := make([]*sql.Tx, 0, len(c.Participants))
+	rollback := func(from int) {
+		for _, tx := range txs[from:] {
+			tx.Rollback()
+		}
+	}
+	// transaksi yang sudah commit atau rollback diabaikan (sql.ErrTxDone)
+	defer rollback(0)
+
+	// database/sql me-rollback transaksi saat context-nya selesai, termasuk
+	// setelah participant pertama commit
+	beginCtx := context.WithoutCancel(ctx)
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		beginCtx, cancel = context.WithTimeout(beginCtx, c.Timeout)
+		defer cancel()
+	}
+
+	txCtx := ctx
+	for _, p := range c.Participants {
+		db, err := Pool().Get(p.Alias)
+		if err != nil {
+			return fmt.Errorf("tools: %s: %w", p.Alias, err)
+		}
+		tx, err := db.BeginTx(beginCtx, p.Opts)
+		if err != nil {
+			return fmt.Errorf("tools: begin %s: %w", p.Alias, err)
+		}
+		txs = append(txs, tx)
+		txCtx = WithTx(txCtx, p.Alias, tx)
+	}
+
+	if err := fn(txCtx); err != nil {
+		return err
+	}
+
+	for i, p := range c.Participants {
+		if p.Prepare == nil {
+			continue
+		}
+		if err := p.Prepare(txCtx, txs[i]); err != nil {
+			return fmt.Errorf("tools: prepare %s: %w", p.Alias, err)
+		}
+	}
+
+	// request yang sudah dibatalkan di-rollback sebelum commit pertama
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for i, p := range c.Participants {
+		err := txs[i].Commit()
+		if err == nil {
+			continue
+		}
+		// lepaskan lock participant sisanya sebelum kompensasi
+		rollback(i + 1)
+		if i == 0 {
+			return fmt.Errorf("tools: commit %s: %w", p.Alias, err)
+		}
+		return c.compensate(ctx, i, err)
+	}
+	return nil
+}
+
+// compensate mengompensasi participant [0, failed) yang sudah commit dan
+// mencatat keadaan akhirnya.
+func (c Coordinator) compensate(ctx context.Context, failed int, commitErr error) error {
+	report := PartialFailure{
+		Operation: c.Name,
+		Failed:    c.Participants[failed].Alias,
+		Error:     commitErr.Error(),
+		Resolved:  true,
+		At:        time.Now(),
+	}
+	for _, p := range c.Participants[:failed] {
+		report.Committed = append(report.Committed, p.Alias)
+	}
+	for _, p := range c.Participants[failed+1:] {
+		report.RolledBack = append(report.RolledBack, p.Alias)
+	}
+
+	// kompensasi tetap jalan walaupun request sudah dibatalkan
+	cctx := context.WithoutCancel(ctx)
+	for i := failed - 1; i >= 0; i-- {
+		p := c.Participants[i]
+		if p.Compensate == nil {
+			report.Resolved = false
+			continue
+		}
+		if err := p.Compensate(cctx); err != nil {
+			if report.CompensationErrors == nil {
+				report.CompensationErrors = map[string]string{}
+			}
+			report.CompensationErrors[p.Alias] = err.Error()
+			report.Resolved = false
+			continue
+		}
+		report.Compensated = append(report.Compensated, p.Alias)
+	}
+
+	b, _ := json.Marshal(report)
+	logging.Errorf("coordinator partial failure: %s", b)
+	if c.OnPartialFailure != nil {
+		c.OnPartialFailure(report)
+	}
+
+	if report.Resolved {
+		return fmt.Errorf("tools: commit %s: %w", report.Failed, commitErr)
+	}
+	return fmt.Errorf("%w: commit %s: %w", ErrPartialCommit, report.Failed, commitErr)
+}
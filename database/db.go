@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/godev90/netpath/cache"
+
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/lib/pq"
 )
@@ -24,11 +26,41 @@ type (
 		MaxOpenConns    int
 		MaxIdleConns    int
 		ConnMaxLifetime time.Duration
+
+		// StmtCacheSize > 0 mengaktifkan cache prepared statement untuk
+		// query yang dijalankan lewat Exec dan Query.
+		StmtCacheSize int
+
+		// Retry mengulang Exec dan Query saat terjadi error sementara.
+		Retry RetryPolicy
+
+		// QueryTimeout adalah batas waktu default setiap Exec dan Query
+		// (termasuk membaca rows) bila context belum punya deadline lebih awal.
+		QueryTimeout time.Duration
+		// SQLCommenter menambahkan label context (WithLabels) sebagai komentar
+		// sqlcommenter pada query Exec dan Query. Tidak berlaku bersama
+		// StmtCacheSize.
+		SQLCommenter bool
+
+		// ReadOnly menolak INSERT/UPDATE/DELETE dan DDL lewat Exec dan Query
+		// dengan ErrReadOnly, misalnya untuk replica atau database laporan.
+		ReadOnly bool
+
+		// QueryCache menyimpan hasil CachedQuery, misalnya cache.Redis("main").
+		QueryCache cache.Cache
 	}
 
 	dbPool struct {
-		pool map[string]*sql.DB
-		mu   sync.RWMutex
+		pool       map[string]*sql.DB
+		drivers    map[string]string
+		dsns       map[string]string
+		stmts      map[string]*stmtCache
+		retries    map[string]RetryPolicy
+		timeouts   map[string]time.Duration
+		commenters map[string]bool
+		readOnlys  map[string]bool
+		caches     map[string]cache.Cache
+		mu         sync.RWMutex
 	}
 )
 
@@ -40,7 +72,15 @@ var (
 func Pool() *dbPool {
 	once.Do(func() {
 		pool = &dbPool{
-			pool: make(map[string]*sql.DB),
+			pool:       make(map[string]*sql.DB),
+			drivers:    make(map[string]string),
+			dsns:       make(map[string]string),
+			stmts:      make(map[string]*stmtCache),
+			retries:    make(map[string]RetryPolicy),
+			timeouts:   make(map[string]time.Duration),
+			commenters: make(map[string]bool),
+			readOnlys:  make(map[string]bool),
+			caches:     make(map[string]cache.Cache),
 		}
 	})
 
@@ -75,6 +115,7 @@ func (dbc *dbPool) Connect(alias string, cfg DBConfig) error {
 	}
 
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return err
 	}
 
@@ -97,6 +138,18 @@ func (dbc *dbPool) Connect(alias string, cfg DBConfig) error {
 	}
 
 	dbc.pool[alias] = db
+	dbc.drivers[alias] = cfg.Driver
+	dbc.dsns[alias] = dsn
+	dbc.retries[alias] = cfg.Retry
+	dbc.timeouts[alias] = cfg.QueryTimeout
+	dbc.commenters[alias] = cfg.SQLCommenter
+	dbc.readOnlys[alias] = cfg.ReadOnly
+	if cfg.QueryCache != nil {
+		dbc.caches[alias] = cfg.QueryCache
+	}
+	if cfg.StmtCacheSize > 0 {
+		dbc.stmts[alias] = newStmtCache(db, cfg.StmtCacheSize)
+	}
 	log.Printf("Connected to [%s] database", alias)
 
 	return nil
@@ -113,3 +166,88 @@ func (dbc *dbPool) Get(name string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// Driver mengembalikan nama driver ("mysql" atau "postgres") untuk alias.
+func (dbc *dbPool) Driver(name string) string {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.drivers[name]
+}
+
+func (dbc *dbPool) stmtCache(name string) *stmtCache {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.stmts[name]
+}
+
+func (dbc *dbPool) retryPolicy(name string) RetryPolicy {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.retries[name]
+}
+
+func (dbc *dbPool) queryTimeout(name string) time.Duration {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.timeouts[name]
+}
+
+func (dbc *dbPool) commenter(name string) bool {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.commenters[name]
+}
+
+func (dbc *dbPool) readOnly(name string) bool {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.readOnlys[name]
+}
+
+func (dbc *dbPool) queryCache(name string) cache.Cache {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	return dbc.caches[name]
+}
+
+// Disconnect menutup koneksi alias dan menghapusnya dari pool, sehingga
+// Connect berikutnya dengan alias yang sama membuat koneksi baru.
+func (dbc *dbPool) Disconnect(alias string) error {
+	dbc.mu.Lock()
+	defer dbc.mu.Unlock()
+
+	db, ok := dbc.pool[alias]
+	if !ok {
+		return nil
+	}
+	delete(dbc.pool, alias)
+	delete(dbc.drivers, alias)
+	delete(dbc.dsns, alias)
+	delete(dbc.stmts, alias)
+	delete(dbc.retries, alias)
+	delete(dbc.timeouts, alias)
+	delete(dbc.commenters, alias)
+	delete(dbc.readOnlys, alias)
+	delete(dbc.caches, alias)
+	// statement di cache ikut tertutup bersama db
+	return db.Close()
+}
+
+// Stats mengembalikan statistik connection pool untuk setiap alias.
+func (dbc *dbPool) Stats() map[string]sql.DBStats {
+	dbc.mu.RLock()
+	defer dbc.mu.RUnlock()
+
+	stats := make(map[string]sql.DBStats, len(dbc.pool))
+	for alias, db := range dbc.pool {
+		stats[alias] = db.Stats()
+	}
+	return stats
+}
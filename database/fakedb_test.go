@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeDB adalah driver database/sql berskrip untuk test: setiap Exec atau
+// Query mengambil jawaban berikutnya dari antrean dan dicatat di calls.
+type fakeDB struct {
+	mu      sync.Mutex
+	results []fakeResult
+	calls   []fakeCall
+}
+
+type fakeResult struct {
+	rowsAffected int64
+	lastID       int64
+	columns      []string
+	rows         [][]driver.Value
+	err          error
+}
+
+type fakeCall struct {
+	query string
+	args  []driver.Value
+}
+
+var (
+	fakeDBsMu sync.Mutex
+	fakeDBs   = map[string]*fakeDB{}
+)
+
+func init() {
+	sql.Register("netpath_fake", fakeDriver{})
+}
+
+// useFakeDB mendaftarkan alias di Pool() dengan nama driver (mysql atau
+// postgres, menentukan placeholder) yang dijawab oleh fakeDB.
+func useFakeDB(t *testing.T, alias, driverName string) *fakeDB {
+	t.Helper()
+	fake := &fakeDB{}
+	fakeDBsMu.Lock()
+	fakeDBs[alias] = fake
+	fakeDBsMu.Unlock()
+
+	db, err := sql.Open("netpath_fake", alias)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Pool()
+	p.mu.Lock()
+	p.pool[alias] = db
+	p.drivers[alias] = driverName
+	p.mu.Unlock()
+
+	t.Cleanup(func() {
+		p.Disconnect(alias)
+		fakeDBsMu.Lock()
+		delete(fakeDBs, alias)
+		fakeDBsMu.Unlock()
+	})
+	return fake
+}
+
+func (f *fakeDB) push(results ...fakeResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.results = append(f.results, results...)
+}
+
+func (f *fakeDB) next(query string, args []driver.NamedValue) (fakeResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	f.calls = append(f.calls, fakeCall{query: query, args: values})
+	if len(f.results) == 0 {
+		return fakeResult{}, fmt.Errorf("fakedb: unexpected query %q", query)
+	}
+	res := f.results[0]
+	f.results = f.results[1:]
+	return res, res.err
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeDBsMu.Lock()
+	defer fakeDBsMu.Unlock()
+	db, ok := fakeDBs[name]
+	if !ok {
+		return nil, fmt.Errorf("fakedb: unknown database %q", name)
+	}
+	return &fakeConn{db: db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("fakedb: prepare not supported")
+}
+
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	res, err := c.db.next(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return fakeSQLResult{res}, nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	res, err := c.db.next(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: res.columns, rows: res.rows}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeSQLResult struct {
+	res fakeResult
+}
+
+func (r fakeSQLResult) LastInsertId() (int64, error) { return r.res.lastID, nil }
+func (r fakeSQLResult) RowsAffected() (int64, error) { return r.res.rowsAffected, nil }
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,256 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Fixtures memuat data test dari file ke alias test. Setiap file YAML berisi
+// daftar baris untuk satu tabel yang namanya diambil dari nama file, dengan
+// prefix angka opsional untuk mengatur urutan:
+//
+//	# testdata/fixtures/01_users.yml
+//	- id: 1
+//	  email: a@example.com
+//	# testdata/fixtures/02_orders.yml
+//	- id: 10
+//	  user_id: 1
+//
+// File .sql dijalankan apa adanya, dipecah per ";" (jangan pakai ";" di
+// dalam string). Jangan arahkan Fixtures ke database selain database test:
+// tabelnya dikosongkan.
+type Fixtures struct {
+	Alias string
+	FS    fs.FS // misalnya os.DirFS("testdata/fixtures") atau embed.FS
+	// Migrate dijalankan sekali sebelum fixture pertama dimuat, tempat
+	// memanggil migration runner aplikasi.
+	Migrate func(ctx context.Context, alias string) error
+
+	migrateOnce sync.Once
+	migrateErr  error
+}
+
+type fixtureFile struct {
+	name   string
+	tables []string // tabel YAML, atau tujuan INSERT di file .sql
+	rows   []map[string]any
+	sql    string
+}
+
+// Load mengosongkan tabel yang akan diisi (urutan terbalik, supaya foreign
+// key aman) lalu mengisinya sesuai urutan nama file. Tanpa names, semua file
+// .yml, .yaml, dan .sql di FS dimuat. Tabel yang diisi dikembalikan supaya
+// bisa dikosongkan lagi dengan Truncate; untuk file .sql, tabelnya diambil
+// dari statement INSERT, REPLACE, dan COPY.
+func (f *Fixtures) Load(ctx context.Context, names ...string) ([]string, error) {
+	if err := f.migrate(ctx); err != nil {
+		return nil, err
+	}
+	files, err := f.read(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	for _, file := range files {
+		for _, table := range file.tables {
+			if !slices.Contains(tables, table) {
+				tables = append(tables, table)
+			}
+		}
+	}
+	return tables, f.withConn(ctx, func(conn *sql.Conn, driver string) error {
+		if err := truncate(ctx, conn, driver, tables); err != nil {
+			return err
+		}
+		for _, file := range files {
+			if err := insertFixture(ctx, conn, driver, file); err != nil {
+				return fmt.Errorf("tools: fixture %s: %w", file.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// Truncate mengosongkan tabel, misalnya setelah test selesai.
+func (f *Fixtures) Truncate(ctx context.Context, tables ...string) error {
+	return f.withConn(ctx, func(conn *sql.Conn, driver string) error {
+		return truncate(ctx, conn, driver, tables)
+	})
+}
+
+func (f *Fixtures) migrate(ctx context.Context) error {
+	f.migrateOnce.Do(func() {
+		if f.Migrate != nil {
+			f.migrateErr = f.Migrate(ctx, f.Alias)
+		}
+	})
+	return f.migrateErr
+}
+
+func (f *Fixtures) read(names []string) ([]fixtureFile, error) {
+	if len(names) == 0 {
+		entries, err := fs.ReadDir(f.FS, ".")
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			switch path.Ext(e.Name()) {
+			case ".yml", ".yaml", ".sql":
+				names = append(names, e.Name())
+			}
+		}
+	}
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+
+	files := make([]fixtureFile, 0, len(names))
+	for _, name := range names {
+		raw, err := fs.ReadFile(f.FS, name)
+		if err != nil {
+			return nil, err
+		}
+		file := fixtureFile{name: name}
+		ext := path.Ext(name)
+		switch ext {
+		case ".sql":
+			file.sql = string(raw)
+			file.tables = sqlTables(file.sql)
+		case ".yml", ".yaml":
+			if err := yaml.Unmarshal(raw, &file.rows); err != nil {
+				return nil, fmt.Errorf("tools: fixture %s: %w", name, err)
+			}
+			table := strings.TrimSuffix(path.Base(name), ext)
+			if prefix, rest, ok := strings.Cut(table, "_"); ok && strings.Trim(prefix, "0123456789") == "" {
+				table = rest
+			}
+			file.tables = []string{table}
+		default:
+			return nil, fmt.Errorf("tools: fixture %s: unsupported file type", name)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// withConn memakai satu koneksi supaya pengaturan sesi seperti
+// FOREIGN_KEY_CHECKS di MySQL berlaku untuk semua statement.
+func (f *Fixtures) withConn(ctx context.Context, fn func(conn *sql.Conn, driver string) error) error {
+	if Pool().readOnly(f.Alias) {
+		return fmt.Errorf("%w: fixtures on %q", ErrReadOnly, f.Alias)
+	}
+	db, err := Pool().Get(f.Alias)
+	if err != nil {
+		return err
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	driver := Pool().Driver(f.Alias)
+	if driver == "mysql" {
+		if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.WithoutCancel(ctx), "SET FOREIGN_KEY_CHECKS = 1")
+	}
+	return fn(conn, driver)
+}
+
+func truncate(ctx context.Context, conn *sql.Conn, driver string, tables []string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	if driver == "postgres" {
+		_, err := conn.ExecContext(ctx, "TRUNCATE "+strings.Join(tables, ", ")+" RESTART IDENTITY CASCADE")
+		return err
+	}
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err := conn.ExecContext(ctx, "TRUNCATE TABLE "+tables[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var sqlTableRe = regexp.MustCompile("(?im)^\\s*(?:(?:INSERT|REPLACE)(?:\\s+IGNORE)?\\s+INTO|COPY)\\s+([\\w.\"`]+)")
+
+// sqlTables mengambil tabel tujuan INSERT, REPLACE, dan COPY di file .sql.
+func sqlTables(script string) []string {
+	var tables []string
+	for _, stmt := range strings.Split(script, ";") {
+		m := sqlTableRe.FindStringSubmatch(stmt)
+		if m == nil {
+			continue
+		}
+		if table := strings.NewReplacer(`"`, "", "`", "").Replace(m[1]); !slices.Contains(tables, table) {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+func insertFixture(ctx context.Context, conn *sql.Conn, driver string, file fixtureFile) error {
+	if path.Ext(file.name) == ".sql" {
+		for _, stmt := range strings.Split(file.sql, ";") {
+			if strings.TrimSpace(stmt) == "" {
+				continue
+			}
+			if _, err := conn.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, row := range file.rows {
+		columns := make([]string, 0, len(row))
+		for c := range row {
+			columns = append(columns, c)
+		}
+		sort.Strings(columns)
+		args := make([]any, len(columns))
+		for i, c := range columns {
+			args[i] = row[c]
+			// map dan list YAML untuk kolom JSON
+			switch v := row[c].(type) {
+			case map[string]any, []any:
+				raw, err := json.Marshal(v)
+				if err != nil {
+					return err
+				}
+				args[i] = string(raw)
+			}
+		}
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			file.tables[0], strings.Join(columns, ", "), Placeholders(driver, 1, len(columns)))
+		if _, err := conn.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	// id eksplisit tidak memajukan sequence postgres
+	if driver == "postgres" && len(file.rows) > 0 {
+		if _, ok := file.rows[0]["id"]; ok {
+			table := file.tables[0]
+			query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', 'id'), (SELECT MAX(id) FROM %s))", table, table)
+			if _, err := conn.ExecContext(ctx, query); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type labelsKey struct{}
+
+// WithLabels menambahkan label ke context. Untuk alias dengan
+// DBConfig.SQLCommenter, Exec dan Query menulis label tersebut sebagai
+// komentar sqlcommenter, misalnya
+//
+//	SELECT ... /*request_id='abc',route='%2Forders%2F%3Aid'*/
+//
+// sehingga query lambat di monitoring database bisa ditelusuri ke endpoint.
+// Label yang sudah ada ditimpa.
+func WithLabels(ctx context.Context, labels map[string]string) context.Context {
+	merged := make(map[string]string, len(labels))
+	for k, v := range LabelsFrom(ctx) {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, labelsKey{}, merged)
+}
+
+// LabelsFrom mengembalikan label pada context; map tidak boleh diubah.
+func LabelsFrom(ctx context.Context) map[string]string {
+	labels, _ := ctx.Value(labelsKey{}).(map[string]string)
+	return labels
+}
+
+// sqlComment menyusun komentar sqlcommenter: key dan value di-URL-encode
+// (termasuk kutip dan "*/"), value diapit kutip tunggal, pasangan diurutkan
+// berdasarkan key.
+func sqlComment(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k, v := range labels {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = url.PathEscape(k) + "='" + url.PathEscape(labels[k]) + "'"
+	}
+	return "/*" + strings.Join(pairs, ",") + "*/"
+}
+
+// annotate menambahkan komentar label ke query jika alias mengaktifkan
+// SQLCommenter. Query yang sudah berisi komentar tidak diubah, sesuai
+// spesifikasi sqlcommenter. Alias dengan StmtCacheSize juga dilewati karena
+// teks query yang berbeda per request akan memenuhi cache statement.
+func annotate(ctx context.Context, alias, query string) string {
+	if !Pool().commenter(alias) || Pool().stmtCache(alias) != nil || strings.Contains(query, "/*") {
+		return query
+	}
+	comment := sqlComment(LabelsFrom(ctx))
+	if comment == "" {
+		return query
+	}
+	trimmed := strings.TrimRight(query, " \t\n;")
+	return trimmed + " " + comment + query[len(trimmed):]
+}
+
+// queryContext memasang DBConfig.QueryTimeout alias pada ctx. Deadline ctx
+// yang lebih awal tetap berlaku.
+func queryContext(ctx context.Context, alias string) (context.Context, context.CancelFunc) {
+	timeout := Pool().queryTimeout(alias)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type (
+	// Notification adalah pesan NOTIFY dari Postgres.
+	Notification struct {
+		Channel string
+		Payload string
+	}
+
+	NotifyHandler func(Notification)
+
+	// Listener memultipleks LISTEN beberapa channel di atas satu koneksi.
+	// Koneksi yang putus disambung ulang dan channel di-LISTEN ulang otomatis.
+	Listener struct {
+		l        *pq.Listener
+		mu       sync.RWMutex
+		handlers map[string][]NotifyHandler
+		done     chan struct{}
+
+		// OnReconnect dipanggil setelah koneksi tersambung ulang. Notifikasi
+		// selama koneksi putus bisa hilang, jadi cache sebaiknya dibersihkan di sini.
+		OnReconnect func()
+	}
+)
+
+// Listen membuat Listener untuk alias postgres.
+func (dbc *dbPool) Listen(alias string) (*Listener, error) {
+	dbc.mu.RLock()
+	driver, dsn := dbc.drivers[alias], dbc.dsns[alias]
+	dbc.mu.RUnlock()
+
+	if dsn == "" {
+		return nil, errors.New("no alias found")
+	}
+	if driver != "postgres" {
+		return nil, errors.New("listen/notify requires a postgres alias")
+	}
+
+	ln := &Listener{
+		handlers: make(map[string][]NotifyHandler),
+		done:     make(chan struct{}),
+	}
+	ln.l = pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventDisconnected:
+			log.Printf("[%s] listener disconnected: %v", alias, err)
+		case pq.ListenerEventReconnected:
+			log.Printf("[%s] listener reconnected", alias)
+			if ln.OnReconnect != nil {
+				ln.OnReconnect()
+			}
+		}
+	})
+
+	go ln.run()
+	return ln, nil
+}
+
+// Subscribe mendaftarkan handler untuk channel. LISTEN hanya dikirim sekali per channel.
+func (ln *Listener) Subscribe(channel string, h NotifyHandler) error {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+
+	if _, ok := ln.handlers[channel]; !ok {
+		if err := ln.l.Listen(channel); err != nil {
+			return err
+		}
+	}
+	ln.handlers[channel] = append(ln.handlers[channel], h)
+	return nil
+}
+
+// Unsubscribe menghapus semua handler channel dan mengirim UNLISTEN.
+func (ln *Listener) Unsubscribe(channel string) error {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+
+	if _, ok := ln.handlers[channel]; !ok {
+		return nil
+	}
+	delete(ln.handlers, channel)
+	return ln.l.Unlisten(channel)
+}
+
+func (ln *Listener) Close() error {
+	close(ln.done)
+	return ln.l.Close()
+}
+
+func (ln *Listener) run() {
+	ping := time.NewTicker(90 * time.Second)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ln.done:
+			return
+		case n, ok := <-ln.l.Notify:
+			if !ok {
+				return
+			}
+			// nil dikirim pq setelah reconnect
+			if n == nil {
+				continue
+			}
+			ln.dispatch(Notification{Channel: n.Channel, Payload: n.Extra})
+		case <-ping.C:
+			go ln.l.Ping()
+		}
+	}
+}
+
+func (ln *Listener) dispatch(n Notification) {
+	ln.mu.RLock()
+	handlers := ln.handlers[n.Channel]
+	ln.mu.RUnlock()
+
+	for _, h := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("[PANIC NOTIFY] channel %s: %v", n.Channel, r)
+				}
+			}()
+			h(n)
+		}()
+	}
+}
+
+// Notify mengirim NOTIFY pada alias postgres.
+func Notify(ctx context.Context, alias, channel, payload string) error {
+	_, err := Exec(ctx, alias, "SELECT pg_notify($1, $2)", channel, payload)
+	return err
+}
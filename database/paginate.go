@@ -0,0 +1,20 @@
+package tools
+
+import "fmt"
+
+// Paginate menambahkan klausa LIMIT/OFFSET pada query. Sintaks ini berlaku
+// untuk mysql maupun postgres.
+func Paginate(query string, limit, offset int) string {
+	if limit <= 0 {
+		return query
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return fmt.Sprintf("%s LIMIT %d OFFSET %d", query, limit, offset)
+}
+
+// CountQuery membungkus query menjadi SELECT COUNT(*) untuk menghitung total baris.
+func CountQuery(query string) string {
+	return fmt.Sprintf("SELECT COUNT(*) FROM (%s) AS counted", query)
+}
@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Exec menjalankan statement pada alias. Semua helper di package ini
+// (repository, bulk insert, dsb) lewat fungsi ini dan Query.
+func Exec(ctx context.Context, alias, query string, args ...any) (sql.Result, error) {
+	db, err := Pool().Get(alias)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReadOnly(alias, query); err != nil {
+		return nil, err
+	}
+	ctx, cancel := queryContext(ctx, alias)
+	defer cancel()
+	query = annotate(ctx, alias, query)
+
+	// transaksi tidak di-retry: statement sebelumnya sudah hilang bila tx gagal
+	if tx := TxFrom(ctx, alias); tx != nil {
+		return tx.ExecContext(ctx, query, args...)
+	}
+
+	sc := Pool().stmtCache(alias)
+	var res sql.Result
+	err = Pool().retryPolicy(alias).do(ctx, true, func() error {
+		var err error
+		if sc != nil {
+			res, err = sc.exec(ctx, query, args...)
+		} else {
+			res, err = db.ExecContext(ctx, query, args...)
+		}
+		return err
+	})
+	return res, err
+}
+
+// Rows adalah *sql.Rows yang Close-nya juga melepas context QueryTimeout.
+type Rows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+}
+
+// Close menutup rows dan membatalkan context query-nya.
+func (r *Rows) Close() error {
+	err := r.Rows.Close()
+	r.cancel()
+	return err
+}
+
+// Query menjalankan query pada alias dan mengembalikan rows yang harus ditutup pemanggil.
+func Query(ctx context.Context, alias, query string, args ...any) (*Rows, error) {
+	db, err := Pool().Get(alias)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkReadOnly(alias, query); err != nil {
+		return nil, err
+	}
+	// rows dibaca setelah Query kembali, jadi context QueryTimeout baru
+	// dibatalkan saat Rows.Close
+	ctx, cancel := queryContext(ctx, alias)
+	query = annotate(ctx, alias, query)
+
+	if tx := TxFrom(ctx, alias); tx != nil {
+		rows, err := tx.QueryContext(ctx, query, args...)
+		return wrapRows(rows, err, cancel)
+	}
+
+	// INSERT ... RETURNING lewat Query tetap diperlakukan sebagai write
+	write := writeKeyword(query, Pool().Driver(alias) == "mysql") != ""
+	sc := Pool().stmtCache(alias)
+	var rows *sql.Rows
+	err = Pool().retryPolicy(alias).do(ctx, write, func() error {
+		var err error
+		if sc != nil {
+			rows, err = sc.query(ctx, query, args...)
+		} else {
+			rows, err = db.QueryContext(ctx, query, args...)
+		}
+		return err
+	})
+	return wrapRows(rows, err, cancel)
+}
+
+func wrapRows(rows *sql.Rows, err error, cancel context.CancelFunc) (*Rows, error) {
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Rows{Rows: rows, cancel: cancel}, nil
+}
+
+// Placeholders menghasilkan n placeholder mulai dari start sesuai driver:
+// "$1, $2" untuk postgres, "?, ?" untuk mysql.
+func Placeholders(driver string, start, n int) string {
+	marks := make([]string, n)
+	for i := range marks {
+		marks[i] = placeholder(driver, start+i)
+	}
+	return strings.Join(marks, ", ")
+}
+
+func placeholder(driver string, n int) string {
+	if driver == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
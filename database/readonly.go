@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrReadOnly dikembalikan Exec dan Query ketika statement tulis atau DDL
+// dijalankan ke alias dengan DBConfig.ReadOnly.
+var ErrReadOnly = errors.New("tools: alias is read-only")
+
+var writeKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "REPLACE": true, "MERGE": true, "UPSERT": true,
+	"CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true, "RENAME": true,
+	"GRANT": true, "REVOKE": true, "COMMENT": true, "CALL": true, "LOAD": true, "COPY": true,
+}
+
+// checkReadOnly menolak statement tulis untuk alias read-only.
+func checkReadOnly(alias, query string) error {
+	if !Pool().readOnly(alias) {
+		return nil
+	}
+	if kw := writeKeyword(query, Pool().Driver(alias) == "mysql"); kw != "" {
+		return fmt.Errorf("%w: %s statement on %q", ErrReadOnly, kw, alias)
+	}
+	return nil
+}
+
+// writeKeyword mengembalikan kata kunci tulis pada query, atau "" untuk
+// statement baca. Statement ditentukan dari kata pertama; untuk WITH, CTE
+// yang berisi INSERT/UPDATE/DELETE juga dianggap tulis. Komentar, string,
+// dan identifier berkutip dilewati. Beberapa statement dipisah ";" diperiksa
+// semuanya.
+func writeKeyword(query string, mysql bool) string {
+	first := true
+	cte := false
+	prev := ""
+	for _, word := range sqlWords(query, mysql) {
+		if word == ";" {
+			first, cte, prev = true, false, ""
+			continue
+		}
+		switch {
+		case first:
+			first = false
+			if word == "WITH" {
+				cte = true
+			} else if writeKeywords[word] {
+				return word
+			}
+		case cte && prev != "FOR" && (word == "INSERT" || word == "UPDATE" || word == "DELETE" || word == "MERGE"):
+			return word
+		}
+		prev = word
+	}
+	return ""
+}
+
+// sqlWords memecah query menjadi kata (huruf besar) dan ";", tanpa isi
+// komentar, string, dan identifier berkutip. Untuk MySQL, \' tidak menutup
+// string dan # memulai komentar.
+func sqlWords(query string, mysql bool) []string {
+	var words []string
+	rs := []rune(query)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		switch {
+		case r == '-' && i+1 < len(rs) && rs[i+1] == '-', r == '#' && mysql:
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			i += 2
+			for i+1 < len(rs) && !(rs[i] == '*' && rs[i+1] == '/') {
+				i++
+			}
+			i++
+		case r == '\'' || r == '"' || r == '`':
+			for i++; i < len(rs); i++ {
+				if rs[i] == '\\' && r == '\'' && mysql {
+					i++
+					continue
+				}
+				if rs[i] == r {
+					if i+1 < len(rs) && rs[i+1] == r { // kutip ganda sebagai escape
+						i++
+						continue
+					}
+					break
+				}
+			}
+		case r == ';':
+			words = append(words, ";")
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i+1 < len(rs) && (unicode.IsLetter(rs[i+1]) || unicode.IsDigit(rs[i+1]) || rs[i+1] == '_' || rs[i+1] == '$') {
+				i++
+			}
+			words = append(words, strings.ToUpper(string(rs[start:i+1])))
+		}
+	}
+	return words
+}
@@ -0,0 +1,350 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godev90/validator/faults"
+)
+
+var (
+	// ErrNotFound dikembalikan jika baris tidak ada atau sudah di-soft-delete.
+	ErrNotFound = faults.ErrNotFound
+	// ErrVersionConflict dikembalikan Update jika kolom version sudah berubah.
+	ErrVersionConflict = faults.ErrConflict
+)
+
+type column struct {
+	name  string
+	index int
+}
+
+type tableMeta struct {
+	columns   []column
+	pk        *column
+	version   *column
+	deletedAt *column
+}
+
+var tableMetas sync.Map
+
+// metaFor membaca tag `db` pada struct. Opsi yang dikenali:
+//
+//	ID        int64      `db:"id,pk"`
+//	Version   int        `db:"version,version"`
+//	DeletedAt *time.Time `db:"deleted_at,softdelete"`
+//
+// Kolom bernama deleted_at otomatis dianggap kolom soft delete.
+func metaFor(t reflect.Type) *tableMeta {
+	if m, ok := tableMetas.Load(t); ok {
+		return m.(*tableMeta)
+	}
+
+	meta := &tableMeta{}
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		col := column{name: parts[0], index: i}
+		meta.columns = append(meta.columns, col)
+
+		c := &meta.columns[len(meta.columns)-1]
+		for _, opt := range parts[1:] {
+			switch opt {
+			case "pk":
+				meta.pk = c
+			case "version":
+				meta.version = c
+			case "softdelete":
+				meta.deletedAt = c
+			}
+		}
+		if col.name == "deleted_at" && meta.deletedAt == nil {
+			meta.deletedAt = c
+		}
+	}
+
+	// pointer ke elemen slice bisa berubah selama append, jadi ambil ulang
+	for i := range meta.columns {
+		c := &meta.columns[i]
+		if meta.pk != nil && meta.pk.index == c.index {
+			meta.pk = c
+		}
+		if meta.version != nil && meta.version.index == c.index {
+			meta.version = c
+		}
+		if meta.deletedAt != nil && meta.deletedAt.index == c.index {
+			meta.deletedAt = c
+		}
+	}
+
+	m, _ := tableMetas.LoadOrStore(t, meta)
+	return m.(*tableMeta)
+}
+
+// Repository menyediakan CRUD untuk struct T pada tabel dan alias tertentu.
+type Repository[T any] struct {
+	Alias string
+	Table string
+	meta  *tableMeta
+}
+
+func NewRepository[T any](alias, table string) *Repository[T] {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("tools: repository type %s must be a struct", t))
+	}
+
+	meta := metaFor(t)
+	if meta.pk == nil {
+		panic(fmt.Sprintf("tools: repository type %s has no `db:\"...,pk\"` field", t))
+	}
+	return &Repository[T]{Alias: alias, Table: table, meta: meta}
+}
+
+func (r *Repository[T]) driver() string {
+	return Pool().Driver(r.Alias)
+}
+
+func (r *Repository[T]) columnList() string {
+	names := make([]string, len(r.meta.columns))
+	for i, c := range r.meta.columns {
+		names[i] = c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+func (r *Repository[T]) notDeleted() string {
+	if r.meta.deletedAt == nil {
+		return ""
+	}
+	return " AND " + r.meta.deletedAt.name + " IS NULL"
+}
+
+// Find mengambil satu baris berdasarkan primary key, mengabaikan baris yang sudah dihapus.
+func (r *Repository[T]) Find(ctx context.Context, id any) (*T, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = %s%s",
+		r.columnList(), r.Table, r.meta.pk.name, placeholder(r.driver(), 1), r.notDeleted())
+
+	items, err := r.query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	return items[0], nil
+}
+
+// Where mengambil baris yang cocok dengan kondisi (tanpa kata WHERE),
+// otomatis mengabaikan baris yang sudah dihapus.
+func (r *Repository[T]) Where(ctx context.Context, cond string, args ...any) ([]*T, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE (%s)%s", r.columnList(), r.Table, cond, r.notDeleted())
+	return r.query(ctx, query, args...)
+}
+
+func (r *Repository[T]) query(ctx context.Context, query string, args ...any) ([]*T, error) {
+	rows, err := Query(ctx, r.Alias, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*T
+	for rows.Next() {
+		item := new(T)
+		v := reflect.ValueOf(item).Elem()
+		dest := make([]any, len(r.meta.columns))
+		for i, c := range r.meta.columns {
+			dest[i] = v.Field(c.index).Addr().Interface()
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// Insert menyimpan item. Primary key bernilai zero dianggap auto increment
+// dan diisi kembali setelah insert.
+func (r *Repository[T]) Insert(ctx context.Context, item *T) error {
+	v := reflect.ValueOf(item).Elem()
+	driver := r.driver()
+
+	var (
+		names []string
+		args  []any
+	)
+	pkField := v.Field(r.meta.pk.index)
+	autoPK := pkField.IsZero()
+	for _, c := range r.meta.columns {
+		if autoPK && c.index == r.meta.pk.index {
+			continue
+		}
+		if r.meta.version != nil && c.index == r.meta.version.index && v.Field(c.index).IsZero() {
+			setInt(v.Field(c.index), 1)
+		}
+		names = append(names, c.name)
+		args = append(args, v.Field(c.index).Interface())
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		r.Table, strings.Join(names, ", "), Placeholders(driver, 1, len(names)))
+
+	if autoPK && driver == "postgres" {
+		rows, err := Query(ctx, r.Alias, query+" RETURNING "+r.meta.pk.name, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		if rows.Next() {
+			if err := rows.Scan(pkField.Addr().Interface()); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	}
+
+	res, err := Exec(ctx, r.Alias, query, args...)
+	if err != nil {
+		return err
+	}
+	if autoPK {
+		if id, err := res.LastInsertId(); err == nil {
+			setInt(pkField, id)
+		}
+	}
+	return nil
+}
+
+// Update menyimpan semua kolom item. Jika struct punya kolom version, update
+// hanya berhasil bila version di database sama (optimistic locking) dan
+// version pada item dinaikkan; selain itu ErrVersionConflict dikembalikan.
+func (r *Repository[T]) Update(ctx context.Context, item *T) error {
+	v := reflect.ValueOf(item).Elem()
+	driver := r.driver()
+
+	var (
+		sets []string
+		args []any
+	)
+	n := 1
+	for _, c := range r.meta.columns {
+		if c.index == r.meta.pk.index {
+			continue
+		}
+		if r.meta.version != nil && c.index == r.meta.version.index {
+			sets = append(sets, fmt.Sprintf("%s = %s + 1", c.name, c.name))
+			continue
+		}
+		sets = append(sets, fmt.Sprintf("%s = %s", c.name, placeholder(driver, n)))
+		args = append(args, v.Field(c.index).Interface())
+		n++
+	}
+
+	where := fmt.Sprintf("%s = %s", r.meta.pk.name, placeholder(driver, n))
+	args = append(args, v.Field(r.meta.pk.index).Interface())
+	n++
+	if r.meta.version != nil {
+		where += fmt.Sprintf(" AND %s = %s", r.meta.version.name, placeholder(driver, n))
+		args = append(args, v.Field(r.meta.version.index).Interface())
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s%s", r.Table, strings.Join(sets, ", "), where, r.notDeleted())
+	res, err := Exec(ctx, r.Alias, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// MySQL menghitung baris yang berubah, bukan yang cocok, sehingga
+		// update tanpa perubahan juga menghasilkan 0.
+		found, err := r.exists(ctx, v.Field(r.meta.pk.index).Interface())
+		if err != nil {
+			return err
+		}
+		switch {
+		case !found:
+			return ErrNotFound
+		case r.meta.version != nil:
+			return ErrVersionConflict
+		}
+		return nil
+	}
+
+	if r.meta.version != nil {
+		vf := v.Field(r.meta.version.index)
+		setInt(vf, intValue(vf)+1)
+	}
+	return nil
+}
+
+// exists memeriksa apakah baris dengan id ada dan belum dihapus.
+func (r *Repository[T]) exists(ctx context.Context, id any) (bool, error) {
+	query := fmt.Sprintf("SELECT 1 FROM %s WHERE %s = %s%s",
+		r.Table, r.meta.pk.name, placeholder(r.driver(), 1), r.notDeleted())
+
+	rows, err := Query(ctx, r.Alias, query, id)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	found := rows.Next()
+	return found, rows.Err()
+}
+
+// SoftDelete mengisi kolom deleted_at. Tanpa kolom soft delete, baris dihapus permanen.
+func (r *Repository[T]) SoftDelete(ctx context.Context, id any) error {
+	driver := r.driver()
+
+	var (
+		query string
+		args  []any
+	)
+	if r.meta.deletedAt == nil {
+		query = fmt.Sprintf("DELETE FROM %s WHERE %s = %s", r.Table, r.meta.pk.name, placeholder(driver, 1))
+		args = []any{id}
+	} else {
+		query = fmt.Sprintf("UPDATE %s SET %s = %s WHERE %s = %s%s", r.Table, r.meta.deletedAt.name,
+			placeholder(driver, 1), r.meta.pk.name, placeholder(driver, 2), r.notDeleted())
+		args = []any{time.Now(), id}
+	}
+
+	res, err := Exec(ctx, r.Alias, query, args...)
+	if err != nil {
+		return err
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func intValue(v reflect.Value) int64 {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	}
+	return v.Int()
+}
+
+func setInt(v reflect.Value, n int64) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(n))
+	}
+}
@@ -0,0 +1,96 @@
+package tools
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/godev90/validator/faults"
+)
+
+type versionedItem struct {
+	ID      int64  `db:"id,pk"`
+	Name    string `db:"name"`
+	Version uint32 `db:"version,version"`
+}
+
+func TestRepositoryUnsignedVersion(t *testing.T) {
+	fake := useFakeDB(t, "repo_version", "mysql")
+	repo := NewRepository[versionedItem]("repo_version", "items")
+	ctx := context.Background()
+
+	item := &versionedItem{Name: "a"}
+	fake.push(fakeResult{rowsAffected: 1, lastID: 7})
+	if err := repo.Insert(ctx, item); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if item.ID != 7 || item.Version != 1 {
+		t.Fatalf("after Insert: id=%d version=%d, want 7 and 1", item.ID, item.Version)
+	}
+	if got := fake.calls[0].args; len(got) != 2 || got[1] != int64(1) {
+		t.Fatalf("Insert args = %v, want version 1", got)
+	}
+
+	fake.push(fakeResult{rowsAffected: 1})
+	if err := repo.Update(ctx, item); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if item.Version != 2 {
+		t.Fatalf("after Update: version=%d, want 2", item.Version)
+	}
+	call := fake.calls[1]
+	if !strings.Contains(call.query, "version = version + 1") || !strings.HasSuffix(call.query, "AND version = ?") {
+		t.Fatalf("Update query = %q", call.query)
+	}
+	if last := call.args[len(call.args)-1]; last != int64(1) {
+		t.Fatalf("Update compared version %v, want 1", last)
+	}
+}
+
+func TestRepositoryUpdateZeroRows(t *testing.T) {
+	exists := fakeResult{columns: []string{"1"}, rows: [][]driver.Value{{int64(1)}}}
+	missing := fakeResult{columns: []string{"1"}}
+
+	tests := []struct {
+		name    string
+		lookup  fakeResult
+		version uint32
+		want    error
+	}{
+		{"stale version", exists, 3, ErrVersionConflict},
+		{"missing row", missing, 3, ErrNotFound},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := useFakeDB(t, "repo_zero", "mysql")
+			repo := NewRepository[versionedItem]("repo_zero", "items")
+
+			item := &versionedItem{ID: 5, Name: "a", Version: tc.version}
+			fake.push(fakeResult{rowsAffected: 0}, tc.lookup)
+			err := repo.Update(context.Background(), item)
+			if err == nil || !faults.Is(err, tc.want) {
+				t.Fatalf("Update error = %v, want %v", err, tc.want)
+			}
+			if item.Version != tc.version {
+				t.Fatalf("version changed to %d after failed Update", item.Version)
+			}
+		})
+	}
+}
+
+type plainItem struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+// MySQL melaporkan 0 baris untuk update yang tidak mengubah apa pun.
+func TestRepositoryUpdateUnchangedRow(t *testing.T) {
+	fake := useFakeDB(t, "repo_plain", "mysql")
+	repo := NewRepository[plainItem]("repo_plain", "items")
+
+	fake.push(fakeResult{rowsAffected: 0}, fakeResult{columns: []string{"1"}, rows: [][]driver.Value{{int64(1)}}})
+	if err := repo.Update(context.Background(), &plainItem{ID: 5, Name: "a"}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// RetryPolicy mengatur pengulangan Exec dan Query ketika database mengembalikan
+// error sementara (deadlock, failover, koneksi terputus).
+type RetryPolicy struct {
+	// MaxAttempts adalah jumlah percobaan total. Nilai <= 1 berarti tanpa retry.
+	MaxAttempts int
+	// Backoff adalah jeda sebelum percobaan kedua, dilipatgandakan tiap percobaan.
+	// Default 50ms.
+	Backoff time.Duration
+	// MaxBackoff membatasi jeda. Default 2 detik.
+	MaxBackoff time.Duration
+	// Retryable menentukan apakah error layak diulang, untuk Exec maupun
+	// Query. Default IsTransient untuk Query dan IsRetryableWrite untuk Exec.
+	Retryable func(error) bool
+	// RetryAmbiguousWrites membuat Exec ikut mengulang error jaringan yang
+	// ambigu (koneksi putus di tengah statement). Statement yang sebenarnya
+	// sudah commit di server akan dijalankan dua kali, jadi aktifkan hanya
+	// jika semua write idempotent.
+	RetryAmbiguousWrites bool
+}
+
+// IsRetryableWrite mengenali error yang pasti terjadi sebelum statement
+// berlaku: koneksi ditolak, driver.ErrBadConn, atau statement yang
+// dibatalkan server karena deadlock, serialization failure, atau read-only.
+// Aman diulang untuk write yang tidak idempotent.
+func IsRetryableWrite(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case 1205, // lock wait timeout, statement di-rollback
+			1213, // deadlock, transaksi di-rollback
+			1290, // read-only saat failover
+			1792: // read-only transaction
+			return true
+		}
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "40001", "40P01", // serialization failure, deadlock
+			"08001", "08004", // koneksi gagal dibuat atau ditolak
+			"25006": // read-only transaction
+			return true
+		}
+	}
+	return false
+}
+
+// IsTransient mengenali error yang biasanya hilang jika query diulang,
+// termasuk error jaringan yang ambigu. Hanya aman untuk operasi idempotent
+// seperti SELECT; Exec memakai IsRetryableWrite.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case 1205, // lock wait timeout
+			1213, // deadlock
+			1290, // read-only saat failover
+			1792: // read-only transaction
+			return true
+		}
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "40001", "40P01", "57P01", "08000", "08001", "08003", "08004", "08006", "25006":
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "connection reset")
+}
+
+// do menjalankan fn dengan retry. write menandai Exec: tanpa Retryable
+// kustom, hanya error IsRetryableWrite yang diulang kecuali
+// RetryAmbiguousWrites aktif.
+func (p RetryPolicy) do(ctx context.Context, write bool, fn func() error) error {
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsTransient
+		if write && !p.RetryAmbiguousWrites {
+			retryable = IsRetryableWrite
+		}
+	}
+	backoff := p.Backoff
+	if backoff <= 0 {
+		backoff = 50 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 2 * time.Second
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || attempt >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
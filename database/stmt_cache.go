@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// stmtCache menyimpan prepared statement per teks query dengan kebijakan LRU.
+// Entry dihitung pemakainya: statement yang dikeluarkan dari cache (LRU atau
+// invalidate) baru ditutup setelah pemakai terakhir selesai.
+type stmtCache struct {
+	db    *sql.DB
+	size  int
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type stmtEntry struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int  // dilindungi stmtCache.mu
+	evicted bool // sudah keluar dari cache, tutup saat refs 0
+}
+
+func newStmtCache(db *sql.DB, size int) *stmtCache {
+	return &stmtCache{
+		db:    db,
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// acquire mengembalikan entry untuk query dengan refs sudah dinaikkan;
+// pemanggil wajib memanggil release.
+func (c *stmtCache) acquire(ctx context.Context, query string) (*stmtEntry, error) {
+	c.mu.Lock()
+	if el, ok := c.items[query]; ok {
+		c.ll.MoveToFront(el)
+		entry := el.Value.(*stmtEntry)
+		entry.refs++
+		c.mu.Unlock()
+		return entry, nil
+	}
+	c.mu.Unlock()
+
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// goroutine lain mungkin sudah menyiapkan query yang sama
+	if el, ok := c.items[query]; ok {
+		stmt.Close()
+		c.ll.MoveToFront(el)
+		entry := el.Value.(*stmtEntry)
+		entry.refs++
+		return entry, nil
+	}
+
+	entry := &stmtEntry{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.ll.PushFront(entry)
+	if c.ll.Len() > c.size {
+		c.evict(c.ll.Back())
+	}
+	return entry, nil
+}
+
+func (c *stmtCache) release(entry *stmtEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// evict mengeluarkan el dari cache; c.mu harus sudah dikunci.
+func (c *stmtCache) evict(el *list.Element) {
+	entry := el.Value.(*stmtEntry)
+	c.ll.Remove(el)
+	delete(c.items, entry.query)
+	entry.evicted = true
+	if entry.refs == 0 {
+		entry.stmt.Close()
+	}
+}
+
+// invalidate membuang entry setelah error yang membuat statement tidak bisa
+// dipakai lagi, sehingga pemanggilan berikutnya menyiapkan ulang.
+func (c *stmtCache) invalidate(entry *stmtEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[entry.query]; ok && el.Value == entry {
+		c.evict(el)
+	}
+}
+
+// staleStmt mengenali error karena statement sudah tidak valid (koneksi
+// putus atau skema berubah). Error lain, misalnya constraint violation,
+// tidak membuang statement.
+func staleStmt(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case 1243, // unknown prepared statement handler
+			1615: // prepared statement needs to be re-prepared
+			return true
+		}
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "26000", // prepared statement does not exist
+			"0A000": // cached plan must not change result type
+			return true
+		}
+	}
+	return false
+}
+
+func (c *stmtCache) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(entry)
+
+	res, err := entry.stmt.ExecContext(ctx, args...)
+	if err != nil && staleStmt(err) {
+		c.invalidate(entry)
+	}
+	return res, err
+}
+
+// query melepas entry segera setelah QueryContext kembali; rows yang masih
+// terbuka menahan statement sendiri (database/sql menunda penutupannya).
+func (c *stmtCache) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(entry)
+
+	rows, err := entry.stmt.QueryContext(ctx, args...)
+	if err != nil && staleStmt(err) {
+		c.invalidate(entry)
+	}
+	return rows, err
+}
@@ -0,0 +1,20 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{ alias string }
+
+// WithTx menyimpan transaksi untuk alias pada context. Exec dan Query yang
+// dipanggil dengan context ini otomatis berjalan di dalam transaksi tersebut.
+func WithTx(ctx context.Context, alias string, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txKey{alias}, tx)
+}
+
+// TxFrom mengembalikan transaksi aktif untuk alias, atau nil.
+func TxFrom(ctx context.Context, alias string) *sql.Tx {
+	tx, _ := ctx.Value(txKey{alias}).(*sql.Tx)
+	return tx
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	logging "github.com/godev90/netpath/helpers/logging"
+)
+
+// ProfileStore menyimpan hasil capture diagnostik.
+type ProfileStore interface {
+	Save(ctx context.Context, name string, data []byte) error
+	List(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// DirProfileStore menyimpan profil sebagai file di satu direktori.
+type DirProfileStore string
+
+func (d DirProfileStore) Save(ctx context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(string(d), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(string(d), name), data, 0o640)
+}
+
+func (d DirProfileStore) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(string(d))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+func (d DirProfileStore) Delete(ctx context.Context, name string) error {
+	return os.Remove(filepath.Join(string(d), filepath.Base(name)))
+}
+
+type DiagnosticsConfig struct {
+	Store ProfileStore
+	// Keep adalah jumlah capture terakhir yang disimpan, default 10.
+	Keep int
+	// MaxAge menghapus capture yang lebih tua. 0 berarti tanpa batas umur.
+	MaxAge time.Duration
+	// MutexFraction diteruskan ke runtime.SetMutexProfileFraction, default 5.
+	MutexFraction int
+}
+
+// WithDiagnostics mengaktifkan CaptureDiagnostics dan route
+// {prefix}/diagnostics di MountAdmin.
+func WithDiagnostics(cfg DiagnosticsConfig) Option {
+	if cfg.Store == nil {
+		panic("netpath: diagnostics requires a ProfileStore")
+	}
+	if cfg.Keep <= 0 {
+		cfg.Keep = 10
+	}
+	if cfg.MutexFraction == 0 {
+		cfg.MutexFraction = 5
+	}
+	return func(app *App) {
+		runtime.SetMutexProfileFraction(cfg.MutexFraction)
+		app.diagnostics = &diagnostics{cfg: cfg}
+	}
+}
+
+type diagnostics struct {
+	cfg DiagnosticsConfig
+	mu  sync.Mutex // satu capture dalam satu waktu
+}
+
+// diagnosticsStamp adalah prefix nama file satu capture; urutan leksikal
+// sama dengan urutan waktu.
+const diagnosticsStamp = "20060102T150405.000Z"
+
+var errNoDiagnostics = errors.New("netpath: diagnostics not configured, use WithDiagnostics")
+
+// CaptureDiagnostics menyimpan profil goroutine (lengkap dengan stack),
+// heap, dan mutex ke ProfileStore lalu menerapkan retensi. Mengembalikan
+// nama file yang tersimpan.
+func (app *App) CaptureDiagnostics(ctx context.Context) ([]string, error) {
+	d := app.diagnostics
+	if d == nil {
+		return nil, errNoDiagnostics
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stamp := time.Now().UTC().Format(diagnosticsStamp)
+	profiles := []struct {
+		name, file string
+		debug      int
+	}{
+		{"goroutine", stamp + "-goroutine.txt", 2},
+		{"heap", stamp + "-heap.pb.gz", 0},
+		{"mutex", stamp + "-mutex.pb.gz", 0},
+	}
+
+	var saved []string
+	for _, p := range profiles {
+		var buf bytes.Buffer
+		if err := pprof.Lookup(p.name).WriteTo(&buf, p.debug); err != nil {
+			return saved, err
+		}
+		if err := d.cfg.Store.Save(ctx, p.file, buf.Bytes()); err != nil {
+			return saved, err
+		}
+		saved = append(saved, p.file)
+	}
+	return saved, d.prune(ctx)
+}
+
+// prune menghapus capture di luar Keep terbaru atau lebih tua dari MaxAge.
+func (d *diagnostics) prune(ctx context.Context) error {
+	names, err := d.cfg.Store.List(ctx)
+	if err != nil {
+		return err
+	}
+	byStamp := map[string][]string{}
+	for _, name := range names {
+		stamp, _, ok := strings.Cut(name, "-")
+		if !ok {
+			continue
+		}
+		if _, err := time.Parse(diagnosticsStamp, stamp); err == nil {
+			byStamp[stamp] = append(byStamp[stamp], name)
+		}
+	}
+	stamps := make([]string, 0, len(byStamp))
+	for s := range byStamp {
+		stamps = append(stamps, s)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(stamps)))
+
+	for i, s := range stamps {
+		at, _ := time.Parse(diagnosticsStamp, s)
+		if i < d.cfg.Keep && (d.cfg.MaxAge == 0 || time.Since(at) <= d.cfg.MaxAge) {
+			continue
+		}
+		for _, name := range byStamp[s] {
+			if err := d.cfg.Store.Delete(ctx, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CaptureOnSignal menangkap SIGQUIT (atau sig lain) dan menyimpan diagnostik
+// alih-alih membiarkan runtime mencetak stack lalu keluar, sehingga hang di
+// production bisa diperiksa tanpa mematikan proses.
+func (app *App) CaptureOnSignal(sig ...os.Signal) {
+	if len(sig) == 0 {
+		sig = []os.Signal{syscall.SIGQUIT}
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+
+	go func() {
+		for range ch {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			files, err := app.CaptureDiagnostics(ctx)
+			cancel()
+			if err != nil {
+				logging.Errorf("diagnostics capture: %v", err)
+				continue
+			}
+			logging.Infof("diagnostics captured: %s", strings.Join(files, ", "))
+		}
+	}()
+}
+
+func (app *App) mountDiagnostics(g *Router) {
+	if app.diagnostics == nil {
+		return
+	}
+	g.GET("/diagnostics", func(ctx *Context) error {
+		names, err := app.diagnostics.cfg.Store.List(ctx.request.Context())
+		if err != nil {
+			return ctx.ServerError(err)
+		}
+		sort.Sort(sort.Reverse(sort.StringSlice(names)))
+		return ctx.Success(names)
+	})
+	g.POST("/diagnostics", func(ctx *Context) error {
+		files, err := app.CaptureDiagnostics(ctx.request.Context())
+		if err != nil {
+			return ctx.ServerError(err)
+		}
+		ctx.httpStatus = http.StatusCreated
+		return ctx.JSON(http.StatusCreated, map[string]any{
+			"code": http.StatusCreated,
+			"data": files,
+		})
+	})
+}
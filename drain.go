@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DrainConfig mengatur cara Shutdown memutus koneksi long-lived (SSE dan
+// WebSocket) yang didaftarkan lewat ctx.Hold.
+type DrainConfig struct {
+	// Grace adalah waktu bagi client untuk menutup koneksi sendiri dan
+	// reconnect ke instance lain setelah menerima sinyal terminasi.
+	// Default 5 detik.
+	Grace time.Duration
+	// Event adalah nama event SSE yang dikirim saat drain. Default "shutdown".
+	Event string
+}
+
+func WithDrain(cfg DrainConfig) Option {
+	return func(app *App) {
+		app.drainCfg = cfg
+	}
+}
+
+// LongLived adalah koneksi yang bertahan melewati satu request, misalnya
+// *websocket.Conn atau *SSEStream.
+type LongLived interface {
+	// Drain mengirim sinyal terminasi ke client tanpa memutus koneksi.
+	Drain()
+	// Abort memutus koneksi secara paksa setelah grace window habis.
+	Abort() error
+}
+
+type drainRegistry struct {
+	mu       sync.Mutex
+	conns    map[LongLived]struct{}
+	draining bool
+}
+
+// Hold mendaftarkan conn supaya Shutdown bisa memberi sinyal dan menunggunya
+// sebelum memutus paksa. Panggil fungsi yang dikembalikan ketika koneksi
+// selesai. Koneksi yang dibuka saat drain sudah berjalan langsung di-Drain.
+func (c *Context) Hold(conn LongLived) (release func()) {
+	r := &c.app.drains
+	r.mu.Lock()
+	if r.conns == nil {
+		r.conns = make(map[LongLived]struct{})
+	}
+	r.conns[conn] = struct{}{}
+	draining := r.draining
+	r.mu.Unlock()
+
+	if draining {
+		conn.Drain()
+	}
+	return func() {
+		r.mu.Lock()
+		delete(r.conns, conn)
+		r.mu.Unlock()
+	}
+}
+
+func (r *drainRegistry) snapshot() []LongLived {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	list := make([]LongLived, 0, len(r.conns))
+	for conn := range r.conns {
+		list = append(list, conn)
+	}
+	return list
+}
+
+// drain memberi sinyal ke semua koneksi long-lived, menunggu sampai semuanya
+// dilepas atau grace/ctx habis, lalu memutus sisanya.
+func (app *App) drain(ctx context.Context) {
+	r := &app.drains
+	r.mu.Lock()
+	r.draining = true
+	r.mu.Unlock()
+
+	conns := r.snapshot()
+	if len(conns) == 0 {
+		return
+	}
+	for _, conn := range conns {
+		conn.Drain()
+	}
+
+	grace := app.drainCfg.Grace
+	if grace <= 0 {
+		grace = 5 * time.Second
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	tick := time.NewTicker(50 * time.Millisecond)
+	defer tick.Stop()
+
+wait:
+	for len(r.snapshot()) > 0 {
+		select {
+		case <-tick.C:
+		case <-timer.C:
+			break wait
+		case <-ctx.Done():
+			break wait
+		}
+	}
+
+	for _, conn := range r.snapshot() {
+		conn.Abort()
+	}
+}
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/godev90/validator/faults"
+	"golang.org/x/text/language"
+)
+
+// errorCatalog menyimpan faults.Error yang bisa diterjemahkan lewat key,
+// misalnya "err_required", beserta daftar bahasa yang terdaftar.
+var errorCatalog = struct {
+	mu        sync.RWMutex
+	errors    map[string]faults.Error
+	languages []faults.LanguageTag
+}{
+	errors: map[string]faults.Error{
+		"err_bad_request":             faults.ErrBadRequest,
+		"err_unauthorized":            faults.ErrUnauthorized,
+		"err_forbidden":               faults.ErrForbidden,
+		"err_not_found":               faults.ErrNotFound,
+		"err_method_not_allowed":      faults.ErrMethodNotAllowed,
+		"err_conflict":                faults.ErrConflict,
+		"err_gone":                    faults.ErrGone,
+		"err_precondition_failed":     faults.ErrPreconditionFailed,
+		"err_payload_too_large":       faults.ErrPayloadTooLarge,
+		"err_unsupported_media_type":  faults.ErrUnsupportedMediaType,
+		"err_unprocessable_entity":    faults.ErrUnprocessableEntity,
+		"err_too_many_requests":       faults.ErrTooManyRequests,
+		"err_internal_server_error":   faults.ErrInternalServerError,
+		"err_service_unavailable":     faults.ErrServiceUnavailable,
+		"err_gateway_timeout":         faults.ErrGatewayTimeout,
+		"err_required":                faults.ErrRequired,
+		"err_below_minimum":           faults.ErrBelowMinimum,
+		"err_above_maximum":           faults.ErrAboveMaximum,
+		"err_must_be_email":           faults.ErrMustBeEmail,
+		"err_must_be_digit":           faults.ErrMustBeDigit,
+		"err_must_be_alphanum":        faults.ErrMustBeAlphanum,
+		"err_must_be_alphabet":        faults.ErrMustBeAlphabet,
+		"err_length_below_minimum":    faults.ErrLengthBelowMinimum,
+		"err_length_above_maximum":    faults.ErrLengthAboveMaximum,
+		"err_invalid_date_format":     faults.ErrInvalidDateFormat,
+		"err_invalid_datetime_format": faults.ErrInvalidDatetimeFormat,
+		"err_invalid_parameter":       faults.ErrInvalidParameter,
+		"err_must_be_one_of":          faults.ErrMustBeOneOf,
+		"err_cannot_be_null":          faults.ErrCannotBeNull,
+		"err_type_mismatch":           faults.ErrTypeMismatch,
+		"err_invalid_numeric_format":  faults.ErrInvalidNumericFormat,
+		"err_invalid_float_number":    faults.ErrInvalidFloatNumber,
+		"err_invalid_integer_number":  faults.ErrInvalidIntegerNumber,
+	},
+	languages: []faults.LanguageTag{faults.English, faults.Bahasa},
+}
+
+// RegisterErrorKey menambahkan error milik aplikasi ke katalog dengan key,
+// supaya bisa diterjemahkan lewat RegisterErrorMessages dan LoadErrorCatalog.
+func RegisterErrorKey(key string, err faults.Error) {
+	errorCatalog.mu.Lock()
+	defer errorCatalog.mu.Unlock()
+
+	errorCatalog.errors[key] = err
+}
+
+// RegisterErrorMessages menambahkan terjemahan error untuk bahasa tag,
+// dengan key seperti "err_required". Pesan untuk bahasa yang ditulis dari
+// kanan ke kiri (misalnya "ar") dibungkus penanda isolasi RTL agar tampil
+// benar di UI berbahasa kiri-ke-kanan. Daftarkan saat startup, sebelum
+// request pertama; key yang tidak dikenal dilaporkan sebagai error.
+func RegisterErrorMessages(tag faults.LanguageTag, messages map[string]string) error {
+	errorCatalog.mu.Lock()
+	defer errorCatalog.mu.Unlock()
+
+	rtl := isRTL(tag)
+	var unknown []string
+	for key, msg := range messages {
+		err, ok := errorCatalog.errors[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		if rtl {
+			msg = "\u2067" + msg + "\u2069"
+		}
+		err.SetLocaleMessage(tag, msg)
+	}
+
+	registered := false
+	for _, l := range errorCatalog.languages {
+		registered = registered || l == tag
+	}
+	if !registered {
+		errorCatalog.languages = append(errorCatalog.languages, tag)
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("netpath: unknown error keys for %s: %s", tag, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// LoadErrorCatalog membaca file JSON berisi key error dan pesannya,
+// misalnya {"err_required": "必須項目です。"}.
+func LoadErrorCatalog(tag faults.LanguageTag, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var messages map[string]string
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return RegisterErrorMessages(tag, messages)
+}
+
+// Languages mengembalikan bahasa yang punya katalog error.
+func Languages() []faults.LanguageTag {
+	errorCatalog.mu.RLock()
+	defer errorCatalog.mu.RUnlock()
+
+	return append([]faults.LanguageTag(nil), errorCatalog.languages...)
+}
+
+// LanguageRegistered melaporkan apakah tag punya katalog error.
+func LanguageRegistered(tag faults.LanguageTag) bool {
+	for _, l := range Languages() {
+		if l == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func isRTL(tag faults.LanguageTag) bool {
+	t, err := language.Parse(string(tag))
+	if err != nil {
+		return false
+	}
+	script, _ := t.Script()
+	switch script.String() {
+	case "Arab", "Hebr", "Thaa", "Syrc", "Nkoo", "Adlm", "Rohg":
+		return true
+	}
+	return false
+}
+
+// localeChain adalah urutan bahasa untuk request ini: locale Context,
+// fallback per request dari UseLocale, lalu rantai global dari fallbackChain.
+func (c *Context) localeChain() []faults.LanguageTag {
+	locale := c.locale
+	if locale == "" {
+		locale = faults.DefaultLocale
+	}
+
+	catalogs.mu.RLock()
+	chain := fallbackChain(locale)
+	catalogs.mu.RUnlock()
+
+	if len(c.localeFallbacks) == 0 {
+		return chain
+	}
+	out := append([]faults.LanguageTag{chain[0]}, c.localeFallbacks...)
+	return append(out, chain[1:]...)
+}
+
+// localizeError memilih pesan dari bahasa pertama di localeChain yang
+// didukung err.
+func (c *Context) localizeError(err faults.Error) string {
+	supported := err.SupportedTags()
+	for _, tag := range c.localeChain() {
+		for _, s := range supported {
+			if s == tag {
+				return err.LocalizedError(tag)
+			}
+		}
+	}
+	return err.Error()
+}
+
+// localizeErrors setara faults.Errors.LocalizedError dengan rantai fallback.
+func (c *Context) localizeErrors(errs faults.Errors) map[string]any {
+	result := make(map[string]any, len(errs))
+	for key, err := range errs {
+		switch e := err.(type) {
+		case faults.Errors:
+			result[key] = c.localizeErrors(e)
+		case faults.Error:
+			result[key] = c.localizeError(e)
+		default:
+			result[key] = err.Error()
+		}
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/godev90/validator/faults"
+)
+
+type errorMapping struct {
+	target error
+	status int
+	err    faults.Error
+}
+
+var (
+	errorRegistry []errorMapping
+	codeRegistry  map[faults.ErrCode]int
+)
+
+// RegisterError memetakan error domain ke status HTTP dan pesan terlokalisasi.
+// Handler cukup mengembalikan error tersebut; App menulis envelope yang sesuai
+// jika handler belum menulis response.
+//
+//	var ErrOrderNotFound = errors.New("order not found")
+//
+//	netpath.RegisterError(ErrOrderNotFound, http.StatusNotFound,
+//		faults.LangPackage{Tag: faults.English, Message: "Order not found."},
+//		faults.LangPackage{Tag: faults.Bahasa, Message: "Pesanan tidak ditemukan."})
+func RegisterError(target error, status int, messages ...faults.LangPackage) {
+	if target == nil {
+		panic(faults.ErrCannotBeNull)
+	}
+
+	fe, ok := target.(faults.Error)
+	if !ok || len(messages) > 0 {
+		fe = faults.New(target, &faults.ErrAttr{Code: faults.ErrCode(status), Messages: messages})
+	}
+
+	errorRegistry = append(errorRegistry, errorMapping{target: target, status: status, err: fe})
+}
+
+// RegisterErrorCode memetakan kode faults.Error ke status HTTP.
+func RegisterErrorCode(code faults.ErrCode, status int) {
+	if codeRegistry == nil {
+		codeRegistry = make(map[faults.ErrCode]int)
+	}
+	codeRegistry[code] = status
+}
+
+// resolveError mencari status HTTP dan error yang akan dirender untuk err.
+func resolveError(err error) (int, error) {
+	for _, m := range errorRegistry {
+		if errors.Is(err, m.target) || faults.Is(err, m.target) {
+			return m.status, m.err
+		}
+	}
+
+	var fe faults.Error
+	if errors.As(err, &fe) {
+		if status, ok := codeRegistry[fe.Code()]; ok {
+			return status, fe
+		}
+		if status := statusFromCode(fe.Code()); status != 0 {
+			return status, fe
+		}
+	}
+
+	var fes faults.Errors
+	if errors.As(err, &fes) {
+		return http.StatusBadRequest, fes
+	}
+
+	// body melewati WithMaxBody atau batas dekompresi
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, faults.ErrPayloadTooLarge
+	}
+
+	return http.StatusInternalServerError, err
+}
+
+// ErrorStatus mengembalikan status HTTP yang akan dipakai App untuk err,
+// misalnya untuk middleware yang mencatat status setelah handler mengembalikan
+// error yang belum dirender.
+func ErrorStatus(err error) int {
+	status, _ := resolveError(err)
+	return status
+}
+
+// statusFromCode menurunkan status dari kode faults: kode HTTP apa adanya,
+// atau kode builtin validator seperti 4404/5503.
+func statusFromCode(code faults.ErrCode) int {
+	c := int(code)
+	if c >= 100 && c < 600 {
+		return c
+	}
+	if c >= 1000 && c < 6000 && c%1000 >= 100 && c%1000 < 600 {
+		return c % 1000
+	}
+	return 0
+}
+
+// Error menulis envelope error dengan status yang ditentukan oleh registry.
+func (c *Context) Error(err error) error {
+	status, rendered := resolveError(err)
+	if status == http.StatusInternalServerError {
+		c.ServerError(rendered)
+		return err
+	}
+
+	c.respondError(status, rendered)
+	return err
+}
+
+func (c *Context) respondError(status int, err error) {
+	c.httpStatus = status
+	if ers, ok := err.(faults.Errors); ok {
+		c.JSON(status, map[string]any{
+			"code": status,
+			"data": c.localizeErrors(ers),
+		})
+	} else if er, ok := err.(faults.Error); ok {
+		c.JSON(status, map[string]any{
+			"code": er.Code(),
+			"data": map[string]any{
+				"description": c.localizeError(er),
+			},
+		})
+	} else {
+		c.JSON(status, map[string]any{
+			"code": status,
+			"data": map[string]any{
+				"description": err.Error(),
+			},
+		})
+	}
+}
+
+var errNoHandlerVersion = errors.New("no handler version available")
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+type Event struct {
+	Topic   string
+	Payload any
+	Time    time.Time
+}
+
+type Handler func(Event)
+
+type eventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+var (
+	bus  *eventBus
+	once sync.Once
+)
+
+// Bus mengembalikan event bus in-process milik aplikasi.
+func Bus() *eventBus {
+	once.Do(func() {
+		bus = &eventBus{
+			handlers: make(map[string][]Handler),
+		}
+	})
+
+	return bus
+}
+
+// Subscribe mendaftarkan handler untuk topic. Topic "*" menerima semua event.
+func (b *eventBus) Subscribe(topic string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[topic] = append(b.handlers[topic], h)
+}
+
+// Publish mengirim event ke semua subscriber secara asynchronous sehingga
+// tidak menahan request yang sedang berjalan.
+func (b *eventBus) Publish(topic string, payload any) {
+	b.mu.RLock()
+	handlers := append(append([]Handler{}, b.handlers[topic]...), b.handlers["*"]...)
+	b.mu.RUnlock()
+
+	if len(handlers) == 0 {
+		return
+	}
+
+	ev := Event{Topic: topic, Payload: payload, Time: time.Now()}
+	for _, h := range handlers {
+		go func(h Handler) {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("events: subscriber for %q panicked: %v", topic, r)
+				}
+			}()
+			h(ev)
+		}(h)
+	}
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"hash/fnv"
+	"net/http"
+	"time"
+
+	"github.com/godev90/netpath/events"
+)
+
+const TopicExperimentAssigned = "experiment.assigned"
+
+type VariantWeight struct {
+	Name   string
+	Weight int
+}
+
+type ExperimentConfig struct {
+	Name     string
+	Variants []VariantWeight
+	// Cookie menyimpan ID pengunjung anonim jika request tidak membawa session.
+	// Default "np_uid".
+	Cookie string
+}
+
+type ExperimentAssignment struct {
+	Experiment string
+	Variant    string
+	Subject    string
+	Route      string
+}
+
+// Experiment menetapkan variant secara deterministik dengan hashing Identifier
+// session (atau cookie pengunjung), sehingga subjek yang sama selalu mendapat
+// variant yang sama. Hasilnya tersedia lewat ctx.Variant(name) dan dikirim
+// sebagai event TopicExperimentAssigned ke events.Bus().
+func Experiment(cfg ExperimentConfig) MiddlewareFunc {
+	if cfg.Cookie == "" {
+		cfg.Cookie = "np_uid"
+	}
+	total := 0
+	for _, v := range cfg.Variants {
+		total += v.Weight
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if total == 0 {
+				return next(ctx)
+			}
+
+			subject := experimentSubject(ctx, cfg.Cookie)
+			h := fnv.New32a()
+			h.Write([]byte(cfg.Name))
+			h.Write([]byte{0})
+			h.Write([]byte(subject))
+			n := int(h.Sum32() % uint32(total))
+
+			var variant string
+			for _, v := range cfg.Variants {
+				if n < v.Weight {
+					variant = v.Name
+					break
+				}
+				n -= v.Weight
+			}
+
+			if ctx.variants == nil {
+				ctx.variants = make(map[string]string)
+			}
+			ctx.variants[cfg.Name] = variant
+
+			events.Bus().Publish(TopicExperimentAssigned, ExperimentAssignment{
+				Experiment: cfg.Name,
+				Variant:    variant,
+				Subject:    subject,
+				Route:      ctx.route,
+			})
+
+			return next(ctx)
+		}
+	}
+}
+
+// Variant mengembalikan variant yang ditetapkan untuk eksperimen, atau "".
+func (c *Context) Variant(experiment string) string {
+	return c.variants[experiment]
+}
+
+func experimentSubject(ctx *Context, cookie string) string {
+	if sess := ctx.Session(); sess != nil {
+		return sess.Identifier()
+	}
+	if ck, err := ctx.request.Cookie(cookie); err == nil && ck.Value != "" {
+		return ck.Value
+	}
+
+	b := make([]byte, 16)
+	rand.Read(b)
+	id := hex.EncodeToString(b)
+
+	// simpan juga di request supaya eksperimen lain di chain yang sama memakai ID ini
+	ctx.request.AddCookie(&http.Cookie{Name: cookie, Value: id})
+	ctx.SetCookie(&http.Cookie{
+		Name:     cookie,
+		Value:    id,
+		Path:     "/",
+		Expires:  time.Now().AddDate(1, 0, 0),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return id
+}
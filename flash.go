@@ -0,0 +1,146 @@
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type FlashConfig struct {
+	CookieName string // default "np_flash"
+	// Secret menandatangani cookie dengan HMAC-SHA256 sehingga pesan tidak
+	// bisa dipalsukan client. Kosong berarti tanpa tanda tangan.
+	Secret []byte
+	// MaxAge membatasi umur pesan yang belum dibaca, default 5 menit.
+	MaxAge time.Duration
+}
+
+type flashState struct {
+	cfg  FlashConfig
+	in   map[string][]string
+	out  map[string][]string
+	read bool
+}
+
+// WithFlash mengatur cookie flash message. Tanpa middleware ini Flash dan
+// Flashes tetap bekerja dengan konfigurasi bawaan.
+func WithFlash(cfg FlashConfig) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			ctx.flash = newFlashState(ctx, cfg)
+			return next(ctx)
+		}
+	}
+}
+
+// Flash menyimpan pesan untuk request berikutnya, biasanya halaman tujuan
+// redirect setelah form berhasil diproses:
+//
+//	ctx.Flash("success", "Order saved")
+//	return ctx.Redirect(http.StatusSeeOther, "/orders")
+//
+// Pesan disimpan di cookie, jadi jaga agar tetap pendek (batas cookie 4 KB).
+func (c *Context) Flash(key, message string) {
+	f := c.flashes()
+	if f.out == nil {
+		f.out = map[string][]string{}
+	}
+	f.out[key] = append(f.out[key], message)
+}
+
+// Flashes mengembalikan pesan dari request sebelumnya, dikelompokkan per key,
+// lalu menghapusnya dari cookie. Pesan yang belum dibaca bertahan sampai
+// request berikutnya, misalnya melewati beberapa redirect.
+func (c *Context) Flashes() map[string][]string {
+	f := c.flashes()
+	f.read = true
+	return f.in
+}
+
+func (c *Context) flashes() *flashState {
+	if c.flash == nil {
+		c.flash = newFlashState(c, FlashConfig{})
+	}
+	return c.flash
+}
+
+func newFlashState(ctx *Context, cfg FlashConfig) *flashState {
+	if cfg.CookieName == "" {
+		cfg.CookieName = "np_flash"
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = 5 * time.Minute
+	}
+	f := &flashState{cfg: cfg}
+	if ck, err := ctx.request.Cookie(cfg.CookieName); err == nil {
+		f.in = decodeFlash(ck.Value, cfg.Secret)
+	}
+	ctx.BeforeWrite(func(status int, header http.Header) {
+		f.write(ctx)
+	})
+	return f
+}
+
+// write menulis cookie sebelum header dikirim: pesan baru beserta pesan
+// lama yang belum dibaca, atau menghapus cookie jika semuanya sudah dibaca.
+// Tanpa pesan baru dan tanpa Flashes, cookie dibiarkan.
+func (f *flashState) write(ctx *Context) {
+	ck := &http.Cookie{Name: f.cfg.CookieName, Path: "/", HttpOnly: true}
+	if len(f.out) == 0 {
+		if !f.read || len(f.in) == 0 {
+			return
+		}
+		ck.MaxAge = -1
+		ctx.SetCookie(ck)
+		return
+	}
+
+	pending := f.out
+	if !f.read {
+		for k, v := range f.in {
+			pending[k] = append(append([]string(nil), v...), pending[k]...)
+		}
+	}
+	ck.Value = encodeFlash(pending, f.cfg.Secret)
+	ck.MaxAge = int(f.cfg.MaxAge / time.Second)
+	ctx.SetCookie(ck)
+}
+
+// format cookie: base64url(json)[.base64url(hmac)]
+func encodeFlash(messages map[string][]string, secret []byte) string {
+	raw, _ := json.Marshal(messages)
+	value := base64.RawURLEncoding.EncodeToString(raw)
+	if len(secret) > 0 {
+		value += "." + base64.RawURLEncoding.EncodeToString(flashMAC(value, secret))
+	}
+	return value
+}
+
+func decodeFlash(value string, secret []byte) map[string][]string {
+	payload, sig, signed := strings.Cut(value, ".")
+	if len(secret) > 0 {
+		mac, err := base64.RawURLEncoding.DecodeString(sig)
+		if !signed || err != nil || !hmac.Equal(mac, flashMAC(payload, secret)) {
+			return nil
+		}
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil
+	}
+	var messages map[string][]string
+	if json.Unmarshal(raw, &messages) != nil {
+		return nil
+	}
+	return messages
+}
+
+func flashMAC(payload string, secret []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
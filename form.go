@@ -0,0 +1,91 @@
+package app
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/godev90/validator/faults"
+)
+
+// Key data template yang diisi RenderFormErrors.
+const (
+	// FormValuesKey berisi url.Values yang dikirim user: {{.Form.Get "email"}}.
+	FormValuesKey = "Form"
+	// FormErrorsKey berisi map[string]string pesan per field yang sudah
+	// diterjemahkan: {{with .Errors.email}}<p class="error">{{.}}</p>{{end}}.
+	FormErrorsKey = "Errors"
+	// FormErrorKey berisi pesan yang tidak terkait satu field.
+	FormErrorKey = "FormError"
+	// CSRFTokenKey berisi ctx.CSRFToken() untuk hidden field form.
+	CSRFTokenKey = "CSRFToken"
+)
+
+// RenderFormErrors merender ulang template form name dengan status 422
+// setelah BindForm gagal, berisi nilai yang tadi dikirim dan pesan error
+// dalam bahasa request:
+//
+//	var in SignupForm
+//	if err := ctx.BindForm(&in); err != nil {
+//		return ctx.RenderFormErrors("signup.html", err, nil)
+//	}
+//
+// data boleh berisi key lain untuk template; key di atas ditimpa. Field yang
+// namanya mengandung "password" tidak diisi ulang. Error selain
+// faults.Errors dan faults.Error diteruskan ke ctx.Error.
+func (c *Context) RenderFormErrors(name string, err error, data map[string]any) error {
+	fields := map[string]string{}
+	formError := ""
+	switch e := err.(type) {
+	case faults.Errors:
+		c.flattenFormErrors("", e, fields)
+	case faults.Error:
+		formError = c.localizeError(e)
+	default:
+		return c.Error(err)
+	}
+
+	merged := make(map[string]any, len(data)+4)
+	for k, v := range data {
+		merged[k] = v
+	}
+	merged[FormValuesKey] = c.submittedValues()
+	merged[FormErrorsKey] = fields
+	merged[FormErrorKey] = formError
+	merged[CSRFTokenKey] = c.csrfToken
+
+	if rerr := c.Render(http.StatusUnprocessableEntity, name, merged); rerr != nil {
+		return rerr
+	}
+	return err
+}
+
+// flattenFormErrors meratakan faults.Errors bersarang menjadi path seperti
+// "address.city".
+func (c *Context) flattenFormErrors(prefix string, errs faults.Errors, out map[string]string) {
+	for key, err := range errs {
+		path := joinPath(prefix, key)
+		switch e := err.(type) {
+		case faults.Errors:
+			c.flattenFormErrors(path, e, out)
+		case faults.Error:
+			out[path] = c.localizeError(e)
+		default:
+			out[path] = err.Error()
+		}
+	}
+}
+
+func (c *Context) submittedValues() url.Values {
+	if c.request.Form == nil {
+		c.request.ParseForm()
+	}
+	values := url.Values{}
+	for key, v := range c.request.Form {
+		if strings.Contains(strings.ToLower(key), "password") {
+			continue
+		}
+		values[key] = v
+	}
+	return values
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/godev90/validator/faults"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// DateStyle mengikuti gaya tanggal CLDR.
+type DateStyle int
+
+const (
+	DateShort  DateStyle = iota // 1/2/06, 02/01/06
+	DateMedium                  // Jan 2, 2006, 2 Jan 2006
+	DateLong                    // January 2, 2006, 2 Januari 2006
+	DateFull                    // Monday, January 2, 2006, Senin, 02 Januari 2006
+)
+
+type dateNames struct {
+	months      [12]string
+	shortMonths [12]string
+	days        [7]string
+	format      func(t time.Time, style DateStyle, n *dateNames) string
+}
+
+var dateLocales = map[string]*dateNames{
+	"en": {
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		format: func(t time.Time, style DateStyle, n *dateNames) string {
+			switch style {
+			case DateShort:
+				return fmt.Sprintf("%d/%d/%02d", t.Month(), t.Day(), t.Year()%100)
+			case DateMedium:
+				return fmt.Sprintf("%s %d, %d", n.shortMonths[t.Month()-1], t.Day(), t.Year())
+			case DateLong:
+				return fmt.Sprintf("%s %d, %d", n.months[t.Month()-1], t.Day(), t.Year())
+			}
+			return fmt.Sprintf("%s, %s %d, %d", n.days[t.Weekday()], n.months[t.Month()-1], t.Day(), t.Year())
+		},
+	},
+	"id": {
+		months:      [12]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"},
+		days:        [7]string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"},
+		format: func(t time.Time, style DateStyle, n *dateNames) string {
+			switch style {
+			case DateShort:
+				return fmt.Sprintf("%02d/%02d/%02d", t.Day(), t.Month(), t.Year()%100)
+			case DateMedium:
+				return fmt.Sprintf("%d %s %d", t.Day(), n.shortMonths[t.Month()-1], t.Year())
+			case DateLong:
+				return fmt.Sprintf("%d %s %d", t.Day(), n.months[t.Month()-1], t.Year())
+			}
+			return fmt.Sprintf("%s, %02d %s %d", n.days[t.Weekday()], t.Day(), n.months[t.Month()-1], t.Year())
+		},
+	},
+}
+
+func (c *Context) languageTag() language.Tag {
+	locale := c.locale
+	if locale == "" {
+		locale = faults.DefaultLocale
+	}
+	tag, err := language.Parse(string(locale))
+	if err != nil {
+		return language.English
+	}
+	return tag
+}
+
+// FormatDate memformat tanggal sesuai locale Context. Locale tanpa data
+// tanggal memakai format bahasa Inggris.
+func (c *Context) FormatDate(t time.Time, style DateStyle) string {
+	base, _ := c.languageTag().Base()
+	names, ok := dateLocales[strings.ToLower(base.String())]
+	if !ok {
+		names = dateLocales["en"]
+	}
+	return names.format(t, style, names)
+}
+
+// FormatNumber memformat angka dengan pemisah ribuan dan desimal sesuai locale.
+// decimals < 0 berarti jumlah digit pecahan mengikuti nilainya.
+func (c *Context) FormatNumber(v any, decimals int) string {
+	p := message.NewPrinter(c.languageTag())
+	if decimals < 0 {
+		return p.Sprint(number.Decimal(v))
+	}
+	return p.Sprint(number.Decimal(v, number.Scale(decimals)))
+}
+
+// FormatCurrency memformat nominal dengan simbol mata uang (kode ISO 4217,
+// misalnya "IDR" atau "USD") sesuai locale Context.
+func (c *Context) FormatCurrency(amount any, code string) string {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return fmt.Sprintf("%s %v", code, amount)
+	}
+	p := message.NewPrinter(c.languageTag())
+	return p.Sprint(currency.Symbol(unit.Amount(amount)))
+}
@@ -0,0 +1,247 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithTrustedProxies menentukan alamat proxy (IP atau CIDR) yang header
+// Forwarded / X-Forwarded-Proto / X-Forwarded-Host / X-Forwarded-For-nya
+// dipercaya oleh ctx.Scheme, ctx.Host, ctx.BaseURL, dan ctx.ClientIP. Tanpa
+// option ini header tersebut diabaikan.
+func WithTrustedProxies(proxies ...string) Option {
+	nets := make([]*net.IPNet, 0, len(proxies))
+	for _, p := range proxies {
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			panic(fmt.Sprintf("netpath: invalid trusted proxy %q", p))
+		}
+		nets = append(nets, n)
+	}
+
+	return func(app *App) {
+		app.trustedProxies = nets
+	}
+}
+
+func (c *Context) fromTrustedProxy() bool {
+	host, _, err := net.SplitHostPort(c.request.RemoteAddr)
+	if err != nil {
+		host = c.request.RemoteAddr
+	}
+	return c.trustedProxy(net.ParseIP(host))
+}
+
+func (c *Context) trustedProxy(ip net.IP) bool {
+	if c.app == nil || len(c.app.trustedProxies) == 0 || ip == nil {
+		return false
+	}
+	for _, n := range c.app.trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP mengembalikan IP client. Jika request datang dari proxy
+// tepercaya, alamat diambil dari Forwarded (for=) atau X-Forwarded-For,
+// dibaca dari kanan dan melewati hop yang juga proxy tepercaya, sehingga
+// alamat palsu yang dikirim client di awal header tidak dipakai.
+func (c *Context) ClientIP() string {
+	ip := clientKey(c.request)
+	if !c.fromTrustedProxy() {
+		return ip
+	}
+	hops := c.forwardedFor()
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := parseHop(hops[i])
+		if hop == nil {
+			break
+		}
+		ip = hop.String()
+		if !c.trustedProxy(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+// forwardedFor mengembalikan semua nilai for= dari header Forwarded, atau
+// X-Forwarded-For sebagai cadangan, urut dari client ke proxy terakhir.
+func (c *Context) forwardedFor() []string {
+	var hops []string
+	for _, elem := range forwardedElems(c.request.Header) {
+		if hop, ok := elem["for"]; ok {
+			hops = append(hops, hop)
+		}
+	}
+	if len(hops) > 0 {
+		return hops
+	}
+	for _, v := range c.request.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// parseHop mengurai "192.0.2.1", "192.0.2.1:4711", atau "[2001:db8::1]:4711".
+// Nilai obfuscated seperti "unknown" atau "_hidden" menghasilkan nil.
+func parseHop(hop string) net.IP {
+	if ip := net.ParseIP(hop); ip != nil {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		return net.ParseIP(host)
+	}
+	return net.ParseIP(strings.Trim(hop, "[]"))
+}
+
+// forwarded mengembalikan param (proto atau host) dari header Forwarded
+// (RFC 7239), atau dari header X-Forwarded-* sebagai cadangan. Nilai diambil
+// dari elemen yang ditambahkan proxy tepercaya terluar, dibaca dari kanan
+// seperti ClientIP, sehingga elemen yang dikirim client sendiri diabaikan.
+func (c *Context) forwarded(param, legacy string) string {
+	if elems := forwardedElems(c.request.Header); len(elems) > 0 {
+		fors := make([]string, len(elems))
+		for i, elem := range elems {
+			fors[i] = elem["for"]
+		}
+		return elems[len(elems)-1-c.trustedHops(fors)][param]
+	}
+
+	var values []string
+	for _, v := range c.request.Header.Values(legacy) {
+		for _, val := range strings.Split(v, ",") {
+			values = append(values, strings.TrimSpace(val))
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	// proxy yang menimpa header (bukan menambah) meninggalkan satu nilai
+	i := len(values) - 1 - c.trustedHops(c.forwardedFor())
+	return values[max(i, 0)]
+}
+
+// trustedHops menghitung dari kanan berapa hop yang dilewati sampai hop yang
+// bukan proxy tepercaya: indeks (dari kanan) elemen yang ditambahkan proxy
+// tepercaya terluar.
+func (c *Context) trustedHops(hops []string) int {
+	for k := 0; k < len(hops); k++ {
+		hop := parseHop(hops[len(hops)-1-k])
+		if hop == nil || !c.trustedProxy(hop) {
+			return k
+		}
+	}
+	return max(len(hops)-1, 0)
+}
+
+// forwardedElems mengurai semua elemen header Forwarded menjadi pasangan
+// param (huruf kecil) dan nilai, urut dari client ke proxy terakhir.
+func forwardedElems(h http.Header) []map[string]string {
+	var elems []map[string]string
+	for _, v := range h.Values("Forwarded") {
+		for _, elem := range strings.Split(v, ",") {
+			params := map[string]string{}
+			for _, pair := range strings.Split(elem, ";") {
+				k, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok {
+					params[strings.ToLower(k)] = strings.Trim(val, `"`)
+				}
+			}
+			elems = append(elems, params)
+		}
+	}
+	return elems
+}
+
+// Scheme mengembalikan "https" atau "http" sesuai yang dilihat client.
+func (c *Context) Scheme() string {
+	if c.fromTrustedProxy() {
+		if proto := strings.ToLower(c.forwarded("proto", "X-Forwarded-Proto")); proto == "https" || proto == "http" {
+			return proto
+		}
+	}
+	if c.request.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// Host mengembalikan host yang diminta client, termasuk port jika ada.
+func (c *Context) Host() string {
+	if c.fromTrustedProxy() {
+		if host := c.forwarded("host", "X-Forwarded-Host"); host != "" {
+			return host
+		}
+	}
+	return c.request.Host
+}
+
+// BaseURL mengembalikan scheme://host, misalnya untuk link absolut di email
+// atau header Location.
+func (c *Context) BaseURL() string {
+	return c.Scheme() + "://" + c.Host()
+}
+
+// HTTPSConfig mengatur WithHTTPSRedirect.
+type HTTPSConfig struct {
+	// HSTSMaxAge default satu tahun; nilai negatif mematikan header HSTS.
+	HSTSMaxAge        time.Duration
+	IncludeSubdomains bool
+	Preload           bool
+}
+
+// WithHTTPSRedirect mengarahkan request HTTP ke HTTPS (301 untuk GET/HEAD,
+// 308 untuk method lain) dan menambahkan Strict-Transport-Security pada
+// response HTTPS. Di belakang proxy, pasang WithTrustedProxies supaya
+// X-Forwarded-Proto dihormati.
+func WithHTTPSRedirect(cfg HTTPSConfig) MiddlewareFunc {
+	if cfg.HSTSMaxAge == 0 {
+		cfg.HSTSMaxAge = 365 * 24 * time.Hour
+	}
+	var hsts string
+	if cfg.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(cfg.HSTSMaxAge.Seconds()))
+		if cfg.IncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+		if cfg.Preload {
+			hsts += "; preload"
+		}
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if ctx.Scheme() == "https" {
+				if hsts != "" {
+					ctx.writer.Header().Set("Strict-Transport-Security", hsts)
+				}
+				return next(ctx)
+			}
+
+			code := http.StatusMovedPermanently
+			if ctx.request.Method != http.MethodGet && ctx.request.Method != http.MethodHead {
+				code = http.StatusPermanentRedirect
+			}
+			target := "https://" + ctx.Host() + ctx.request.URL.RequestURI()
+			ctx.httpStatus = code
+			http.Redirect(ctx.writer, ctx.request, target, code)
+			return nil
+		}
+	}
+}
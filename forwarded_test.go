@@ -0,0 +1,118 @@
+package app
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestForwardedHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   string
+		headers  map[string]string
+		baseURL  string
+		clientIP string
+	}{
+		{
+			name:     "untrusted peer ignores headers",
+			remote:   "203.0.113.9:5000",
+			headers:  map[string]string{"Forwarded": "for=198.51.100.1;host=evil.example;proto=https"},
+			baseURL:  "http://app.example",
+			clientIP: "203.0.113.9",
+		},
+		{
+			name:     "single proxy",
+			remote:   "10.0.0.1:5000",
+			headers:  map[string]string{"Forwarded": "for=203.0.113.5;host=api.example;proto=https"},
+			baseURL:  "https://api.example",
+			clientIP: "203.0.113.5",
+		},
+		{
+			name:   "client-sent element is skipped",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"Forwarded": "for=198.51.100.1;host=evil.example;proto=http, for=203.0.113.5;host=api.example;proto=https",
+			},
+			baseURL:  "https://api.example",
+			clientIP: "203.0.113.5",
+		},
+		{
+			name:   "client host without for is skipped",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"Forwarded": "host=evil.example, for=203.0.113.5;host=api.example;proto=https",
+			},
+			baseURL:  "https://api.example",
+			clientIP: "203.0.113.5",
+		},
+		{
+			name:   "chain of trusted proxies uses the outermost",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"Forwarded": `for=203.0.113.5;host=api.example;proto=https, for="10.0.0.2:4711";host=internal;proto=http`,
+			},
+			baseURL:  "https://api.example",
+			clientIP: "203.0.113.5",
+		},
+		{
+			name:   "ipv6 client",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"Forwarded": `for="[2001:db8::1]:4711";host=api.example;proto=https`,
+			},
+			baseURL:  "https://api.example",
+			clientIP: "2001:db8::1",
+		},
+		{
+			name:   "obfuscated client keeps the proxy element",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"Forwarded": "for=_hidden;host=api.example;proto=https",
+			},
+			baseURL:  "https://api.example",
+			clientIP: "10.0.0.1",
+		},
+		{
+			name:   "appended legacy headers",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"X-Forwarded-For":   "198.51.100.1, 203.0.113.5",
+				"X-Forwarded-Host":  "evil.example, api.example",
+				"X-Forwarded-Proto": "http, https",
+			},
+			baseURL:  "https://api.example",
+			clientIP: "203.0.113.5",
+		},
+		{
+			name:   "overwritten legacy headers",
+			remote: "10.0.0.1:5000",
+			headers: map[string]string{
+				"X-Forwarded-For":   "203.0.113.5, 10.0.0.2",
+				"X-Forwarded-Host":  "api.example",
+				"X-Forwarded-Proto": "https",
+			},
+			baseURL:  "https://api.example",
+			clientIP: "203.0.113.5",
+		},
+	}
+
+	app := New(WithTrustedProxies("10.0.0.0/8"))
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Host = "app.example"
+			r.RemoteAddr = tc.remote
+			for k, v := range tc.headers {
+				r.Header.Set(k, v)
+			}
+			ctx := &Context{app: app, request: r}
+
+			if got := ctx.BaseURL(); got != tc.baseURL {
+				t.Errorf("BaseURL() = %q, want %q", got, tc.baseURL)
+			}
+			if got := ctx.ClientIP(); got != tc.clientIP {
+				t.Errorf("ClientIP() = %q, want %q", got, tc.clientIP)
+			}
+		})
+	}
+}
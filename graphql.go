@@ -0,0 +1,70 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type GraphQLConfig struct {
+	// GraphiQL memasang UI GraphiQL pada GraphiQLPath (default "<path>/graphiql").
+	// Sebaiknya hanya diaktifkan di lingkungan non-production.
+	GraphiQL     bool
+	GraphiQLPath string
+}
+
+// GraphQL memasang handler GraphQL (gqlgen, graphql-go, dsb) untuk GET dan POST.
+// Resolver dapat mengambil Context netpath (session, locale, request ID)
+// dari context.Context lewat FromContext.
+func (r *Router) GraphQL(path string, h http.Handler, cfg GraphQLConfig, mws ...MiddlewareFunc) {
+	handler := func(ctx *Context) error {
+		h.ServeHTTP(ctx.Writer(), ctx.Request())
+		return nil
+	}
+
+	r.GET(path, handler, mws...)
+	r.POST(path, handler, mws...)
+
+	if cfg.GraphiQL {
+		uiPath := cfg.GraphiQLPath
+		if uiPath == "" {
+			uiPath = strings.TrimSuffix(path, "/") + "/graphiql"
+		}
+		endpoint := r.prefix + path
+		r.GET(uiPath, func(ctx *Context) error {
+			ctx.Writer().Header().Set("Content-Type", "text/html; charset=utf-8")
+			ctx.httpStatus = http.StatusOK
+			ctx.Writer().WriteHeader(http.StatusOK)
+			_, err := ctx.Writer().Write([]byte(strings.ReplaceAll(graphiqlPage, "{{endpoint}}", endpoint)))
+			return err
+		}, mws...)
+	}
+}
+
+// FromContext mengambil Context netpath dari context.Context milik request,
+// misalnya di dalam resolver GraphQL atau handler gRPC gateway.
+func FromContext(ctx context.Context) (*Context, bool) {
+	c, ok := ctx.Value(contextKey{}).(*Context)
+	return c, ok
+}
+
+const graphiqlPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>GraphiQL</title>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+</head>
+<body style="margin:0">
+  <div id="graphiql" style="height:100vh"></div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: "{{endpoint}}" });
+    ReactDOM.createRoot(document.getElementById("graphiql"))
+      .render(React.createElement(GraphiQL, { fetcher: fetcher }));
+  </script>
+</body>
+</html>
+`
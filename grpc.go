@@ -0,0 +1,89 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ServeGRPC membuat App meneruskan request gRPC (HTTP/2 dengan content-type
+// application/grpc) ke h, biasanya *grpc.Server, sehingga REST dan gRPC berbagi
+// listener yang sama. Untuk TLS, ALPN "h2" dinegosiasikan otomatis oleh net/http;
+// untuk plaintext, bungkus App dengan handler h2c sebelum diberikan ke server.
+func (app *App) ServeGRPC(h http.Handler) {
+	app.grpc = h
+}
+
+func isGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+// MountGateway memasang handler hasil generate grpc-gateway di bawah prefix.
+// Response error dari gateway diterjemahkan ke envelope standar netpath.
+func (r *Router) MountGateway(prefix string, h http.Handler, mws ...MiddlewareFunc) {
+	r.Mount(prefix, gatewayErrors(h), mws...)
+}
+
+func gatewayErrors(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gw := &gatewayWriter{ResponseWriter: w}
+		h.ServeHTTP(gw, r)
+		if !gw.failed {
+			return
+		}
+
+		var body struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(gw.buf.Bytes(), &body)
+		if body.Message == "" {
+			body.Message = http.StatusText(gw.status)
+		}
+
+		if ctx, ok := ContextFromRequest(r); ok {
+			ctx.writer = w
+			ctx.respondError(gw.status, errors.New(body.Message))
+			return
+		}
+		w.WriteHeader(gw.status)
+		w.Write(gw.buf.Bytes())
+	})
+}
+
+// gatewayWriter meneruskan response sukses apa adanya dan menahan body
+// response error supaya bisa diterjemahkan.
+type gatewayWriter struct {
+	http.ResponseWriter
+	status int
+	failed bool
+	buf    bytes.Buffer
+}
+
+func (w *gatewayWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	if code >= 400 {
+		w.failed = true
+		return
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *gatewayWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.failed {
+		return w.buf.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *gatewayWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// headWriter membuang body response tetapi tetap menghitung panjangnya,
+// sehingga HEAD yang dilayani oleh route GET mengirim Content-Length yang sama.
+// Header baru dikirim saat finish, atau lebih awal jika handler melakukan Flush.
+type headWriter struct {
+	http.ResponseWriter
+	status  int
+	written int64
+	wrote   bool
+	sent    bool
+}
+
+func (w *headWriter) WriteHeader(code int) {
+	if !w.wrote {
+		w.status = code
+		w.wrote = true
+	}
+}
+
+func (w *headWriter) Write(p []byte) (int, error) {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.written += int64(len(p))
+	return len(p), nil
+}
+
+// Flush mengirim header yang tertunda sebelum flush ke writer asli, sehingga
+// http.ResponseController tidak melewati headWriter lewat Unwrap dan finish
+// tidak menulis header kedua kali. Content-Length tidak dikirim karena
+// panjang body belum diketahui.
+func (w *headWriter) Flush() {
+	w.send()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *headWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *headWriter) send() {
+	if w.sent {
+		return
+	}
+	w.sent = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+func (w *headWriter) finish() {
+	if w.sent {
+		return
+	}
+	h := w.ResponseWriter.Header()
+	if h.Get("Content-Length") == "" && w.written > 0 {
+		h.Set("Content-Length", strconv.FormatInt(w.written, 10))
+	}
+	w.send()
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// headerCounter mencatat berapa kali WriteHeader sampai ke writer asli.
+type headerCounter struct {
+	*httptest.ResponseRecorder
+	headers int
+}
+
+func (w *headerCounter) WriteHeader(code int) {
+	w.headers++
+	w.ResponseRecorder.WriteHeader(code)
+}
+
+func TestHeadServedByGet(t *testing.T) {
+	app := New()
+	app.Route().GET("/items", func(ctx *Context) error {
+		return ctx.Success([]string{"a", "b"})
+	})
+
+	get := httptest.NewRecorder()
+	app.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+	head := &headerCounter{ResponseRecorder: httptest.NewRecorder()}
+	app.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/items", nil))
+
+	if head.Code != http.StatusOK || head.headers != 1 {
+		t.Fatalf("HEAD status %d with %d WriteHeader calls", head.Code, head.headers)
+	}
+	if head.Body.Len() != 0 {
+		t.Fatalf("HEAD wrote body %q", head.Body)
+	}
+	if got, want := head.Header().Get("Content-Length"), get.Header().Get("Content-Length"); want != "" && got != want {
+		t.Fatalf("Content-Length = %q, want %q", got, want)
+	}
+	if got := head.Header().Get("Content-Length"); got == "" || got == "0" {
+		t.Fatalf("Content-Length = %q, want body length", got)
+	}
+}
+
+func TestHeadFlushSendsHeaderOnce(t *testing.T) {
+	app := New()
+	app.Route().GET("/stream", func(ctx *Context) error {
+		ctx.Writer().WriteHeader(http.StatusAccepted)
+		if err := http.NewResponseController(ctx.Writer()).Flush(); err != nil {
+			return err
+		}
+		ctx.Writer().Write([]byte("chunk"))
+		return nil
+	})
+
+	head := &headerCounter{ResponseRecorder: httptest.NewRecorder()}
+	app.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/stream", nil))
+
+	if head.headers != 1 {
+		t.Fatalf("WriteHeader reached the connection %d times, want 1", head.headers)
+	}
+	if head.Code != http.StatusAccepted || !head.Flushed {
+		t.Fatalf("status %d flushed %v, want 202 flushed", head.Code, head.Flushed)
+	}
+	if head.Body.Len() != 0 {
+		t.Fatalf("HEAD wrote body %q", head.Body)
+	}
+}
@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+	"syscall"
+)
+
+type Level int32
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < LevelDebug || l > LevelError {
+		return fmt.Sprintf("level(%d)", l)
+	}
+	return levelNames[l]
+}
+
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", s)
+}
+
+var level atomic.Int32
+
+func init() {
+	level.Store(int32(LevelInfo))
+	if env := os.Getenv("LOG_LEVEL"); env != "" {
+		if l, err := ParseLevel(env); err == nil {
+			level.Store(int32(l))
+		}
+	}
+}
+
+// SetLevel mengganti level log secara atomik; aman dipanggil saat aplikasi berjalan.
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+func GetLevel() Level {
+	return Level(level.Load())
+}
+
+func Enabled(l Level) bool {
+	return l >= GetLevel()
+}
+
+func logf(l Level, format string, args ...any) {
+	if Enabled(l) {
+		log.Printf("["+strings.ToUpper(l.String())+"] "+format, args...)
+	}
+}
+
+func Debugf(format string, args ...any) { logf(LevelDebug, format, args...) }
+func Infof(format string, args ...any)  { logf(LevelInfo, format, args...) }
+func Warnf(format string, args ...any)  { logf(LevelWarn, format, args...) }
+func Errorf(format string, args ...any) { logf(LevelError, format, args...) }
+
+// ToggleDebugOnSIGHUP membuat SIGHUP berpindah antara level debug dan level
+// sebelumnya, supaya debug log bisa dinyalakan sementara tanpa restart.
+func ToggleDebugOnSIGHUP() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+
+	go func() {
+		previous := GetLevel()
+		for range ch {
+			if GetLevel() == LevelDebug {
+				SetLevel(previous)
+			} else {
+				previous = GetLevel()
+				SetLevel(LevelDebug)
+			}
+			log.Printf("log level set to %s", GetLevel())
+		}
+	}()
+}
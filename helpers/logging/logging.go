@@ -6,5 +6,8 @@ import (
 )
 
 func SimpleEventIO(event string, in, out any, startedAt time.Time) {
+	if !Enabled(LevelInfo) {
+		return
+	}
 	log.Printf("[%s] %s: \n\tin:%+v \n\tout:%+v\n", event, time.Since(startedAt), in, out)
 }
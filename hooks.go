@@ -0,0 +1,38 @@
+package app
+
+import "net/http"
+
+// AfterResponseHook dipanggil setelah handler selesai dengan status dan
+// jumlah byte response final.
+type AfterResponseHook func(ctx *Context, status int, size int64)
+
+// AfterResponse mendaftarkan hook yang berjalan setelah setiap response,
+// misalnya untuk cleanup atau post-processing asinkron. Hook juga berjalan
+// ketika handler panic tanpa ditangkap middleware, setelah panic hooks.
+func (app *App) AfterResponse(h AfterResponseHook) {
+	app.afterHooks = append(app.afterHooks, h)
+}
+
+// BeforeWrite mendaftarkan fn yang dipanggil tepat sebelum header response
+// dikirim, dengan status yang akan ditulis. Header masih bisa diubah di dalam fn.
+// Callback yang didaftarkan setelah response mulai ditulis tidak dijalankan.
+func (c *Context) BeforeWrite(fn func(status int, header http.Header)) {
+	if c.rw == nil || c.rw.written {
+		return
+	}
+	c.rw.before = append(c.rw.before, fn)
+}
+
+func (app *App) runAfterHooks(ctx *Context) {
+	if len(app.afterHooks) == 0 {
+		return
+	}
+
+	status := ctx.Status()
+	if ctx.rw.written {
+		status = ctx.rw.status
+	}
+	for _, h := range app.afterHooks {
+		h(ctx, status, ctx.rw.size)
+	}
+}
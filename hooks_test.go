@@ -0,0 +1,62 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestAfterResponseRunsOnPanic(t *testing.T) {
+	var events []string
+	var statuses []int
+	app := New()
+	app.OnPanic(func(ctx *Context, recovered any, stack []byte) {
+		events = append(events, "panic")
+	})
+	app.AfterResponse(func(ctx *Context, status int, size int64) {
+		events = append(events, "after")
+		statuses = append(statuses, status)
+	})
+	app.Route().GET("/ok", func(ctx *Context) error {
+		return ctx.Success("ok")
+	})
+	app.Route().GET("/boom", func(ctx *Context) error {
+		panic("boom")
+	})
+	app.Route().GET("/partial", func(ctx *Context) error {
+		ctx.Writer().WriteHeader(http.StatusAccepted)
+		panic("boom")
+	})
+
+	serve := func(path string) (panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		return false
+	}
+
+	tests := []struct {
+		path     string
+		panicked bool
+		events   []string
+		status   int
+	}{
+		{"/ok", false, []string{"after"}, http.StatusOK},
+		{"/boom", true, []string{"panic", "after"}, http.StatusInternalServerError},
+		{"/partial", true, []string{"panic", "after"}, http.StatusAccepted},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			events, statuses = nil, nil
+			if got := serve(tc.path); got != tc.panicked {
+				t.Fatalf("panic propagated = %v, want %v", got, tc.panicked)
+			}
+			if !slices.Equal(events, tc.events) {
+				t.Fatalf("hooks ran %v, want %v", events, tc.events)
+			}
+			if statuses[0] != tc.status {
+				t.Fatalf("AfterResponse status %d, want %d", statuses[0], tc.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"net"
+	"strings"
+)
+
+type hostRouter struct {
+	pattern string
+	router  *Router
+}
+
+// Host mengembalikan Router yang hanya melayani request untuk host tersebut.
+// Pattern "*.example.com" match dengan semua subdomain example.com.
+// Request yang tidak cocok dengan host manapun dilayani oleh Route().
+func (app *App) Host(pattern string) *Router {
+	pattern = strings.ToLower(pattern)
+	for _, h := range app.hosts {
+		if h.pattern == pattern {
+			return h.router
+		}
+	}
+
+	r := &Router{
+		routes: make(map[string][]*routeEntry),
+	}
+	app.hosts = append(app.hosts, &hostRouter{pattern: pattern, router: r})
+	return r
+}
+
+func (app *App) routerFor(host string) *Router {
+	if len(app.hosts) == 0 {
+		return app.router
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	var wildcard *Router
+	for _, h := range app.hosts {
+		if h.pattern == host {
+			return h.router
+		}
+		if wildcard == nil && strings.HasPrefix(h.pattern, "*.") && strings.HasSuffix(host, h.pattern[1:]) {
+			wildcard = h.router
+		}
+	}
+	if wildcard != nil {
+		return wildcard
+	}
+	return app.router
+}
@@ -0,0 +1,280 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/godev90/validator/faults"
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// catalogs menyimpan pesan aplikasi per bahasa dengan key datar seperti
+// "cart.items.one". Bentuk plural disimpan sebagai sub-key zero/one/two/few/many/other.
+var catalogs = struct {
+	mu        sync.RWMutex
+	messages  map[faults.LanguageTag]map[string]string
+	fallbacks map[faults.LanguageTag][]faults.LanguageTag
+}{
+	messages:  make(map[faults.LanguageTag]map[string]string),
+	fallbacks: make(map[faults.LanguageTag][]faults.LanguageTag),
+}
+
+var pluralForms = map[plural.Form]string{
+	plural.Other: "other",
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+}
+
+// AddMessages menambahkan pesan untuk bahasa tag. Value boleh string atau
+// map bersarang; key bersarang digabung dengan titik.
+func AddMessages(tag faults.LanguageTag, messages map[string]any) {
+	flat := make(map[string]string)
+	flattenMessages("", messages, flat)
+
+	catalogs.mu.Lock()
+	defer catalogs.mu.Unlock()
+
+	dst, ok := catalogs.messages[tag]
+	if !ok {
+		dst = make(map[string]string)
+		catalogs.messages[tag] = dst
+	}
+	for k, v := range flat {
+		dst[k] = v
+	}
+}
+
+func flattenMessages(prefix string, src map[string]any, dst map[string]string) {
+	for k, v := range src {
+		key := joinPath(prefix, k)
+		switch val := v.(type) {
+		case string:
+			dst[key] = val
+		case map[string]any:
+			flattenMessages(key, val, dst)
+		default:
+			dst[key] = fmt.Sprint(val)
+		}
+	}
+}
+
+// LoadCatalog membaca file katalog .json atau .toml untuk bahasa tag.
+func LoadCatalog(tag faults.LanguageTag, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var messages map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(raw, &messages)
+	case ".toml":
+		messages, err = parseTOML(raw)
+	default:
+		err = fmt.Errorf("unsupported catalog format %q", filepath.Ext(path))
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	AddMessages(tag, messages)
+	return nil
+}
+
+// LoadCatalogs memuat semua file katalog di dir; nama file adalah tag bahasa,
+// misalnya "en.json" atau "id.toml".
+func LoadCatalogs(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".json" && ext != ".toml") {
+			continue
+		}
+		tag := faults.LanguageTag(strings.TrimSuffix(e.Name(), ext))
+		if err := LoadCatalog(tag, filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetFallback mengatur urutan bahasa cadangan untuk tag, misalnya
+// SetFallback("id", "ms", "en"). Setelah rantai habis, faults.DefaultLocale dipakai.
+func SetFallback(tag faults.LanguageTag, fallbacks ...faults.LanguageTag) {
+	catalogs.mu.Lock()
+	defer catalogs.mu.Unlock()
+
+	catalogs.fallbacks[tag] = fallbacks
+}
+
+func fallbackChain(tag faults.LanguageTag) []faults.LanguageTag {
+	chain := []faults.LanguageTag{tag}
+	if t, err := language.Parse(string(tag)); err == nil {
+		if base, _ := t.Base(); base.String() != string(tag) {
+			chain = append(chain, faults.LanguageTag(base.String()))
+		}
+	}
+	chain = append(chain, catalogs.fallbacks[tag]...)
+	return append(chain, faults.DefaultLocale)
+}
+
+// Translate mencari key untuk bahasa tag mengikuti rantai fallback dan
+// memformatnya dengan args (gaya fmt). Jika pesan punya bentuk plural,
+// argumen numerik pertama menentukan bentuknya; pesan tanpa verb tidak
+// memakai args. Key yang tidak ditemukan dikembalikan apa adanya.
+func Translate(tag faults.LanguageTag, key string, args ...any) string {
+	catalogs.mu.RLock()
+	defer catalogs.mu.RUnlock()
+
+	for _, lang := range fallbackChain(tag) {
+		messages := catalogs.messages[lang]
+		if messages == nil {
+			continue
+		}
+
+		if msg, ok := messages[key]; ok {
+			return sprintMessage(msg, args)
+		}
+		if _, ok := messages[key+".other"]; ok {
+			form := pluralFormFor(lang, args)
+			if msg, ok := messages[key+"."+form]; ok {
+				return sprintMessage(msg, args)
+			}
+			return sprintMessage(messages[key+".other"], args)
+		}
+	}
+	return key
+}
+
+// sprintMessage hanya memformat pesan yang punya verb: bentuk plural seperti
+// "satu item" menerima argumen jumlah tanpa memakainya, dan Sprintf akan
+// menambahkan %!(EXTRA int=1).
+func sprintMessage(msg string, args []any) string {
+	if len(args) == 0 {
+		return msg
+	}
+	if !hasVerb(msg) {
+		return strings.ReplaceAll(msg, "%%", "%")
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// hasVerb melaporkan apakah msg berisi verb fmt selain %%.
+func hasVerb(msg string) bool {
+	for i := 0; i < len(msg); i++ {
+		if msg[i] != '%' {
+			continue
+		}
+		if i+1 < len(msg) && msg[i+1] == '%' {
+			i++
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func pluralFormFor(lang faults.LanguageTag, args []any) string {
+	tag, err := language.Parse(string(lang))
+	if err != nil {
+		return "other"
+	}
+
+	for _, arg := range args {
+		var n int
+		switch v := arg.(type) {
+		case int:
+			n = v
+		case int64:
+			n = int(v)
+		case uint:
+			n = int(v)
+		case float64:
+			if v != float64(int(v)) {
+				return "other"
+			}
+			n = int(v)
+		default:
+			continue
+		}
+		if n < 0 {
+			n = -n
+		}
+		return pluralForms[plural.Cardinal.MatchPlural(tag, n, 0, 0, 0, 0)]
+	}
+	return "other"
+}
+
+// T menerjemahkan key ke locale Context.
+func (c *Context) T(key string, args ...any) string {
+	locale := c.locale
+	if locale == "" {
+		locale = faults.DefaultLocale
+	}
+	return Translate(locale, key, args...)
+}
+
+// parseTOML membaca subset TOML yang cukup untuk katalog pesan: tabel
+// [section], key = "string" (boleh dotted key), dan komentar #.
+func parseTOML(raw []byte) (map[string]any, error) {
+	messages := make(map[string]any)
+	section := ""
+
+	sc := bufio.NewScanner(bytes.NewReader(raw))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(strings.TrimSpace(line[1:len(line)-1]), `"`)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		key = strings.Trim(strings.TrimSpace(key), `"`)
+		value = strings.TrimSpace(value)
+
+		var str string
+		switch {
+		case strings.HasPrefix(value, `"`):
+			end := strings.LastIndex(value, `"`)
+			s, err := strconv.Unquote(value[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			str = s
+		case strings.HasPrefix(value, "'"):
+			end := strings.LastIndex(value, "'")
+			if end == 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", n)
+			}
+			str = value[1:end]
+		default:
+			return nil, fmt.Errorf("line %d: only string values are supported", n)
+		}
+
+		messages[joinPath(section, key)] = str
+	}
+	return messages, sc.Err()
+}
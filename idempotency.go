@@ -0,0 +1,266 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+)
+
+// RetrySafety menandai apakah route aman diulang client tanpa efek ganda.
+type RetrySafety int
+
+const (
+	// RetryDefault mengikuti method: GET, HEAD, OPTIONS, PUT, dan DELETE dianggap aman.
+	RetryDefault RetrySafety = iota
+	RetrySafe
+	RetryUnsafe
+)
+
+// Idempotent menandai route (atau group) aman diulang, misalnya POST /search.
+func Idempotent() MiddlewareFunc {
+	return markIdempotent
+}
+
+// NonIdempotent menandai route yang memiliki efek samping walaupun methodnya
+// biasanya aman, misalnya PUT yang memicu pembayaran.
+func NonIdempotent() MiddlewareFunc {
+	return markNonIdempotent
+}
+
+// Penanda dikenali lewat alamat fungsinya saat route didaftarkan, jadi
+// keduanya harus tetap fungsi top-level tanpa closure.
+func markIdempotent(next HandlerFunc) HandlerFunc    { return next }
+func markNonIdempotent(next HandlerFunc) HandlerFunc { return next }
+
+var (
+	markIdempotentPtr    = reflect.ValueOf(markIdempotent).Pointer()
+	markNonIdempotentPtr = reflect.ValueOf(markNonIdempotent).Pointer()
+)
+
+// retrySafetyOf mengambil penanda terakhir di chain, sehingga penanda route
+// menimpa penanda group.
+func retrySafetyOf(mws []MiddlewareFunc) RetrySafety {
+	safety := RetryDefault
+	for _, mw := range mws {
+		switch reflect.ValueOf(mw).Pointer() {
+		case markIdempotentPtr:
+			safety = RetrySafe
+		case markNonIdempotentPtr:
+			safety = RetryUnsafe
+		}
+	}
+	return safety
+}
+
+// RetrySafe melaporkan apakah route saat ini aman diulang.
+func (c *Context) RetrySafe() bool {
+	switch c.retrySafety {
+	case RetrySafe:
+		return true
+	case RetryUnsafe:
+		return false
+	}
+	switch c.request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+type IdempotencyConfig struct {
+	// Store menyimpan response per key, misalnya cache.Redis("main").
+	Store cache.Cache
+	TTL   time.Duration // default 24 jam
+	// KeyHeader default "Idempotency-Key".
+	KeyHeader string
+	// RetryHeader menandai request ulangan dari client; nilai selain kosong
+	// dan "0" dianggap retry. Default "Retry-Attempt".
+	RetryHeader string
+	// LockTTL membatasi berapa lama key ditandai sedang diproses. Default 1 menit.
+	LockTTL time.Duration
+}
+
+type idempotentRecord struct {
+	Pending     bool        `json:"pending,omitempty"`
+	Fingerprint string      `json:"fingerprint"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// WithIdempotency menerapkan Idempotency-Key pada route yang tidak aman
+// diulang (lihat RetrySafe). Retry tanpa key ditolak dengan 428, key yang
+// sudah selesai diproses dijawab ulang dari Store, key yang sedang
+// diproses dijawab 409, dan key yang dipakai dengan body berbeda dijawab 422.
+// Response 5xx, error, atau panic tidak disimpan sehingga client bisa mencoba lagi.
+func WithIdempotency(cfg IdempotencyConfig) MiddlewareFunc {
+	if cfg.Store == nil {
+		panic("netpath: WithIdempotency requires a Store")
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.KeyHeader == "" {
+		cfg.KeyHeader = "Idempotency-Key"
+	}
+	if cfg.RetryHeader == "" {
+		cfg.RetryHeader = "Retry-Attempt"
+	}
+	if cfg.LockTTL == 0 {
+		cfg.LockTTL = time.Minute
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if ctx.RetrySafe() {
+				return next(ctx)
+			}
+
+			r := ctx.request
+			key := r.Header.Get(cfg.KeyHeader)
+			if key == "" {
+				if retry := r.Header.Get(cfg.RetryHeader); retry != "" && retry != "0" {
+					ctx.httpStatus = http.StatusPreconditionRequired
+					ers := faults.Errors{cfg.KeyHeader: faults.ErrRequired}
+					ctx.JSON(http.StatusPreconditionRequired, map[string]any{
+						"code": http.StatusPreconditionRequired,
+						"data": ctx.localizeErrors(ers),
+					})
+					return ers
+				}
+				return next(ctx)
+			}
+
+			body, err := ctx.BodyBytes()
+			if err != nil {
+				return ctx.BadInput(err)
+			}
+			sum := sha256.Sum256(body)
+			fingerprint := hex.EncodeToString(sum[:])
+			storeKey := idempotencyKey(ctx, key)
+
+			rc := r.Context()
+			raw, err := cfg.Store.Get(rc, storeKey)
+			switch {
+			case err == nil:
+				var rec idempotentRecord
+				if err := json.Unmarshal(raw, &rec); err != nil {
+					return ctx.ServerError(err)
+				}
+				if rec.Fingerprint != fingerprint {
+					ctx.httpStatus = http.StatusUnprocessableEntity
+					ers := faults.Errors{cfg.KeyHeader: faults.ErrInvalidParameter.Render(cfg.KeyHeader)}
+					ctx.JSON(http.StatusUnprocessableEntity, map[string]any{
+						"code": http.StatusUnprocessableEntity,
+						"data": ctx.localizeErrors(ers),
+					})
+					return ers
+				}
+				if rec.Pending {
+					return ctx.Conflict(faults.ErrConflict)
+				}
+				return replayIdempotent(ctx, &rec)
+			case !errors.Is(err, cache.ErrMiss):
+				return ctx.Unavailable(err)
+			}
+
+			// Store hanya punya Get/Set, jadi dua request pertama yang benar-benar
+			// bersamaan masih bisa lolos; penanda pending menutup sisanya.
+			pending, _ := json.Marshal(idempotentRecord{Pending: true, Fingerprint: fingerprint})
+			if err := cfg.Store.Set(rc, storeKey, pending, cfg.LockTTL); err != nil {
+				return ctx.Unavailable(err)
+			}
+			entry := IdempotencyEntry{Key: key, Method: r.Method, Path: r.URL.Path, Pending: true, ExpiresAt: time.Now().Add(cfg.LockTTL)}
+			trackIdempotency(storeKey, cfg.Store, entry)
+
+			// context request bisa sudah selesai, jadi simpan dengan context baru
+			bg := context.WithoutCancel(rc)
+			tee := &teeWriter{ResponseWriter: ctx.writer}
+			ctx.writer = tee
+			stored := false
+			defer func() {
+				ctx.writer = tee.ResponseWriter
+				// gagal, 5xx, atau panic: lepas penanda pending supaya retry
+				// dijalankan ulang, bukan dijawab 409 sampai LockTTL habis
+				if !stored {
+					cfg.Store.Delete(bg, storeKey)
+					untrackIdempotency(storeKey)
+				}
+			}()
+
+			err = next(ctx)
+			if err != nil || tee.status == 0 || tee.status >= 500 {
+				return err
+			}
+
+			done, _ := json.Marshal(idempotentRecord{
+				Fingerprint: fingerprint,
+				Status:      tee.status,
+				Header:      tee.Header().Clone(),
+				Body:        tee.buf.Bytes(),
+			})
+			cfg.Store.Set(bg, storeKey, done, cfg.TTL)
+			entry.Pending, entry.Status, entry.ExpiresAt = false, tee.status, time.Now().Add(cfg.TTL)
+			trackIdempotency(storeKey, cfg.Store, entry)
+			stored = true
+			return nil
+		}
+	}
+}
+
+func idempotencyKey(ctx *Context, key string) string {
+	r := ctx.request
+	principal := r.Header.Get("Authorization")
+	if ctx.session != nil {
+		principal = ctx.session.Identifier()
+	}
+	sum := sha256.Sum256([]byte(principal))
+	return "idem:" + hex.EncodeToString(sum[:8]) + ":" + r.Method + ":" + r.URL.Path + ":" + key
+}
+
+func replayIdempotent(ctx *Context, rec *idempotentRecord) error {
+	h := ctx.writer.Header()
+	for k, v := range rec.Header {
+		h[k] = v
+	}
+	h.Set("Idempotent-Replayed", "true")
+	ctx.httpStatus = rec.Status
+	ctx.writer.WriteHeader(rec.Status)
+	_, err := ctx.writer.Write(rec.Body)
+	return err
+}
+
+// teeWriter meneruskan response ke client sambil menyimpan salinannya.
+type teeWriter struct {
+	http.ResponseWriter
+	status int
+	buf    bytes.Buffer
+}
+
+func (w *teeWriter) WriteHeader(code int) {
+	if w.status == 0 && code >= 200 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *teeWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.buf.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *teeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
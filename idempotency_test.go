@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+)
+
+// memoryCache adalah cache.Cache di memori untuk test; ttl diabaikan.
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{items: map[string][]byte{}}
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[key]
+	if !ok {
+		return nil, cache.ErrMiss
+	}
+	return v, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	return nil
+}
+
+func (c *memoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+	return nil
+}
+
+func (c *memoryCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+func TestIdempotencyReleasesKeyAfterPanic(t *testing.T) {
+	store := newMemoryCache()
+	app := New()
+	calls := 0
+	app.Route().POST("/payments", func(ctx *Context) error {
+		calls++
+		if calls == 1 {
+			panic("gateway exploded")
+		}
+		return ctx.Success("paid")
+	}, WithIdempotency(IdempotencyConfig{Store: store}))
+
+	send := func() (w *httptest.ResponseRecorder, panicked bool) {
+		defer func() { panicked = recover() != nil }()
+		r := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":10}`))
+		r.Header.Set("Idempotency-Key", "abc")
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+		return w, false
+	}
+
+	if _, panicked := send(); !panicked {
+		t.Fatal("first request did not panic")
+	}
+	if n := store.len(); n != 0 {
+		t.Fatalf("store holds %d records after panic, want pending key released", n)
+	}
+
+	w, _ := send()
+	if w.Code != http.StatusOK || calls != 2 {
+		t.Fatalf("retry: status %d after %d calls, want 200 after re-execution", w.Code, calls)
+	}
+
+	w, _ = send()
+	if w.Code != http.StatusOK || calls != 2 || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replay: status %d, calls %d, replayed %q", w.Code, calls, w.Header().Get("Idempotent-Replayed"))
+	}
+}
+
+func TestIdempotencyRejectsConcurrentAndMismatchedRequests(t *testing.T) {
+	store := newMemoryCache()
+	app := New()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	app.Route().POST("/payments", func(ctx *Context) error {
+		close(started)
+		<-release
+		return ctx.Success("paid")
+	}, WithIdempotency(IdempotencyConfig{Store: store}))
+
+	send := func(body string) int {
+		r := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(body))
+		r.Header.Set("Idempotency-Key", "abc")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	first := make(chan int)
+	go func() { first <- send(`{"amount":10}`) }()
+	<-started
+
+	if code := send(`{"amount":10}`); code != http.StatusConflict {
+		t.Errorf("concurrent request: status %d, want 409", code)
+	}
+	if code := send(`{"amount":99}`); code != http.StatusUnprocessableEntity {
+		t.Errorf("different body: status %d, want 422", code)
+	}
+	close(release)
+	if code := <-first; code != http.StatusOK {
+		t.Errorf("first request: status %d, want 200", code)
+	}
+}
+
+func TestAdminIdempotencyEntries(t *testing.T) {
+	store := newMemoryCache()
+	app := New()
+	app.Route().Admin("/admin")
+	calls := 0
+	app.Route().POST("/payments", func(ctx *Context) error {
+		calls++
+		return ctx.Success("paid")
+	}, WithIdempotency(IdempotencyConfig{Store: store}))
+
+	pay := func(key string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":5}`))
+		r.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, r)
+		return w
+	}
+	admin := func(method, target string) string {
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s %s: status %d", method, target, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	pay("admin-k1")
+	pay("admin-k2")
+	body := admin(http.MethodGet, "/admin/idempotency")
+	for _, want := range []string{`"key":"admin-k1"`, `"key":"admin-k2"`, `"path":"/payments"`, `"status":200`} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("listing %s lacks %s", body, want)
+		}
+	}
+
+	if body := admin(http.MethodDelete, "/admin/idempotency?key=admin-k1"); !strings.Contains(body, `"purged":1`) {
+		t.Fatalf("purge: %s", body)
+	}
+	if store.len() != 1 {
+		t.Fatalf("store holds %d keys after purge, want 1", store.len())
+	}
+	if body := admin(http.MethodGet, "/admin/idempotency"); strings.Contains(body, "admin-k1") {
+		t.Fatalf("purged key still listed: %s", body)
+	}
+
+	// key yang dihapus dijalankan ulang, key lain masih dijawab ulang
+	pay("admin-k1")
+	pay("admin-k2")
+	if calls != 3 {
+		t.Fatalf("handler ran %d times, want 3", calls)
+	}
+	PurgeIdempotencyEntries(context.Background(), "")
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/godev90/validator/faults"
+)
+
+// EarlyHints mengirim 103 Early Hints dengan header Link supaya browser bisa
+// mulai memuat aset sebelum response final siap, misalnya
+// ctx.EarlyHints("</app.css>; rel=preload; as=style").
+// Header Link tetap ikut di response final.
+func (c *Context) EarlyHints(links ...string) {
+	for _, link := range links {
+		c.writer.Header().Add("Link", link)
+	}
+	c.writer.WriteHeader(http.StatusEarlyHints)
+}
+
+// ExpectsContinue melaporkan apakah client menunggu 100 Continue sebelum mengirim body.
+func (c *Context) ExpectsContinue() bool {
+	return strings.EqualFold(c.request.Header.Get("Expect"), "100-continue")
+}
+
+// Continue mengirim 100 Continue secara eksplisit, misalnya setelah handler
+// upload besar memeriksa auth dan kuota. Tanpa ini server baru mengirimnya
+// ketika body pertama kali dibaca.
+func (c *Context) Continue() {
+	if c.ExpectsContinue() {
+		c.writer.WriteHeader(http.StatusContinue)
+	}
+}
+
+// RejectExpectation menolak request Expect: 100-continue dengan err (atau
+// 417 Expectation Failed) tanpa membaca body, dan menutup koneksi agar
+// body yang mungkin tetap dikirim client tidak perlu dikuras.
+func (c *Context) RejectExpectation(err error) error {
+	if err == nil {
+		err = faults.ErrExpectationFailed
+	}
+	c.writer.Header().Set("Connection", "close")
+	return c.Error(err)
+}
+
+// DeclareTrailer mengumumkan nama trailer yang akan dikirim setelah body.
+// Harus dipanggil sebelum response mulai ditulis.
+func (c *Context) DeclareTrailer(names ...string) {
+	for _, name := range names {
+		c.writer.Header().Add("Trailer", name)
+	}
+}
+
+// SetTrailer mengisi nilai trailer, boleh dipanggil setelah body ditulis.
+// Trailer yang tidak dideklarasikan tetap dikirim (lewat http.TrailerPrefix)
+// selama protokol mendukung trailer.
+func (c *Context) SetTrailer(name, value string) {
+	for _, declared := range c.writer.Header().Values("Trailer") {
+		if strings.EqualFold(strings.TrimSpace(declared), name) {
+			c.writer.Header().Set(name, value)
+			return
+		}
+	}
+	c.writer.Header().Set(http.TrailerPrefix+name, value)
+}
@@ -0,0 +1,239 @@
+package app
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/godev90/validator/faults"
+)
+
+// ContentTypeJOSE adalah media type untuk JWE compact serialization.
+const ContentTypeJOSE = "application/jose"
+
+// JWEConfig mengatur enkripsi payload untuk endpoint yang memuat PII.
+// Kunci bisa berupa *rsa.PrivateKey / *rsa.PublicKey (alg RSA-OAEP-256)
+// atau []byte 32 byte (alg dir). Konten selalu dienkripsi dengan A256GCM.
+type JWEConfig struct {
+	// DecryptionKey mengembalikan kunci untuk membuka request berdasarkan
+	// kid di header JWE. Jika nil, request tidak didekripsi.
+	DecryptionKey func(ctx *Context, kid string) (any, error)
+	// EncryptionKey mengembalikan kunci penerima response, misalnya kunci
+	// publik client yang terdaftar untuk session. Jika nil, response
+	// dikirim apa adanya.
+	EncryptionKey func(ctx *Context) (kid string, key any, err error)
+	// AllowPlaintext menerima request yang tidak terenkripsi. Default
+	// request ber-body wajib application/jose.
+	AllowPlaintext bool
+}
+
+// WithJWE mendekripsi body request application/jose sebelum handler
+// berjalan (Bind membaca plaintext) dan mengenkripsi body response, sehingga
+// handler tidak perlu menyentuh kode kriptografi.
+func WithJWE(cfg JWEConfig) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if cfg.DecryptionKey != nil {
+				if err := decryptRequest(ctx, cfg); err != nil {
+					return err
+				}
+			}
+			if cfg.EncryptionKey == nil {
+				return next(ctx)
+			}
+
+			buf := &bufferWriter{ResponseWriter: ctx.writer}
+			ctx.writer = buf
+			err := next(ctx)
+			// error yang belum dirender dienkripsi juga, bukan dikirim plaintext
+			if err != nil && buf.status == 0 {
+				ctx.Error(err)
+			}
+			ctx.writer = buf.ResponseWriter
+			if buf.status == 0 {
+				return err
+			}
+
+			kid, key, kerr := cfg.EncryptionKey(ctx)
+			if kerr != nil {
+				return ctx.ServerError(kerr)
+			}
+			h := ctx.writer.Header()
+			token, eerr := EncryptJWE(buf.body.Bytes(), kid, key, h.Get("Content-Type"))
+			if eerr != nil {
+				return ctx.ServerError(eerr)
+			}
+			h.Set("Content-Type", ContentTypeJOSE)
+			h.Del("Content-Length")
+			ctx.writer.WriteHeader(buf.status)
+			ctx.writer.Write([]byte(token))
+			return err
+		}
+	}
+}
+
+func decryptRequest(ctx *Context, cfg JWEConfig) error {
+	body, err := ctx.BodyBytes()
+	if err != nil {
+		return ctx.BadInput(err)
+	}
+	mediaType, _, _ := mime.ParseMediaType(ctx.request.Header.Get("Content-Type"))
+	if mediaType != ContentTypeJOSE {
+		if len(body) == 0 || cfg.AllowPlaintext {
+			return nil
+		}
+		return ctx.Error(faults.ErrUnsupportedMediaType)
+	}
+
+	plain, header, err := decryptJWE(strings.TrimSpace(string(body)), func(kid string) (any, error) {
+		return cfg.DecryptionKey(ctx, kid)
+	})
+	if err != nil {
+		return ctx.BadInput(faults.ErrBadRequest)
+	}
+
+	cty := header.Cty
+	if cty == "" {
+		cty = "application/json"
+	}
+	ctx.request.Header.Set("Content-Type", cty)
+	ctx.request.ContentLength = int64(len(plain))
+	ctx.body = plain
+	ctx.resetBody()
+	return nil
+}
+
+type jweHeader struct {
+	Alg string `json:"alg"`
+	Enc string `json:"enc"`
+	Kid string `json:"kid,omitempty"`
+	Cty string `json:"cty,omitempty"`
+}
+
+var b64 = base64.RawURLEncoding
+
+// EncryptJWE membuat JWE compact A256GCM untuk key, misalnya untuk client
+// Go yang memanggil endpoint WithJWE. cty boleh kosong.
+func EncryptJWE(plain []byte, kid string, key any, cty string) (string, error) {
+	header := jweHeader{Enc: "A256GCM", Kid: kid, Cty: cty}
+	var cek, encryptedKey []byte
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		header.Alg = "RSA-OAEP-256"
+		cek = make([]byte, 32)
+		if _, err := rand.Read(cek); err != nil {
+			return "", err
+		}
+		var err error
+		encryptedKey, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, k, cek, nil)
+		if err != nil {
+			return "", err
+		}
+	case []byte:
+		header.Alg = "dir"
+		cek = k
+	default:
+		return "", fmt.Errorf("netpath: unsupported JWE key %T", key)
+	}
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return "", err
+	}
+	rawHeader, _ := json.Marshal(header)
+	protected := b64.EncodeToString(rawHeader)
+	iv := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nil, iv, plain, []byte(protected))
+	ciphertext, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return strings.Join([]string{
+		protected,
+		b64.EncodeToString(encryptedKey),
+		b64.EncodeToString(iv),
+		b64.EncodeToString(ciphertext),
+		b64.EncodeToString(tag),
+	}, "."), nil
+}
+
+// decryptJWE membuka JWE compact; keyFor dipanggil dengan kid dari header.
+func decryptJWE(token string, keyFor func(kid string) (any, error)) ([]byte, jweHeader, error) {
+	var header jweHeader
+	parts := strings.Split(token, ".")
+	if len(parts) != 5 {
+		return nil, header, errors.New("netpath: malformed JWE")
+	}
+	raw := make([][]byte, 5)
+	for i, p := range parts {
+		b, err := b64.DecodeString(p)
+		if err != nil {
+			return nil, header, err
+		}
+		raw[i] = b
+	}
+	if err := json.Unmarshal(raw[0], &header); err != nil {
+		return nil, header, err
+	}
+	if header.Enc != "A256GCM" {
+		return nil, header, fmt.Errorf("netpath: unsupported JWE enc %q", header.Enc)
+	}
+
+	key, err := keyFor(header.Kid)
+	if err != nil {
+		return nil, header, err
+	}
+	var cek []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if header.Alg != "RSA-OAEP-256" {
+			return nil, header, fmt.Errorf("netpath: JWE alg %q does not match key", header.Alg)
+		}
+		if cek, err = rsa.DecryptOAEP(sha256.New(), nil, k, raw[1], nil); err != nil {
+			return nil, header, err
+		}
+	case []byte:
+		if header.Alg != "dir" || len(raw[1]) != 0 {
+			return nil, header, fmt.Errorf("netpath: JWE alg %q does not match key", header.Alg)
+		}
+		cek = k
+	default:
+		return nil, header, fmt.Errorf("netpath: unsupported JWE key %T", key)
+	}
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, header, err
+	}
+	if len(raw[2]) != gcm.NonceSize() {
+		return nil, header, errors.New("netpath: malformed JWE")
+	}
+	plain, err := gcm.Open(nil, raw[2], append(raw[3], raw[4]...), []byte(parts[0]))
+	return plain, header, err
+}
+
+// DecryptJWE membuka JWE compact dengan key (*rsa.PrivateKey atau []byte).
+func DecryptJWE(token string, key any) ([]byte, error) {
+	plain, _, err := decryptJWE(token, func(string) (any, error) { return key, nil })
+	return plain, err
+}
+
+func newGCM(cek []byte) (cipher.AEAD, error) {
+	if len(cek) != 32 {
+		return nil, errors.New("netpath: A256GCM requires a 32-byte key")
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
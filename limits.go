@@ -0,0 +1,231 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/godev90/validator/faults"
+)
+
+// WithTimeout membatasi durasi handler. Deadline dipasang pada context request;
+// jika terlewati, client menerima 503 dan output handler dibuang.
+func WithTimeout(d time.Duration) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			tctx, cancel := context.WithTimeout(ctx.request.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{header: make(http.Header), status: http.StatusOK}
+			inner := ctx.detached(tw)
+			inner.request = ctx.request.WithContext(tctx)
+
+			done := make(chan error, 1)
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				done <- next(inner)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case err := <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+
+				ctx.adopt(inner)
+				writer := ctx.writer
+
+				dst := writer.Header()
+				for k, v := range tw.header {
+					dst[k] = v
+				}
+				if tw.wrote || tw.buf.Len() > 0 {
+					writer.WriteHeader(tw.status)
+					writer.Write(tw.buf.Bytes())
+				}
+				return err
+			case <-tctx.Done():
+				tw.mu.Lock()
+				tw.timedOut = true
+				tw.mu.Unlock()
+
+				return ctx.Unavailable(faults.ErrServiceUnavailable)
+			}
+		}
+	}
+}
+
+// detached membuat salinan ctx untuk handler yang berjalan di goroutine lain
+// dengan writer dan state sendiri, sehingga handler yang terus berjalan
+// setelah timeout tidak menyentuh response atau map milik ctx.
+func (c *Context) detached(w http.ResponseWriter) *Context {
+	inner := *c
+	inner.rw = &responseWriter{ResponseWriter: w}
+	inner.writer = inner.rw
+	inner.Params = maps.Clone(c.Params)
+	inner.variants = maps.Clone(c.variants)
+	inner.scoped = maps.Clone(c.scoped)
+	inner.breadcrumbs = slices.Clip(c.breadcrumbs)
+	// flash dibuat di ctx agar callback cookie-nya menulis ke writer ctx
+	flash := *c.flashes()
+	flash.out = maps.Clone(c.flash.out)
+	inner.flash = &flash
+	return &inner
+}
+
+// adopt mengambil state inner setelah handler selesai tepat waktu; writer
+// ctx tetap dipakai. Callback BeforeWrite yang didaftarkan handler tetapi
+// belum jalan dipindahkan ke writer ctx.
+func (c *Context) adopt(inner *Context) {
+	writer, rw, flash := c.writer, c.rw, c.flash
+	if !inner.rw.written && rw != nil {
+		rw.before = append(rw.before, inner.rw.before...)
+	}
+	*flash = *inner.flash
+	*c = *inner
+	c.writer, c.rw, c.flash = writer, rw, flash
+}
+
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	wrote    bool
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.wrote {
+		return
+	}
+	w.status = code
+	w.wrote = true
+}
+
+func (w *timeoutWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.wrote = true
+	return w.buf.Write(p)
+}
+
+// WithMaxBody membatasi ukuran body request dalam byte. Request dengan
+// Content-Length yang lebih besar langsung ditolak dengan 413.
+func WithMaxBody(n int64) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if ctx.request.ContentLength > n {
+				return ctx.PayloadTooLarge(faults.ErrPayloadTooLarge)
+			}
+			if ctx.request.Body != nil {
+				ctx.request.Body = http.MaxBytesReader(ctx.writer, ctx.request.Body, n)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// WithRateLimit membatasi jumlah request per alamat client dalam satu jendela waktu.
+// Setiap pemanggilan membuat limiter tersendiri, sehingga dipasang di route
+// atau group berarti kuota terpisah untuk route atau group tersebut.
+func WithRateLimit(limit int, per time.Duration) MiddlewareFunc {
+	return WithNamedRateLimit("", limit, per)
+}
+
+// WithNamedRateLimit sama dengan WithRateLimit, tetapi limit dan jendelanya
+// bisa diganti saat aplikasi berjalan lewat "rate_limits" di WatchConfig.
+func WithNamedRateLimit(name string, limit int, per time.Duration) MiddlewareFunc {
+	rl := &windowLimiter{
+		name:    name,
+		limit:   limit,
+		per:     per,
+		windows: make(map[string]*window),
+	}
+	registerLimiter(rl)
+
+	mw := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			allowed, retry := rl.allow(clientKey(ctx.request))
+			if !allowed {
+				ctx.writer.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds()+0.5)))
+				return ctx.TooManyRequest(faults.ErrTooManyRequests)
+			}
+			return next(ctx)
+		}
+	}
+	return describe(mw, func(m *RouteMeta) {
+		rl.mu.Lock()
+		defer rl.mu.Unlock()
+		m.RateLimits = append(m.RateLimits, RateLimitMeta{Name: rl.name, Limit: rl.limit, Per: rl.per})
+	})
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+type windowLimiter struct {
+	mu      sync.Mutex
+	name    string
+	limit   int
+	per     time.Duration
+	windows map[string]*window
+	swept   time.Time
+}
+
+func (l *windowLimiter) allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.swept) > l.per {
+		for k, w := range l.windows {
+			if now.Sub(w.start) >= l.per {
+				delete(l.windows, k)
+			}
+		}
+		l.swept = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.per {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.limit {
+		return false, w.start.Add(l.per).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+func clientKey(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+type LoginGuardConfig struct {
+	Alias  string // alias Redis di cache.Pool()
+	Prefix string // default "login:"
+
+	// Identifier mengambil username/email dari request, misalnya dari body
+	// lewat ctx.BodyBytes (body tetap bisa di-Bind handler).
+	Identifier func(ctx *Context) string
+	// Failed menentukan apakah percobaan gagal. Default status response 401.
+	Failed func(ctx *Context, err error) bool
+
+	MaxAttempts      int           // per identifier sebelum dikunci, default 5
+	MaxAttemptsPerIP int           // per IP sebelum dikunci, default 20
+	Window           time.Duration // jendela hitungan gagal, default 15 menit
+	Lockout          time.Duration // lama kunci, default 15 menit
+	// Delay adalah jeda setelah gagal pertama, digandakan setiap kegagalan
+	// berikutnya sampai MaxDelay. Default 1 detik dan 30 detik.
+	Delay    time.Duration
+	MaxDelay time.Duration
+}
+
+// LoginGuard melacak kegagalan autentikasi per identifier dan per IP di
+// Redis. Setiap kegagalan menambah jeda sebelum percobaan berikutnya boleh
+// dilakukan; setelah batas tercapai, identifier atau IP dikunci.
+type LoginGuard struct {
+	cfg LoginGuardConfig
+}
+
+func NewLoginGuard(cfg LoginGuardConfig) *LoginGuard {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "login:"
+	}
+	if cfg.Failed == nil {
+		cfg.Failed = func(ctx *Context, err error) bool {
+			return outcomeStatus(ctx, err) == http.StatusUnauthorized
+		}
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.MaxAttemptsPerIP <= 0 {
+		cfg.MaxAttemptsPerIP = 20
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = 15 * time.Minute
+	}
+	if cfg.Lockout <= 0 {
+		cfg.Lockout = 15 * time.Minute
+	}
+	if cfg.Delay <= 0 {
+		cfg.Delay = time.Second
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = 30 * time.Second
+	}
+	return &LoginGuard{cfg: cfg}
+}
+
+// LoginStatus adalah keadaan throttling satu identifier atau IP.
+type LoginStatus struct {
+	Failures    int64     `json:"failures"`
+	RetryAt     time.Time `json:"retry_at,omitempty"`
+	LockedUntil time.Time `json:"locked_until,omitempty"`
+}
+
+func (g *LoginGuard) keys(subject string) (failures, next, lock string) {
+	base := g.cfg.Prefix + subject
+	return base + ":f", base + ":n", base + ":l"
+}
+
+// loginFailScript mencatat satu kegagalan.
+// KEYS: failures, next, lock; ARGV: max, window, delay, maxDelay, lockout (ms), now (unix ms).
+var loginFailScript = redis.NewScript(`
+local f = redis.call('INCR', KEYS[1])
+if f == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[2]) end
+local now = tonumber(ARGV[6])
+if f >= tonumber(ARGV[1]) then
+  redis.call('SET', KEYS[3], string.format('%d', now + tonumber(ARGV[5])), 'PX', ARGV[5])
+  redis.call('DEL', KEYS[1], KEYS[2])
+  return f
+end
+local delay = math.floor(math.min(tonumber(ARGV[3]) * 2 ^ (f - 1), tonumber(ARGV[4])))
+redis.call('SET', KEYS[2], string.format('%d', now + delay), 'PX', delay)
+return f
+`)
+
+// Middleware menolak percobaan yang masih dalam jeda atau kunci dengan 429
+// dan Retry-After, lalu mencatat hasil handler: gagal menambah hitungan,
+// berhasil menghapus hitungan identifier.
+func (g *LoginGuard) Middleware() MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			client, err := cache.Pool().Get(g.cfg.Alias)
+			if err != nil {
+				return ctx.ServerError(err)
+			}
+			rctx := ctx.request.Context()
+
+			subjects := []string{"ip:" + ctx.ClientIP()}
+			if g.cfg.Identifier != nil {
+				if id := g.cfg.Identifier(ctx); id != "" {
+					subjects = append(subjects, "id:"+id)
+				}
+			}
+
+			var blockedUntil time.Time
+			for _, s := range subjects {
+				st, err := g.status(rctx, client, s)
+				if err != nil {
+					return ctx.ServerError(err)
+				}
+				blockedUntil = latest(blockedUntil, st.RetryAt, st.LockedUntil)
+			}
+			if wait := time.Until(blockedUntil); wait > 0 {
+				ctx.writer.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+				return ctx.TooManyRequest(faults.ErrTooManyRequests)
+			}
+
+			herr := next(ctx)
+
+			if g.cfg.Failed(ctx, herr) {
+				now := time.Now().UnixMilli()
+				for _, s := range subjects {
+					limit := g.cfg.MaxAttempts
+					if strings.HasPrefix(s, "ip:") {
+						limit = g.cfg.MaxAttemptsPerIP
+					}
+					f, n, l := g.keys(s)
+					loginFailScript.Run(context.WithoutCancel(rctx), client, []string{f, n, l},
+						limit, g.cfg.Window.Milliseconds(), g.cfg.Delay.Milliseconds(),
+						g.cfg.MaxDelay.Milliseconds(), g.cfg.Lockout.Milliseconds(), now)
+				}
+			} else if outcomeStatus(ctx, herr) < http.StatusBadRequest && len(subjects) > 1 {
+				f, n, _ := g.keys(subjects[1])
+				client.Del(context.WithoutCancel(rctx), f, n)
+			}
+			return herr
+		}
+	}
+}
+
+// outcomeStatus adalah status response handler, termasuk error yang baru
+// akan dirender App setelah middleware selesai.
+func outcomeStatus(ctx *Context, err error) int {
+	if status := ctx.Status(); status != 0 {
+		return status
+	}
+	if err != nil {
+		status, _ := resolveError(err)
+		return status
+	}
+	return http.StatusOK
+}
+
+func latest(times ...time.Time) time.Time {
+	var t time.Time
+	for _, x := range times {
+		if x.After(t) {
+			t = x
+		}
+	}
+	return t
+}
+
+func (g *LoginGuard) status(ctx context.Context, client *redis.Client, subject string) (LoginStatus, error) {
+	f, n, l := g.keys(subject)
+	values, err := client.MGet(ctx, f, n, l).Result()
+	if err != nil {
+		return LoginStatus{}, err
+	}
+	var st LoginStatus
+	parse := func(v any) int64 {
+		s, _ := v.(string)
+		i, _ := strconv.ParseInt(s, 10, 64)
+		return i
+	}
+	st.Failures = parse(values[0])
+	if ms := parse(values[1]); ms > 0 {
+		st.RetryAt = time.UnixMilli(ms)
+	}
+	if ms := parse(values[2]); ms > 0 {
+		st.LockedUntil = time.UnixMilli(ms)
+	}
+	return st, nil
+}
+
+// Status mengembalikan keadaan identifier, untuk tooling support.
+func (g *LoginGuard) Status(ctx context.Context, identifier string) (LoginStatus, error) {
+	return g.subjectStatus(ctx, "id:"+identifier)
+}
+
+// IPStatus mengembalikan keadaan alamat IP.
+func (g *LoginGuard) IPStatus(ctx context.Context, ip string) (LoginStatus, error) {
+	return g.subjectStatus(ctx, "ip:"+ip)
+}
+
+func (g *LoginGuard) subjectStatus(ctx context.Context, subject string) (LoginStatus, error) {
+	client, err := cache.Pool().Get(g.cfg.Alias)
+	if err != nil {
+		return LoginStatus{}, err
+	}
+	return g.status(ctx, client, subject)
+}
+
+// Reset menghapus hitungan, jeda, dan kunci identifier.
+func (g *LoginGuard) Reset(ctx context.Context, identifier string) error {
+	return g.reset(ctx, "id:"+identifier)
+}
+
+// ResetIP menghapus hitungan, jeda, dan kunci alamat IP.
+func (g *LoginGuard) ResetIP(ctx context.Context, ip string) error {
+	return g.reset(ctx, "ip:"+ip)
+}
+
+func (g *LoginGuard) reset(ctx context.Context, subject string) error {
+	client, err := cache.Pool().Get(g.cfg.Alias)
+	if err != nil {
+		return err
+	}
+	f, n, l := g.keys(subject)
+	return client.Del(ctx, f, n, l).Err()
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/godev90/validator/faults"
+)
+
+// SetMaintenance menyalakan atau mematikan mode maintenance. Selama aktif,
+// semua request dijawab 503 kecuali route admin dari MountAdmin.
+func (app *App) SetMaintenance(on bool) {
+	app.maintenance.Store(on)
+}
+
+func (app *App) Maintenance() bool {
+	return app.maintenance.Load()
+}
+
+func (app *App) inMaintenance(ctx *Context, path string) bool {
+	if !app.maintenance.Load() {
+		return false
+	}
+	if app.adminPrefix != "" && (path == app.adminPrefix || strings.HasPrefix(path, app.adminPrefix+"/")) {
+		return false
+	}
+
+	ctx.writer.Header().Set("Retry-After", "120")
+	ctx.Unavailable(faults.ErrServiceUnavailable)
+	return true
+}
+
+// RouteInfo adalah satu baris tabel route untuk endpoint admin.
+type RouteInfo struct {
+	Host    string `json:"host,omitempty"`
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+
+	Middleware []string `json:"middleware,omitempty"`
+	Source     string   `json:"source,omitempty"`
+
+	// Request dan Response hanya terisi untuk route yang didaftarkan lewat Handle.
+	Request  reflect.Type `json:"-"`
+	Response reflect.Type `json:"-"`
+
+	// Meta berisi kebijakan yang dideklarasikan middleware App, group, dan route.
+	Meta RouteMeta `json:"meta"`
+}
+
+// Routes mengembalikan semua route terdaftar, termasuk milik virtual host.
+func (app *App) Routes() []RouteInfo {
+	var routes []RouteInfo
+	collect := func(host string, r *Router) {
+		for method, entries := range r.routes {
+			for _, e := range entries {
+				routes = append(routes, RouteInfo{
+					Host:       host,
+					Method:     method,
+					Pattern:    e.pattern,
+					Middleware: middlewareNames(e.middleware),
+					Source:     e.source,
+					Request:    e.request,
+					Response:   e.response,
+					Meta:       metaOf(append(app.mw[:len(app.mw):len(app.mw)], e.middleware...)),
+				})
+			}
+		}
+	}
+
+	collect("", app.router)
+	for _, h := range app.hosts {
+		collect(h.pattern, h.router)
+	}
+	return routes
+}
@@ -0,0 +1,240 @@
+package app
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// WithMasking menyaring field bertag `mask` saat response JSON dirender.
+// roles menentukan peran session saat ini; request tanpa session tidak
+// punya peran. Format tag:
+//
+//	Email string `json:"email" mask:"admin,support"`        // dihapus untuk peran lain
+//	Phone string `json:"phone" mask:"admin;redact"`         // diganti "****" (selain string: null)
+//	Card  string `json:"card"  mask:"admin,billing;last4"`  // hanya 4 karakter terakhir terlihat
+func WithMasking(roles func(Session) []string) Option {
+	return func(app *App) {
+		app.maskRoles = roles
+	}
+}
+
+type maskRule struct {
+	roles []string
+	mode  string // "omit", "redact", atau "last4"
+}
+
+func parseMaskTag(tag string) maskRule {
+	roles, mode, _ := strings.Cut(tag, ";")
+	rule := maskRule{mode: strings.TrimSpace(mode)}
+	if rule.mode == "" {
+		rule.mode = "omit"
+	}
+	for _, r := range strings.Split(roles, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			rule.roles = append(rule.roles, r)
+		}
+	}
+	return rule
+}
+
+func (r maskRule) allows(roles map[string]bool) bool {
+	for _, role := range r.roles {
+		if roles[role] {
+			return true
+		}
+	}
+	return false
+}
+
+// maskTypes menyimpan apakah sebuah tipe (atau tipe di dalamnya) punya tag
+// mask, supaya response tanpa tag dilewatkan tanpa disalin.
+var maskTypes sync.Map
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func hasMask(t reflect.Type) bool {
+	if v, ok := maskTypes.Load(t); ok {
+		return v.(bool)
+	}
+	found := scanMask(t, map[reflect.Type]bool{})
+	// hanya hasil akhir yang disimpan; hasil sementara di dalam siklus
+	// tipe rekursif belum tentu lengkap
+	maskTypes.Store(t, found)
+	return found
+}
+
+// scanMask memeriksa t dan tipe di dalamnya. visiting mencegah loop pada
+// tipe rekursif: tipe yang sedang dipindai tidak menambah apa-apa, jalur
+// lain yang menentukan hasilnya.
+func scanMask(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if v, ok := maskTypes.Load(t); ok {
+		return v.(bool)
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return scanMask(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() && !sf.Anonymous {
+				continue
+			}
+			if sf.Tag.Get("mask") != "" || scanMask(sf.Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// masked mengembalikan data dengan field bertag mask disaring sesuai peran
+// session. Struct yang terdampak diubah menjadi map mengikuti aturan tag json.
+func (c *Context) masked(data any) any {
+	return c.masker()(data)
+}
+
+// masker mengunci peran session saat ini, untuk renderer yang meng-encode
+// banyak item (NDJSON, JSONStream, SSE) atau yang berjalan setelah request
+// selesai (Async).
+func (c *Context) masker() func(any) any {
+	if c.app == nil || c.app.maskRoles == nil {
+		return func(data any) any { return data }
+	}
+	roles := map[string]bool{}
+	if c.session != nil {
+		for _, r := range c.app.maskRoles(c.session) {
+			roles[r] = true
+		}
+	}
+	return func(data any) any {
+		if data == nil {
+			return nil
+		}
+		return maskValue(reflect.ValueOf(data), roles)
+	}
+}
+
+func maskValue(v reflect.Value, roles map[string]bool) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if !hasMask(v.Type()) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return maskValue(v.Elem(), roles)
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = maskValue(v.Index(i), roles)
+		}
+		return out
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = maskValue(iter.Value(), roles)
+		}
+		return out
+
+	case reflect.Struct:
+		out := make(map[string]any)
+		maskStruct(v, roles, out)
+		return out
+	}
+	return v.Interface()
+}
+
+func maskStruct(v reflect.Value, roles map[string]bool, out map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		fv := v.Field(i)
+
+		// embedded struct tanpa nama json diratakan seperti encoding/json
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv, ft = fv.Elem(), ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				maskStruct(fv, roles, out)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if strings.Contains(opts, "omitempty") && fv.IsZero() {
+			continue
+		}
+
+		if mt := sf.Tag.Get("mask"); mt != "" {
+			rule := parseMaskTag(mt)
+			if !rule.allows(roles) {
+				switch rule.mode {
+				case "redact":
+					if fv.Kind() == reflect.String {
+						out[name] = "****"
+					} else {
+						out[name] = nil
+					}
+				case "last4":
+					out[name] = maskLast4(fmt.Sprint(fv.Interface()))
+				}
+				continue
+			}
+		}
+		out[name] = maskValue(fv, roles)
+	}
+}
+
+func maskLast4(s string) string {
+	runes := []rune(s)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+}
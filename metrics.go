@@ -0,0 +1,362 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	DefaultSizeBuckets     = []float64{100, 1000, 10_000, 100_000, 1_000_000, 10_000_000}
+)
+
+type MetricsConfig struct {
+	DurationBuckets []float64 // detik
+	SizeBuckets     []float64 // byte, untuk request dan response
+	// Exemplars menautkan observasi histogram ke trace ID dari header
+	// traceparent (W3C) sehingga dashboard bisa melompat ke trace-nya.
+	Exemplars bool
+}
+
+// Metrics mencatat jumlah request serta histogram durasi dan ukuran
+// request/response per pattern route, dan menyajikannya dalam format
+// Prometheus/OpenMetrics lewat ServeHTTP.
+type Metrics struct {
+	cfg MetricsConfig
+
+	mu         sync.Mutex
+	requests   map[requestSeries]uint64
+	routes     map[routeSeries]*routeHistograms
+	challenges map[challengeSeries]uint64
+	fields     map[fieldSeries]uint64
+}
+
+type requestSeries struct {
+	method, route string
+	status        int
+}
+
+type routeSeries struct {
+	method, route string
+}
+
+type challengeSeries struct {
+	provider, route, result string
+}
+
+type fieldSeries struct {
+	route, field, kind string
+}
+
+type routeHistograms struct {
+	duration, reqSize, respSize *histogram
+}
+
+type histogram struct {
+	bounds    []float64
+	counts    []uint64
+	sum       float64
+	count     uint64
+	exemplars []exemplar
+}
+
+type exemplar struct {
+	traceID string
+	value   float64
+	at      time.Time
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds:    bounds,
+		counts:    make([]uint64, len(bounds)),
+		exemplars: make([]exemplar, len(bounds)+1),
+	}
+}
+
+func (h *histogram) observe(v float64, traceID string) {
+	h.sum += v
+	h.count++
+	i := sort.SearchFloat64s(h.bounds, v)
+	for j := i; j < len(h.counts); j++ {
+		h.counts[j]++
+	}
+	if traceID != "" {
+		h.exemplars[i] = exemplar{traceID: traceID, value: v, at: time.Now()}
+	}
+}
+
+// EnableMetrics mulai mencatat metrik untuk setiap request. Pasang Metrics
+// yang dikembalikan sebagai handler, misalnya lewat Router.Mount("/metrics", m).
+func (app *App) EnableMetrics(cfg MetricsConfig) *Metrics {
+	if len(cfg.DurationBuckets) == 0 {
+		cfg.DurationBuckets = DefaultDurationBuckets
+	}
+	if len(cfg.SizeBuckets) == 0 {
+		cfg.SizeBuckets = DefaultSizeBuckets
+	}
+	sort.Float64s(cfg.DurationBuckets)
+	sort.Float64s(cfg.SizeBuckets)
+
+	m := &Metrics{
+		cfg:      cfg,
+		requests: make(map[requestSeries]uint64),
+		routes:   make(map[routeSeries]*routeHistograms),
+
+		challenges: make(map[challengeSeries]uint64),
+		fields:     make(map[fieldSeries]uint64),
+	}
+	app.AfterResponse(m.observe)
+	return m
+}
+
+func (m *Metrics) observe(ctx *Context, status int, size int64) {
+	route := ctx.route
+	if route == "" {
+		route = "unmatched"
+	}
+	method := ctx.request.Method
+
+	var traceID string
+	if m.cfg.Exemplars {
+		traceID = traceIDFrom(ctx.request)
+	}
+
+	duration := time.Since(ctx.startedAt).Seconds()
+	reqSize := float64(max(ctx.request.ContentLength, 0))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestSeries{method: method, route: route, status: status}]++
+
+	key := routeSeries{method: method, route: route}
+	h, ok := m.routes[key]
+	if !ok {
+		h = &routeHistograms{
+			duration: newHistogram(m.cfg.DurationBuckets),
+			reqSize:  newHistogram(m.cfg.SizeBuckets),
+			respSize: newHistogram(m.cfg.SizeBuckets),
+		}
+		m.routes[key] = h
+	}
+	h.duration.observe(duration, traceID)
+	h.reqSize.observe(reqSize, traceID)
+	h.respSize.observe(float64(size), traceID)
+}
+
+// challenge mencatat hasil verifikasi captcha; aman dipanggil dengan m nil.
+func (m *Metrics) challenge(provider, route, result string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.challenges[challengeSeries{provider: provider, route: route, result: result}]++
+}
+
+// payloadField mencatat field body yang unknown atau deprecated; aman
+// dipanggil dengan m nil.
+func (m *Metrics) payloadField(route, field, kind string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fields[fieldSeries{route: route, field: field, kind: kind}]++
+}
+
+// traceIDFrom mengambil trace ID dari header traceparent:
+// "00-<trace id 32 hex>-<span id>-<flags>".
+func traceIDFrom(r *http.Request) string {
+	parts := strings.Split(r.Header.Get("traceparent"), "-")
+	if len(parts) == 4 && len(parts[1]) == 32 {
+		return parts[1]
+	}
+	return ""
+}
+
+// ServeHTTP menyajikan metrik dalam format OpenMetrics (atau Prometheus text
+// jika exemplar dimatikan).
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.cfg.Exemplars {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	}
+
+	// salinan diambil di bawah lock; scrape yang lambat tidak menahan observe
+	m.mu.Lock()
+	snap := m.snapshot()
+	m.mu.Unlock()
+
+	// OpenMetrics menamai family counter tanpa akhiran _total
+	if m.cfg.Exemplars {
+		fmt.Fprintln(w, "# TYPE netpath_requests counter")
+	} else {
+		fmt.Fprintln(w, "# TYPE netpath_requests_total counter")
+	}
+	reqKeys := make([]requestSeries, 0, len(snap.requests))
+	for k := range snap.requests {
+		reqKeys = append(reqKeys, k)
+	}
+	sort.Slice(reqKeys, func(i, j int) bool {
+		a, b := reqKeys[i], reqKeys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.status < b.status
+	})
+	for _, k := range reqKeys {
+		fmt.Fprintf(w, "netpath_requests_total{method=%q,route=%q,status=\"%d\"} %d\n", k.method, k.route, k.status, snap.requests[k])
+	}
+
+	routeKeys := make([]routeSeries, 0, len(snap.routes))
+	for k := range snap.routes {
+		routeKeys = append(routeKeys, k)
+	}
+	sort.Slice(routeKeys, func(i, j int) bool {
+		if routeKeys[i].route != routeKeys[j].route {
+			return routeKeys[i].route < routeKeys[j].route
+		}
+		return routeKeys[i].method < routeKeys[j].method
+	})
+
+	for _, metric := range []struct {
+		name string
+		pick func(*routeHistograms) *histogram
+	}{
+		{"netpath_request_duration_seconds", func(h *routeHistograms) *histogram { return h.duration }},
+		{"netpath_request_size_bytes", func(h *routeHistograms) *histogram { return h.reqSize }},
+		{"netpath_response_size_bytes", func(h *routeHistograms) *histogram { return h.respSize }},
+	} {
+		fmt.Fprintf(w, "# TYPE %s histogram\n", metric.name)
+		for _, k := range routeKeys {
+			labels := fmt.Sprintf("method=%q,route=%q", k.method, k.route)
+			m.writeHistogram(w, metric.name, labels, metric.pick(snap.routes[k]))
+		}
+	}
+
+	if len(snap.challenges) > 0 {
+		m.writeChallenges(w, snap.challenges)
+	}
+	if len(snap.fields) > 0 {
+		m.writePayloadFields(w, snap.fields)
+	}
+
+	if m.cfg.Exemplars {
+		fmt.Fprintln(w, "# EOF")
+	}
+}
+
+// metricsSnapshot adalah salinan seluruh seri untuk satu scrape.
+type metricsSnapshot struct {
+	requests   map[requestSeries]uint64
+	routes     map[routeSeries]*routeHistograms
+	challenges map[challengeSeries]uint64
+	fields     map[fieldSeries]uint64
+}
+
+// snapshot menyalin seri yang tercatat; m.mu harus sudah dikunci.
+func (m *Metrics) snapshot() metricsSnapshot {
+	routes := make(map[routeSeries]*routeHistograms, len(m.routes))
+	for k, h := range m.routes {
+		routes[k] = &routeHistograms{
+			duration: h.duration.clone(),
+			reqSize:  h.reqSize.clone(),
+			respSize: h.respSize.clone(),
+		}
+	}
+	return metricsSnapshot{
+		requests:   maps.Clone(m.requests),
+		routes:     routes,
+		challenges: maps.Clone(m.challenges),
+		fields:     maps.Clone(m.fields),
+	}
+}
+
+func (h *histogram) clone() *histogram {
+	c := *h
+	c.counts = append([]uint64(nil), h.counts...)
+	c.exemplars = append([]exemplar(nil), h.exemplars...)
+	return &c
+}
+
+func (m *Metrics) writeChallenges(w io.Writer, challenges map[challengeSeries]uint64) {
+	if m.cfg.Exemplars {
+		fmt.Fprintln(w, "# TYPE netpath_captcha_challenges counter")
+	} else {
+		fmt.Fprintln(w, "# TYPE netpath_captcha_challenges_total counter")
+	}
+	keys := make([]challengeSeries, 0, len(challenges))
+	for k := range challenges {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.provider != b.provider {
+			return a.provider < b.provider
+		}
+		return a.result < b.result
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "netpath_captcha_challenges_total{provider=%q,route=%q,result=%q} %d\n", k.provider, k.route, k.result, challenges[k])
+	}
+}
+
+func (m *Metrics) writePayloadFields(w io.Writer, fields map[fieldSeries]uint64) {
+	if m.cfg.Exemplars {
+		fmt.Fprintln(w, "# TYPE netpath_payload_fields counter")
+	} else {
+		fmt.Fprintln(w, "# TYPE netpath_payload_fields_total counter")
+	}
+	keys := make([]fieldSeries, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.kind != b.kind {
+			return a.kind < b.kind
+		}
+		return a.field < b.field
+	})
+	for _, k := range keys {
+		fmt.Fprintf(w, "netpath_payload_fields_total{route=%q,field=%q,kind=%q} %d\n", k.route, k.field, k.kind, fields[k])
+	}
+}
+
+func (m *Metrics) writeHistogram(w io.Writer, name, labels string, h *histogram) {
+	for i, bound := range h.bounds {
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"%g\"} %d", name, labels, bound, h.counts[i])
+		m.writeExemplar(w, h.exemplars[i])
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d", name, labels, h.count)
+	m.writeExemplar(w, h.exemplars[len(h.bounds)])
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "%s_sum{%s} %g\n", name, labels, h.sum)
+	fmt.Fprintf(w, "%s_count{%s} %d\n", name, labels, h.count)
+}
+
+func (m *Metrics) writeExemplar(w io.Writer, ex exemplar) {
+	if !m.cfg.Exemplars || ex.traceID == "" {
+		return
+	}
+	fmt.Fprintf(w, " # {trace_id=%q} %g %.3f", ex.traceID, ex.value, float64(ex.at.UnixMilli())/1000)
+}
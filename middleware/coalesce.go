@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sync"
+
+	path "github.com/godev90/netpath"
+)
+
+type coalescedCall struct {
+	wg     sync.WaitGroup
+	header http.Header
+	status int
+	body   []byte
+	err    error
+	// panicked berarti leader panic; waiter tidak mendapat response untuk dibagikan.
+	panicked bool
+}
+
+var errCoalescedPanic = errors.New("middleware: coalesced request panicked")
+
+// Coalesce menggabungkan request GET identik yang datang bersamaan (URL,
+// dan principal yang sama): handler hanya dijalankan sekali dan hasilnya
+// dibagikan ke semua request yang menunggu. Berguna menahan thundering herd
+// pada endpoint yang mahal. Jika handler panic, request yang menunggu dijawab
+// 500 dan panic diteruskan di request yang menjalankan handler.
+func Coalesce() path.MiddlewareFunc {
+	var (
+		mu    sync.Mutex
+		calls = make(map[string]*coalescedCall)
+	)
+
+	return func(next path.HandlerFunc) path.HandlerFunc {
+		return func(ctx *path.Context) error {
+			r := ctx.Request()
+			if r.Method != http.MethodGet {
+				return next(ctx)
+			}
+
+			key := coalesceKey(ctx)
+
+			mu.Lock()
+			if call, ok := calls[key]; ok {
+				mu.Unlock()
+				call.wg.Wait()
+				if call.panicked {
+					return ctx.ServerError(errCoalescedPanic)
+				}
+				replay(ctx.Writer(), call)
+				return call.err
+			}
+			call := &coalescedCall{}
+			call.wg.Add(1)
+			calls[key] = call
+			mu.Unlock()
+
+			rec := &recorder{header: make(http.Header)}
+			w := ctx.Writer()
+			ctx.SetWriter(rec)
+
+			func() {
+				defer func() {
+					p := recover()
+					call.header, call.status, call.body = rec.header, rec.status, rec.buf.Bytes()
+					call.panicked = p != nil
+					mu.Lock()
+					delete(calls, key)
+					mu.Unlock()
+					call.wg.Done()
+					if p != nil {
+						// Recover di luar Coalesce menulis ke writer asli
+						ctx.SetWriter(w)
+						panic(p)
+					}
+				}()
+				call.err = next(ctx)
+				// error yang belum dirender dirender ke recorder supaya
+				// leader dan semua waiter menerima response error yang sama,
+				// bukan 200 kosong
+				if call.err != nil && rec.status == 0 {
+					ctx.Error(call.err)
+				}
+			}()
+
+			ctx.SetWriter(w)
+			replay(w, call)
+			return call.err
+		}
+	}
+}
+
+// coalesceKey hanya menyamakan request dengan kredensial identik, sehingga
+// response yang dibagikan (termasuk hasil WithMasking) dirender untuk
+// principal yang sama. Cookie ikut dihitung karena session bisa dimuat
+// middleware yang berjalan setelah Coalesce.
+func coalesceKey(ctx *path.Context) string {
+	r := ctx.Request()
+	principal := r.Header.Get("Authorization") + " " + r.Header.Get("Cookie")
+	if sess := ctx.Session(); sess != nil {
+		principal = sess.Identifier()
+	}
+	return r.Host + " " + r.URL.RequestURI() + " " + principal
+}
+
+func replay(w http.ResponseWriter, call *coalescedCall) {
+	dst := w.Header()
+	for k, v := range call.header {
+		dst[k] = append([]string(nil), v...)
+	}
+	status := call.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	w.Write(call.body)
+}
+
+type recorder struct {
+	header http.Header
+	status int
+	buf    bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.buf.Write(p)
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	path "github.com/godev90/netpath"
+)
+
+// coalesced menjalankan satu request leader yang tertahan di handler, lalu
+// waiters request identik, dan melepas leader setelah semuanya menunggu.
+func coalesced(t *testing.T, handler path.HandlerFunc, waiters int) []*httptest.ResponseRecorder {
+	t.Helper()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+
+	app := path.New()
+	app.Use(Recover)
+	app.Route().GET("/report", func(ctx *path.Context) error {
+		once.Do(func() { close(started) })
+		<-release
+		return handler(ctx)
+	}, Coalesce())
+
+	results := make([]*httptest.ResponseRecorder, waiters+1)
+	var wg sync.WaitGroup
+	serve := func(i int) {
+		defer wg.Done()
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", nil))
+		results[i] = w
+	}
+
+	wg.Add(1)
+	go serve(0)
+	<-started
+	for i := 1; i <= waiters; i++ {
+		wg.Add(1)
+		go serve(i)
+	}
+	// beri waktu waiter masuk ke antrean call yang sedang berjalan
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	return results
+}
+
+func TestCoalesceSharesResponse(t *testing.T) {
+	var calls atomic.Int32
+	results := coalesced(t, func(ctx *path.Context) error {
+		calls.Add(1)
+		return ctx.Success("report")
+	}, 3)
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler ran %d times, want 1", n)
+	}
+	for i, w := range results {
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"report"`) {
+			t.Errorf("request %d: %d %s", i, w.Code, w.Body)
+		}
+	}
+}
+
+func TestCoalesceLeaderPanic(t *testing.T) {
+	var calls atomic.Int32
+	results := coalesced(t, func(ctx *path.Context) error {
+		calls.Add(1)
+		ctx.Writer().Header().Set("X-Partial", "1")
+		panic("boom")
+	}, 3)
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("handler ran %d times, want 1", n)
+	}
+	for i, w := range results {
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("request %d: status %d, want 500", i, w.Code)
+		}
+		if w.Header().Get("X-Partial") != "" {
+			t.Errorf("request %d: replayed partial response headers", i)
+		}
+	}
+}
@@ -12,6 +12,11 @@ type CORSConfig struct {
 	AllowMethods     []string
 	AllowHeaders     []string
 	AllowCredentials bool
+
+	// AllowOriginsFunc dibaca setiap request, misalnya app.CORSOrigins supaya
+	// origin bisa diganti lewat WatchConfig. Jika mengembalikan nil,
+	// AllowOrigins yang dipakai.
+	AllowOriginsFunc func() []string
 }
 
 var DefaultCORSConfig = CORSConfig{
@@ -36,7 +41,13 @@ func CORS(config CORSConfig) path.MiddlewareFunc {
 
 			origin := r.Header.Get("Origin")
 			if origin != "" {
-				if contains(config.AllowOrigins, "*") || contains(config.AllowOrigins, origin) {
+				origins := config.AllowOrigins
+				if config.AllowOriginsFunc != nil {
+					if list := config.AllowOriginsFunc(); list != nil {
+						origins = list
+					}
+				}
+				if contains(origins, "*") || contains(origins, origin) {
 					w.Header().Add("Access-Control-Allow-Origin", origin)
 				}
 			}
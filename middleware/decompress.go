@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	path "github.com/godev90/netpath"
+	"github.com/godev90/validator/faults"
+)
+
+var DefaultMaxDecompressedSize int64 = 10 << 20
+
+// Decompress membuka body request dengan Content-Encoding gzip atau deflate
+// sebelum sampai ke handler/Bind. Body hasil dekompresi dibatasi maxSize byte
+// (DefaultMaxDecompressedSize jika maxSize <= 0) untuk mencegah zip bomb;
+// body yang melebihi batas menghasilkan *http.MaxBytesError seperti WithMaxBody
+// sehingga dijawab 413.
+func Decompress(maxSize int64) path.MiddlewareFunc {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDecompressedSize
+	}
+
+	return func(next path.HandlerFunc) path.HandlerFunc {
+		return func(ctx *path.Context) error {
+			r := ctx.Request()
+			encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+			if encoding == "" || encoding == "identity" || r.Body == nil {
+				return next(ctx)
+			}
+
+			var (
+				body io.ReadCloser
+				err  error
+			)
+			switch encoding {
+			case "gzip", "x-gzip":
+				body, err = gzip.NewReader(r.Body)
+			case "deflate":
+				body, err = newDeflateReader(r.Body)
+			default:
+				return ctx.Error(faults.ErrUnsupportedMediaType)
+			}
+			if err != nil {
+				return ctx.BadInput(err)
+			}
+			defer body.Close()
+
+			r.Body = &limitedBody{r: body, limit: maxSize, remaining: maxSize}
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+
+			return next(ctx)
+		}
+	}
+}
+
+// newDeflateReader menerima deflate dengan header zlib (sesuai RFC) maupun
+// raw deflate yang masih dikirim sebagian client.
+func newDeflateReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(2)
+	if err == nil && head[0]&0x0f == 8 && (uint16(head[0])<<8|uint16(head[1]))%31 == 0 {
+		return zlib.NewReader(br)
+	}
+	return flate.NewReader(br), nil
+}
+
+type limitedBody struct {
+	r         io.ReadCloser
+	limit     int64
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// cek apakah masih ada data tersisa di luar batas
+		var one [1]byte
+		if n, _ := b.r.Read(one[:]); n > 0 {
+			return 0, &http.MaxBytesError{Limit: b.limit}
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.r.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.r.Close()
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	path "github.com/godev90/netpath"
+)
+
+func compress(t *testing.T, encoding string, data []byte) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	}
+	w.Write(data)
+	w.Close()
+	return &buf
+}
+
+func TestDecompress(t *testing.T) {
+	app := path.New()
+	app.Route().POST("/echo", func(ctx *path.Context) error {
+		body, err := ctx.BodyBytes()
+		if err != nil {
+			return err
+		}
+		ctx.Writer().Write(body)
+		return nil
+	}, Decompress(64))
+
+	tests := []struct {
+		name     string
+		encoding string
+		header   string
+		body     string
+		want     int
+	}{
+		{"gzip", "gzip", "gzip", "hello", http.StatusOK},
+		{"zlib deflate", "deflate", "deflate", "hello", http.StatusOK},
+		{"raw deflate", "raw-deflate", "deflate", "hello", http.StatusOK},
+		{"exact limit", "gzip", "gzip", strings.Repeat("a", 64), http.StatusOK},
+		{"bomb", "gzip", "gzip", strings.Repeat("a", 1<<20), http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/echo", compress(t, tc.encoding, []byte(tc.body)))
+			r.Header.Set("Content-Encoding", tc.header)
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+
+			if w.Code != tc.want {
+				t.Fatalf("status %d, want %d", w.Code, tc.want)
+			}
+			if tc.want == http.StatusOK && w.Body.String() != tc.body {
+				t.Fatalf("body %q, want %q", w.Body, tc.body)
+			}
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader("x"))
+	r.Header.Set("Content-Encoding", "br")
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+	if w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("unsupported encoding: status %d, want 415", w.Code)
+	}
+}
+
+// Zip bomb yang dibaca middleware lain (di sini CSRF membaca field form)
+// juga harus dijawab 413, bukan 400.
+func TestDecompressBombThroughFormField(t *testing.T) {
+	app := path.New()
+	app.Use(Decompress(64), path.WithCSRF(path.CSRFConfig{AuthCookies: []string{"auth"}}))
+	app.Route().POST("/form", func(ctx *path.Context) error {
+		return ctx.Success(nil)
+	})
+
+	body := "csrf_token=tok&note=" + strings.Repeat("a", 1<<20)
+	r := httptest.NewRequest(http.MethodPost, "/form", compress(t, "gzip", []byte(body)))
+	r.Header.Set("Content-Encoding", "gzip")
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.AddCookie(&http.Cookie{Name: "np_csrf", Value: "tok"})
+	r.AddCookie(&http.Cookie{Name: "auth", Value: "x"})
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, r)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status %d, want 413", w.Code)
+	}
+}
@@ -3,16 +3,57 @@ package middleware
 import (
 	path "github.com/godev90/netpath"
 	"github.com/godev90/validator/faults"
+	"golang.org/x/text/language"
 )
 
+// LocaleWrapper memilih bahasa dari query ?lang= atau header Accept-Language.
+// Hanya bahasa yang punya katalog error (lihat netpath.RegisterErrorMessages)
+// yang dipakai; bahasa berikutnya di Accept-Language menjadi fallback.
 func LocaleWrapper(next path.HandlerFunc) path.HandlerFunc {
 	return func(ctx *path.Context) error {
 		l := ctx.Query("lang")
 
-		if l == string(faults.Bahasa) || l == string(faults.English) {
+		if l != "" && path.LanguageRegistered(faults.LanguageTag(l)) {
 			ctx.UseLocale(faults.LanguageTag(l))
+		} else if tags := acceptedLanguages(ctx.Request().Header.Get("Accept-Language")); len(tags) > 0 {
+			ctx.UseLocale(tags[0], tags[1:]...)
 		}
 
 		return next(ctx)
 	}
 }
+
+func acceptedLanguages(header string) []faults.LanguageTag {
+	if header == "" {
+		return nil
+	}
+	prefs, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	var tags []faults.LanguageTag
+	for _, p := range prefs {
+		for _, candidate := range []string{p.String(), baseOf(p)} {
+			tag := faults.LanguageTag(candidate)
+			if path.LanguageRegistered(tag) && !containsTag(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+func baseOf(t language.Tag) string {
+	base, _ := t.Base()
+	return base.String()
+}
+
+func containsTag(tags []faults.LanguageTag, tag faults.LanguageTag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	path "github.com/godev90/netpath"
+)
+
+type MirrorConfig struct {
+	// Target adalah base URL tujuan, misalnya "http://orders-v2.internal:8080".
+	Target string
+	// Percent adalah porsi request yang di-mirror, 0-100.
+	Percent float64
+	Timeout time.Duration
+	// MaxInFlight membatasi jumlah request mirror yang berjalan bersamaan;
+	// request yang melebihi batas tidak di-mirror.
+	MaxInFlight int
+}
+
+// Mirror mengirim salinan request (header dan body) ke Target secara
+// asynchronous. Response dari Target diabaikan dan tidak mempengaruhi
+// response utama.
+func Mirror(cfg MirrorConfig) path.MiddlewareFunc {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	if cfg.MaxInFlight == 0 {
+		cfg.MaxInFlight = 100
+	}
+
+	target := strings.TrimSuffix(cfg.Target, "/")
+	client := &http.Client{Timeout: cfg.Timeout}
+	slots := make(chan struct{}, cfg.MaxInFlight)
+
+	return func(next path.HandlerFunc) path.HandlerFunc {
+		return func(ctx *path.Context) error {
+			if cfg.Percent <= 0 || rand.Float64()*100 >= cfg.Percent {
+				return next(ctx)
+			}
+
+			select {
+			case slots <- struct{}{}:
+			default:
+				return next(ctx)
+			}
+
+			r := ctx.Request()
+			body, err := ctx.BodyBytes()
+			if err != nil {
+				<-slots
+				return next(ctx)
+			}
+
+			header := r.Header.Clone()
+			header.Set("X-Shadow-Request", "1")
+			url := target + r.URL.RequestURI()
+			method := r.Method
+
+			go func() {
+				defer func() { <-slots }()
+
+				req, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+				if err != nil {
+					log.Printf("mirror: %v", err)
+					return
+				}
+				req.Header = header
+
+				resp, err := client.Do(req)
+				if err != nil {
+					log.Printf("mirror: %s %s: %v", method, url, err)
+					return
+				}
+				resp.Body.Close()
+			}()
+
+			return next(ctx)
+		}
+	}
+}
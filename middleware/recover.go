@@ -13,7 +13,9 @@ func Recover(next path.HandlerFunc) path.HandlerFunc {
 		defer func() {
 			if r := recover(); r != nil {
 				// Log the panic — you can use your own logger here
-				log.Printf("[PANIC RECOVER] %v\n%s", r, debug.Stack())
+				stack := debug.Stack()
+				log.Printf("[PANIC RECOVER] %v\n%s", r, stack)
+				ctx.ReportPanic(r, stack)
 
 				// Optionally: wrap panic as an error if your context expects it
 				err = fmt.Errorf("internal panic recover")
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Module membungkus satu fitur (route, middleware, dan lifecycle) agar bisa
+// dipasang sebagai satu unit lewat App.Register. Embed ModuleBase untuk
+// method yang tidak dipakai.
+type Module interface {
+	Name() string
+	Routes(r *Router)
+	// Middleware hanya berlaku untuk route yang didaftarkan module ini.
+	Middleware() []MiddlewareFunc
+	OnStart(ctx context.Context) error
+	OnStop(ctx context.Context) error
+}
+
+// ModuleBase memberi implementasi kosong untuk Middleware, OnStart, dan OnStop.
+type ModuleBase struct{}
+
+func (ModuleBase) Middleware() []MiddlewareFunc      { return nil }
+func (ModuleBase) OnStart(ctx context.Context) error { return nil }
+func (ModuleBase) OnStop(ctx context.Context) error  { return nil }
+
+// Register memasang route module dan mencatatnya untuk lifecycle Start/Stop.
+// Register panic jika module dengan nama yang sama sudah terdaftar.
+func (app *App) Register(m Module) {
+	for _, existing := range app.modules {
+		if existing.Name() == m.Name() {
+			panic(fmt.Sprintf("netpath: module %q already registered", m.Name()))
+		}
+	}
+
+	m.Routes(app.router.Group("", m.Middleware()...))
+	app.modules = append(app.modules, m)
+}
+
+// Modules mengembalikan module terdaftar sesuai urutan Register.
+func (app *App) Modules() []Module {
+	return append([]Module(nil), app.modules...)
+}
+
+// Start menjalankan OnStart setiap module sesuai urutan Register. Jika satu
+// module gagal, module yang sudah berjalan dihentikan lagi. Run memanggil
+// Start secara otomatis.
+func (app *App) Start(ctx context.Context) error {
+	app.moduleMu.Lock()
+	defer app.moduleMu.Unlock()
+	if app.started > 0 {
+		return nil
+	}
+
+	for i, m := range app.modules {
+		if err := m.OnStart(ctx); err != nil {
+			app.started = i
+			app.stopModules(ctx)
+			return fmt.Errorf("netpath: module %s: %w", m.Name(), err)
+		}
+	}
+	app.started = len(app.modules)
+	return nil
+}
+
+// Stop menjalankan OnStop dengan urutan terbalik untuk module yang sudah
+// di-Start. Shutdown memanggil Stop secara otomatis.
+func (app *App) Stop(ctx context.Context) error {
+	app.moduleMu.Lock()
+	defer app.moduleMu.Unlock()
+	return app.stopModules(ctx)
+}
+
+func (app *App) stopModules(ctx context.Context) error {
+	var errs []error
+	for i := app.started - 1; i >= 0; i-- {
+		m := app.modules[i]
+		if err := m.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("netpath: module %s: %w", m.Name(), err))
+		}
+	}
+	app.started = 0
+	return errors.Join(errs...)
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var mountMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// Mount meneruskan semua request di bawah prefix ke h, dengan segmen prefix
+// yang match dihapus dari URL.Path dan URL.RawPath. Middleware router dan mws tetap dijalankan sebelum h.
+func (r *Router) Mount(prefix string, h http.Handler, mws ...MiddlewareFunc) {
+	prefix = strings.TrimSuffix(r.prefix+prefix, "/")
+	handler := stripPrefixHandler(prefix, h)
+
+	for _, method := range mountMethods {
+		if prefix != "" {
+			r.handle(method, prefix, handler, mws...)
+		}
+		r.handle(method, prefix+"/*", handler, mws...)
+	}
+}
+
+// MountApp memasang sub-App di bawah prefix.
+func (r *Router) MountApp(prefix string, sub *App, mws ...MiddlewareFunc) {
+	r.Mount(prefix, sub, mws...)
+}
+
+// stripPrefixHandler memotong segmen yang match dengan prefix, bukan teks
+// prefix, karena route dicocokkan setelah path dinormalisasi dan bisa
+// case-insensitive. Sisa path diambil dari wildcard route.
+func stripPrefixHandler(prefix string, h http.Handler) HandlerFunc {
+	depth := len(strings.Split(prefix, "/"))
+	return func(ctx *Context) error {
+		req := ctx.Request()
+
+		r2 := req.Clone(req.Context())
+		r2.URL.Path = "/" + ctx.Params[""]
+		r2.URL.RawPath = ""
+		if req.URL.RawPath != "" {
+			// RawPath hanya dipakai jika masih sesuai dengan Path yang baru
+			parts := strings.Split(ctx.app.normalizePath(req.URL.RawPath), "/")
+			if len(parts) >= depth {
+				raw := "/" + strings.Join(parts[depth:], "/")
+				if unescaped, err := url.PathUnescape(raw); err == nil && unescaped == r2.URL.Path {
+					r2.URL.RawPath = raw
+				}
+			}
+		}
+
+		h.ServeHTTP(ctx.Writer(), r2)
+		return nil
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMountStripsMatchedPrefix(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path + " " + r.URL.EscapedPath()))
+	})
+	app := New(WithCleanPath(), WithCollapseSlashes(), WithCaseInsensitive(false))
+	app.Route().Mount("/api/v1", echo)
+
+	tests := []struct {
+		name   string
+		target string
+		want   string
+	}{
+		{"plain", "/api/v1/users", "/users /users"},
+		{"prefix only", "/api/v1", "/ /"},
+		{"trailing slash", "/api/v1/users/", "/users/ /users/"},
+		{"case folded prefix", "/API/V1/users", "/users /users"},
+		{"cleaned prefix", "/api/x/../v1/users", "/users /users"},
+		{"doubled slashes", "//api//v1//users", "/users /users"},
+		{"escaped slash kept", "/api/v1/files/a%2Fb", "/files/a/b /files/a%2Fb"},
+		{"escaped slash with folded prefix", "/Api/V1/files/a%2Fb", "/files/a/b /files/a%2Fb"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.target, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status %d", w.Code)
+			}
+			if got := w.Body.String(); got != tc.want {
+				t.Fatalf("mounted handler saw %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
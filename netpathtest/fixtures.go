@@ -0,0 +1,32 @@
+package netpathtest
+
+import (
+	"context"
+	"testing"
+
+	tools "github.com/godev90/netpath/database"
+)
+
+// LoadFixtures memuat fixture untuk satu test dan mengosongkan lagi semua
+// tabel yang diisinya saat test selesai, termasuk tabel yang diisi file .sql.
+// Cocok untuk table-driven test:
+//
+//	for _, tc := range cases {
+//		t.Run(tc.name, func(t *testing.T) {
+//			netpathtest.LoadFixtures(t, fixtures, tc.fixtures...)
+//			...
+//		})
+//	}
+func LoadFixtures(t testing.TB, f *tools.Fixtures, names ...string) {
+	t.Helper()
+	ctx := context.Background()
+	tables, err := f.Load(ctx, names...)
+	if err != nil {
+		t.Fatalf("netpathtest: load fixtures: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := f.Truncate(ctx, tables...); err != nil {
+			t.Errorf("netpathtest: truncate fixtures: %v", err)
+		}
+	})
+}
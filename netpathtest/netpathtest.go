@@ -0,0 +1,117 @@
+// Package netpathtest menyediakan database dan Redis sekali pakai untuk
+// integration test. Setiap helper menjalankan container lewat CLI docker,
+// mendaftarkannya di pool netpath dengan alias test, dan menghapusnya lagi
+// lewat t.Cleanup:
+//
+//	func TestOrders(t *testing.T) {
+//		alias := netpathtest.StartPostgres(t)
+//		fixtures := &tools.Fixtures{Alias: alias, FS: os.DirFS("testdata")}
+//		netpathtest.LoadFixtures(t, fixtures)
+//		...
+//	}
+//
+// Tanpa docker di PATH, StartRedis memakai backend in-memory
+// (RedisConfig.Memory) dan StartPostgres men-skip test.
+package netpathtest
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Options mengatur container yang dijalankan.
+type Options struct {
+	// Alias di pool, default "test". Test paralel yang masing-masing
+	// menjalankan container perlu alias berbeda.
+	Alias string
+	// Image docker, default "postgres:16-alpine" atau "redis:7-alpine".
+	Image string
+	// StartTimeout membatasi waktu menunggu container siap, default 60 detik.
+	StartTimeout time.Duration
+}
+
+func (o Options) withDefaults(image string) Options {
+	if o.Alias == "" {
+		o.Alias = "test"
+	}
+	if o.Image == "" {
+		o.Image = image
+	}
+	if o.StartTimeout <= 0 {
+		o.StartTimeout = 60 * time.Second
+	}
+	return o
+}
+
+// startContainer menjalankan image dengan port di-publish ke port acak dan
+// mengembalikan host:port yang bisa dipakai dari test.
+func startContainer(t testing.TB, image, port string, env ...string) (host, hostPort string) {
+	t.Helper()
+	if !hasDocker() {
+		t.Skip("netpathtest: docker not available")
+	}
+
+	args := []string{"run", "-d", "--rm", "-p", "127.0.0.1::" + port}
+	for _, e := range env {
+		args = append(args, "-e", e)
+	}
+	args = append(args, image)
+	id, err := docker(args...)
+	if err != nil {
+		t.Fatalf("netpathtest: start %s: %v", image, err)
+	}
+	t.Cleanup(func() {
+		if _, err := docker("rm", "-f", id); err != nil {
+			t.Logf("netpathtest: remove container %s: %v", id, err)
+		}
+	})
+
+	out, err := docker("port", id, port+"/tcp")
+	if err != nil {
+		t.Fatalf("netpathtest: port of %s: %v", image, err)
+	}
+	// satu baris per address, misalnya "127.0.0.1:49153"
+	first, _, _ := strings.Cut(out, "\n")
+	host, hostPort, err = net.SplitHostPort(strings.TrimSpace(first))
+	if err != nil {
+		t.Fatalf("netpathtest: parse port %q: %v", out, err)
+	}
+	return host, hostPort
+}
+
+func hasDocker() bool {
+	_, err := exec.LookPath("docker")
+	return err == nil
+}
+
+func docker(args ...string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("docker", args...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// waitFor mengulang connect sampai berhasil atau timeout habis.
+func waitFor(t testing.TB, what string, timeout time.Duration, connect func() error) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		err := connect()
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("netpathtest: %s not ready after %s: %v", what, timeout, err)
+		}
+		time.Sleep(250 * time.Millisecond)
+	}
+}
@@ -0,0 +1,36 @@
+package netpathtest
+
+import (
+	"testing"
+
+	tools "github.com/godev90/netpath/database"
+)
+
+// StartPostgres menjalankan postgres sekali pakai, mendaftarkannya di
+// tools.Pool() dan mengembalikan aliasnya. Koneksi ditutup dan container
+// dihapus saat test selesai.
+func StartPostgres(t testing.TB, opts ...Options) string {
+	t.Helper()
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt = opt.withDefaults("postgres:16-alpine")
+
+	host, port := startContainer(t, opt.Image, "5432",
+		"POSTGRES_USER=netpath", "POSTGRES_PASSWORD=netpath", "POSTGRES_DB=netpath")
+
+	cfg := tools.DBConfig{
+		Driver:   "postgres",
+		Host:     host,
+		Port:     port,
+		User:     "netpath",
+		Password: "netpath",
+		Name:     "netpath",
+	}
+	waitFor(t, "postgres", opt.StartTimeout, func() error {
+		return tools.Pool().Connect(opt.Alias, cfg)
+	})
+	t.Cleanup(func() { tools.Pool().Disconnect(opt.Alias) })
+	return opt.Alias
+}
@@ -0,0 +1,36 @@
+package netpathtest
+
+import (
+	"net"
+	"testing"
+
+	"github.com/godev90/netpath/cache"
+)
+
+// StartRedis menjalankan Redis sekali pakai, mendaftarkannya di cache.Pool()
+// dan mengembalikan aliasnya. Tanpa docker, alias memakai backend in-memory.
+func StartRedis(t testing.TB, opts ...Options) string {
+	t.Helper()
+	var opt Options
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	opt = opt.withDefaults("redis:7-alpine")
+
+	if !hasDocker() {
+		if err := cache.Pool().Connect(opt.Alias, cache.RedisConfig{Memory: true}); err != nil {
+			t.Fatalf("netpathtest: in-memory redis: %v", err)
+		}
+		t.Cleanup(func() { cache.Pool().Disconnect(opt.Alias) })
+		return opt.Alias
+	}
+
+	host, port := startContainer(t, opt.Image, "6379")
+
+	cfg := cache.RedisConfig{Addr: net.JoinHostPort(host, port)}
+	waitFor(t, "redis", opt.StartTimeout, func() error {
+		return cache.Pool().Connect(opt.Alias, cfg)
+	})
+	t.Cleanup(func() { cache.Pool().Disconnect(opt.Alias) })
+	return opt.Alias
+}
@@ -0,0 +1,334 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/godev90/validator/faults"
+)
+
+// OpenAPI adalah dokumen OpenAPI 3 (format JSON) yang sudah di-resolve
+// untuk validasi request. Hanya bagian yang dibutuhkan validasi yang dibaca:
+// paths, parameters, requestBody, dan components.schemas.
+type OpenAPI struct {
+	// BasePath dibuang dari path request sebelum dicocokkan, misalnya "/api".
+	BasePath string
+
+	operations []*openapiOperation
+}
+
+type openapiDoc struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type openapiParameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type openapiRawOperation struct {
+	Parameters  []openapiParameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool `json:"required"`
+		Content  map[string]struct {
+			Schema *Schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+type openapiOperation struct {
+	method   string
+	segments []string
+
+	params       []openapiParameter
+	bodyRequired bool
+	content      map[string]*Schema // media type -> schema
+}
+
+var openapiMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// LoadOpenAPI membaca dokumen OpenAPI 3 dalam JSON dan me-resolve $ref
+// ke components.schemas.
+func LoadOpenAPI(raw []byte) (*OpenAPI, error) {
+	var doc openapiDoc
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	r := &refResolver{components: doc.Components.Schemas, done: map[*Schema]bool{}}
+	for _, s := range doc.Components.Schemas {
+		if err := r.walk(s); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := &OpenAPI{}
+	for path, item := range doc.Paths {
+		var shared []openapiParameter
+		if rawParams, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(rawParams, &shared); err != nil {
+				return nil, fmt.Errorf("openapi %s parameters: %w", path, err)
+			}
+		}
+
+		for _, method := range openapiMethods {
+			rawOp, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op openapiRawOperation
+			if err := json.Unmarshal(rawOp, &op); err != nil {
+				return nil, fmt.Errorf("openapi %s %s: %w", method, path, err)
+			}
+
+			compiled := &openapiOperation{
+				method:   strings.ToUpper(method),
+				segments: strings.Split(path, "/"),
+				params:   mergeParameters(shared, op.Parameters),
+			}
+			for i := range compiled.params {
+				s, err := r.resolve(compiled.params[i].Schema)
+				if err != nil {
+					return nil, err
+				}
+				compiled.params[i].Schema = s
+			}
+			if op.RequestBody != nil {
+				compiled.bodyRequired = op.RequestBody.Required
+				compiled.content = make(map[string]*Schema, len(op.RequestBody.Content))
+				for mediaType, c := range op.RequestBody.Content {
+					s, err := r.resolve(c.Schema)
+					if err != nil {
+						return nil, err
+					}
+					compiled.content[mediaType] = s
+				}
+			}
+			spec.operations = append(spec.operations, compiled)
+		}
+	}
+
+	// path static seperti /orders/me harus dicoba sebelum /orders/{id}
+	sort.SliceStable(spec.operations, func(i, j int) bool {
+		return templateCount(spec.operations[i].segments) < templateCount(spec.operations[j].segments)
+	})
+	return spec, nil
+}
+
+func templateCount(segments []string) int {
+	n := 0
+	for _, seg := range segments {
+		if strings.HasPrefix(seg, "{") {
+			n++
+		}
+	}
+	return n
+}
+
+// mergeParameters menggabungkan parameter level path dan operation; yang
+// terakhir menimpa yang pertama untuk name dan in yang sama.
+func mergeParameters(shared, own []openapiParameter) []openapiParameter {
+	merged := append([]openapiParameter{}, own...)
+	for _, p := range shared {
+		found := false
+		for _, o := range own {
+			if o.Name == p.Name && o.In == p.In {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+type refResolver struct {
+	components map[string]*Schema
+	done       map[*Schema]bool
+}
+
+func (r *refResolver) resolve(s *Schema) (*Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok {
+			return nil, fmt.Errorf("openapi: unsupported $ref %q", s.Ref)
+		}
+		target := r.components[name]
+		if target == nil {
+			return nil, fmt.Errorf("openapi: unknown $ref %q", s.Ref)
+		}
+		return target, nil
+	}
+	return s, r.walk(s)
+}
+
+// walk me-resolve $ref di dalam s dan meng-compile pattern. Schema yang
+// saling mereferensikan hanya dikunjungi sekali.
+func (r *refResolver) walk(s *Schema) error {
+	if s == nil || r.done[s] {
+		return nil
+	}
+	r.done[s] = true
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("schema pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for name, p := range s.Properties {
+		resolved, err := r.resolve(p)
+		if err != nil {
+			return err
+		}
+		s.Properties[name] = resolved
+	}
+	items, err := r.resolve(s.Items)
+	if err != nil {
+		return err
+	}
+	s.Items = items
+	return nil
+}
+
+func (spec *OpenAPI) find(method, path string) (*openapiOperation, map[string]string) {
+	path = strings.TrimPrefix(path, spec.BasePath)
+	parts := strings.Split(path, "/")
+	for _, op := range spec.operations {
+		if op.method != method || len(op.segments) != len(parts) {
+			continue
+		}
+		params := map[string]string{}
+		matched := true
+		for i, seg := range op.segments {
+			if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+				params[seg[1:len(seg)-1]] = parts[i]
+				continue
+			}
+			if seg != parts[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return op, params
+		}
+	}
+	return nil, nil
+}
+
+// WithOpenAPI memvalidasi parameter path, query, dan header, content type,
+// serta body JSON request terhadap spec sebelum handler dijalankan.
+// Pelanggaran dijawab 400 lewat ctx.BadInput dengan key seperti
+// "query.page" atau "body.items[0].price". Request yang tidak ada di spec
+// diteruskan tanpa validasi.
+func WithOpenAPI(spec *OpenAPI) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			r := ctx.request
+			op, pathParams := spec.find(r.Method, r.URL.Path)
+			if op == nil {
+				return next(ctx)
+			}
+
+			errs := faults.Errors{}
+			query := r.URL.Query()
+			for _, p := range op.params {
+				var (
+					raw     string
+					present bool
+				)
+				switch p.In {
+				case "path":
+					raw, present = pathParams[p.Name]
+				case "query":
+					present = query.Has(p.Name)
+					raw = query.Get(p.Name)
+				case "header":
+					raw = r.Header.Get(p.Name)
+					present = raw != ""
+				default:
+					continue
+				}
+
+				key := p.In + "." + p.Name
+				if !present {
+					if p.Required {
+						errs[key] = faults.ErrRequired
+					}
+					continue
+				}
+				if p.Schema != nil {
+					p.Schema.validate(key, p.Schema.coerce(raw), errs)
+				}
+			}
+
+			if op.content != nil {
+				if err := validateOpenAPIBody(ctx, op, errs); err != nil {
+					return ctx.BadInput(err)
+				}
+			}
+
+			if len(errs) > 0 {
+				return ctx.BadInput(errs)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func validateOpenAPIBody(ctx *Context, op *openapiOperation, errs faults.Errors) error {
+	body, err := ctx.BodyBytes()
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		if op.bodyRequired {
+			errs["body"] = faults.ErrRequired
+		}
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(ctx.request.Header.Get("Content-Type"))
+	schema, ok := op.content[mediaType]
+	if !ok {
+		schema, ok = op.content["*/*"]
+	}
+	if !ok {
+		errs["Content-Type"] = faults.ErrInvalidParameter.Render("Content-Type")
+		return nil
+	}
+	if schema == nil || !isJSONMediaType(mediaType) {
+		return nil
+	}
+
+	var data any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		errs["body"] = faults.ErrTypeMismatch
+		return nil
+	}
+	schema.validate("body", data, errs)
+	return nil
+}
+
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,79 @@
+package app
+
+type Option func(*App)
+
+type TrailingSlashPolicy int
+
+const (
+	// TrailingSlashStrict memperlakukan /users dan /users/ sebagai route yang berbeda.
+	TrailingSlashStrict TrailingSlashPolicy = iota
+	// TrailingSlashRedirect mengarahkan client ke varian yang terdaftar
+	// (301 untuk GET/HEAD, 308 untuk method lain).
+	TrailingSlashRedirect
+	// TrailingSlashRewrite melayani varian yang terdaftar tanpa redirect.
+	TrailingSlashRewrite
+)
+
+func WithTrailingSlash(policy TrailingSlashPolicy) Option {
+	return func(app *App) {
+		app.trailingSlash = policy
+	}
+}
+
+// WithCollapseSlashes menggabungkan slash berulang (//users///1) sebelum matching.
+func WithCollapseSlashes() Option {
+	return func(app *App) {
+		app.collapseSlashes = true
+	}
+}
+
+// WithCleanPath membersihkan segmen . dan .. (serta slash berulang) sebelum matching.
+func WithCleanPath() Option {
+	return func(app *App) {
+		app.cleanPath = true
+	}
+}
+
+// WithCaseInsensitive mencocokkan segmen static tanpa membedakan huruf besar/kecil.
+// Jika redirect bernilai true, request diarahkan ke ejaan yang terdaftar.
+func WithCaseInsensitive(redirect bool) Option {
+	return func(app *App) {
+		app.foldCase = true
+		app.canonicalCase = redirect
+	}
+}
+
+// WithoutAutoOptions mematikan respon OPTIONS otomatis; hanya route OPTIONS yang terdaftar dilayani.
+func WithoutAutoOptions() Option {
+	return func(app *App) {
+		app.noAutoOptions = true
+	}
+}
+
+// WithOptionsHandler mengganti respon default (204 + Allow) untuk OPTIONS otomatis,
+// misalnya untuk menangani preflight CORS secara terpusat. Header Allow sudah di-set
+// dan daftarnya tersedia lewat ctx.AllowedMethods().
+func WithOptionsHandler(h HandlerFunc) Option {
+	return func(app *App) {
+		app.optionsHandler = h
+	}
+}
+
+// WithRouteCache menyimpan hasil matching untuk size kombinasi host+method+path
+// terakhir yang dipakai. Tanpa cache, router memindai route method tersebut
+// satu per satu sesuai urutan prioritas, jadi cache berguna jika sebagian besar
+// trafik hanya menuju segelintir endpoint di tabel route yang besar. Semua
+// route harus sudah terdaftar sebelum server berjalan.
+func WithRouteCache(size int) Option {
+	return func(app *App) {
+		if size > 0 {
+			app.routeCache = newRouteCache(size)
+		}
+	}
+}
+
+func WithAccessLog(cfg AccessLogConfig) Option {
+	return func(app *App) {
+		app.accessLog = newAccessLogger(cfg)
+	}
+}
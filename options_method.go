@@ -0,0 +1,60 @@
+package app
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// allowedMethods mengembalikan method yang terdaftar untuk path, termasuk OPTIONS.
+func (r *Router) allowedMethods(path string, fold bool) []string {
+	var allow []string
+	for method := range r.routes {
+		if method == http.MethodOptions {
+			continue
+		}
+		if e, _ := r.find(method, path, fold); e != nil {
+			allow = append(allow, method)
+		}
+	}
+	if len(allow) == 0 {
+		return nil
+	}
+
+	// HEAD selalu tersedia untuk path yang punya route GET
+	if contains(allow, http.MethodGet) && !contains(allow, http.MethodHead) {
+		allow = append(allow, http.MethodHead)
+	}
+	allow = append(allow, http.MethodOptions)
+	sort.Strings(allow)
+	return allow
+}
+
+// AllowedMethods mengembalikan isi header Allow yang dihitung router untuk request OPTIONS otomatis.
+func (c *Context) AllowedMethods() []string {
+	return c.allowed
+}
+
+func (app *App) autoOptions(allow []string) HandlerFunc {
+	return func(ctx *Context) error {
+		ctx.allowed = allow
+		ctx.writer.Header().Set("Allow", strings.Join(allow, ", "))
+
+		if app.optionsHandler != nil {
+			return app.optionsHandler(ctx)
+		}
+
+		ctx.httpStatus = http.StatusNoContent
+		ctx.writer.WriteHeader(http.StatusNoContent)
+		return nil
+	}
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
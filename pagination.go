@@ -0,0 +1,95 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/godev90/validator/faults"
+)
+
+var (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type Pagination struct {
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+	Cursor string `json:"cursor,omitempty"`
+
+	// NextCursor diisi handler untuk pagination berbasis cursor.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+type PageMeta struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	Pages      int64  `json:"pages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// BindPagination membaca page, limit dan cursor dari query string.
+// Limit dibatasi maxLimit (atau MaxPageLimit jika maxLimit <= 0).
+func (c *Context) BindPagination(maxLimit int) (Pagination, error) {
+	if maxLimit <= 0 {
+		maxLimit = MaxPageLimit
+	}
+
+	q := c.request.URL.Query()
+	p := Pagination{Page: 1, Limit: DefaultPageLimit, Cursor: q.Get("cursor")}
+
+	errs := faults.Errors{}
+	if raw := q.Get("page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil {
+			errs["page"] = faults.ErrInvalidIntegerNumber
+		} else if page > 1 {
+			p.Page = page
+		}
+	}
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			errs["limit"] = faults.ErrInvalidIntegerNumber
+		} else if limit > 0 {
+			p.Limit = limit
+		}
+	}
+	if len(errs) > 0 {
+		return p, errs
+	}
+
+	if p.Limit > maxLimit {
+		p.Limit = maxLimit
+	}
+	return p, nil
+}
+
+// Paginated menulis response sukses dengan metadata pagination.
+func (c *Context) Paginated(items any, total int64, p Pagination) error {
+	c.httpStatus = http.StatusOK
+
+	var pages int64
+	if p.Limit > 0 {
+		pages = (total + int64(p.Limit) - 1) / int64(p.Limit)
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"code": http.StatusOK,
+		"data": items,
+		"meta": PageMeta{
+			Page:       p.Page,
+			Limit:      p.Limit,
+			Total:      total,
+			Pages:      pages,
+			NextCursor: p.NextCursor,
+		},
+	})
+
+	return nil
+}
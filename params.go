@@ -0,0 +1,79 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godev90/validator/faults"
+)
+
+// Accessor di file ini mengembalikan faults.Errors yang di-key dengan nama
+// parameter, sehingga bisa langsung diteruskan ke ctx.BadInput.
+
+// ParamInt membaca parameter path sebagai int.
+func (c *Context) ParamInt(key string) (int, error) {
+	n, err := strconv.Atoi(c.Params[key])
+	if err != nil {
+		return 0, faults.Errors{key: faults.ErrInvalidIntegerNumber}
+	}
+	return n, nil
+}
+
+// QueryInt membaca query key sebagai int, atau def jika kosong.
+func (c *Context) QueryInt(key string, def int) (int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return def, faults.Errors{key: faults.ErrInvalidIntegerNumber}
+	}
+	return n, nil
+}
+
+// QueryBool membaca query key dengan aturan strconv.ParseBool (1, t, true,
+// 0, f, false, ...), atau def jika kosong.
+func (c *Context) QueryBool(key string, def bool) (bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return def, faults.Errors{key: faults.ErrTypeMismatch}
+	}
+	return b, nil
+}
+
+// QueryTime membaca query key dengan layout time.Parse. Nilai kosong
+// menghasilkan time.Time nol tanpa error.
+func (c *Context) QueryTime(key, layout string) (time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(layout, raw)
+	if err != nil {
+		if layout == time.DateOnly {
+			return time.Time{}, faults.Errors{key: faults.ErrInvalidDateFormat}
+		}
+		return time.Time{}, faults.Errors{key: faults.ErrInvalidDatetimeFormat}
+	}
+	return t, nil
+}
+
+// QuerySlice mengumpulkan nilai key yang berulang (?ids=1&ids=2) maupun
+// dipisah koma (?ids=1,2). Nilai kosong dibuang.
+func (c *Context) QuerySlice(key string) []string {
+	var out []string
+	for _, raw := range c.request.URL.Query()[key] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
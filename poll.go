@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrNoData dikembalikan fungsi wait jika tidak ada data tanpa harus
+// menunggu timeout, misalnya channel sudah ditutup.
+var ErrNoData = errors.New("no data")
+
+// Poll menunggu data untuk long-polling. wait harus berhenti ketika context
+// selesai dan mengembalikan data (ditulis dengan Success) atau error.
+// Jika timeout tercapai atau wait mengembalikan ErrNoData, Poll menjawab 304 Not Modified untuk request
+// bersyarat (If-None-Match / If-Modified-Since) atau 204 No Content.
+// Jika client terputus, tidak ada yang ditulis.
+func (c *Context) Poll(timeout time.Duration, wait func(ctx context.Context) (any, error)) error {
+	ctx, cancel := context.WithTimeout(c.request.Context(), timeout)
+	defer cancel()
+
+	data, err := wait(ctx)
+	if err == nil {
+		return c.Success(data)
+	}
+
+	if c.request.Context().Err() != nil {
+		// client sudah pergi
+		return c.request.Context().Err()
+	}
+	if errors.Is(err, ErrNoData) || (errors.Is(err, context.DeadlineExceeded) && ctx.Err() != nil) {
+		code := http.StatusNoContent
+		if c.request.Header.Get("If-None-Match") != "" || c.request.Header.Get("If-Modified-Since") != "" {
+			code = http.StatusNotModified
+		}
+		c.httpStatus = code
+		c.writer.WriteHeader(code)
+		return nil
+	}
+	return err
+}
+
+// WaitChan adalah fungsi wait untuk Poll yang menunggu satu nilai dari ch.
+func WaitChan[T any](ch <-chan T) func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return nil, ErrNoData
+			}
+			return v, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
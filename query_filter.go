@@ -0,0 +1,181 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/godev90/validator/faults"
+)
+
+type FilterOp string
+
+const (
+	OpEq   FilterOp = "eq"
+	OpNe   FilterOp = "ne"
+	OpGt   FilterOp = "gt"
+	OpGte  FilterOp = "gte"
+	OpLt   FilterOp = "lt"
+	OpLte  FilterOp = "lte"
+	OpLike FilterOp = "like"
+	OpIn   FilterOp = "in"
+)
+
+var filterOpSQL = map[FilterOp]string{
+	OpEq:   "=",
+	OpNe:   "<>",
+	OpGt:   ">",
+	OpGte:  ">=",
+	OpLt:   "<",
+	OpLte:  "<=",
+	OpLike: "LIKE",
+}
+
+// FilterRules adalah whitelist field yang boleh dipakai client, dipetakan ke
+// nama kolom. Hanya kolom dari sini yang pernah masuk ke fragment SQL.
+type FilterRules struct {
+	Filters map[string]string
+	Sorts   map[string]string
+}
+
+type Filter struct {
+	Field  string
+	Column string
+	Op     FilterOp
+	Values []string
+}
+
+type SortField struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+type QuerySpec struct {
+	Filters []Filter
+	Sort    []SortField
+}
+
+// BindFilters membaca parameter seperti `?filter[status]=active&filter[price][gte]=10&sort=-created_at,name`.
+func (c *Context) BindFilters(rules FilterRules) (QuerySpec, error) {
+	var spec QuerySpec
+	errs := faults.Errors{}
+
+	query := c.request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		field, op, ok := parseFilterKey(key)
+		if !ok {
+			errs[key] = faults.ErrInvalidParameter
+			continue
+		}
+		column, allowed := rules.Filters[field]
+		if !allowed {
+			errs[key] = faults.ErrNotAcceptable
+			continue
+		}
+		if _, known := filterOpSQL[op]; !known && op != OpIn {
+			errs[key] = faults.ErrInvalidParameter
+			continue
+		}
+
+		values := query[key]
+		if op == OpIn {
+			values = strings.Split(values[0], ",")
+		}
+		spec.Filters = append(spec.Filters, Filter{Field: field, Column: column, Op: op, Values: values})
+	}
+
+	if raw := query.Get("sort"); raw != "" {
+		for _, field := range strings.Split(raw, ",") {
+			field = strings.TrimSpace(field)
+			desc := strings.HasPrefix(field, "-")
+			field = strings.TrimPrefix(strings.TrimPrefix(field, "-"), "+")
+
+			column, allowed := rules.Sorts[field]
+			if !allowed {
+				errs["sort"] = faults.ErrNotAcceptable
+				continue
+			}
+			spec.Sort = append(spec.Sort, SortField{Field: field, Column: column, Desc: desc})
+		}
+	}
+
+	if len(errs) > 0 {
+		return spec, errs
+	}
+	return spec, nil
+}
+
+// parseFilterKey memecah "filter[price][gte]" menjadi ("price", "gte").
+func parseFilterKey(key string) (string, FilterOp, bool) {
+	rest := strings.TrimPrefix(key, "filter[")
+	end := strings.Index(rest, "]")
+	if end <= 0 {
+		return "", "", false
+	}
+	field, rest := rest[:end], rest[end+1:]
+	if rest == "" {
+		return field, OpEq, true
+	}
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return "", "", false
+	}
+	return field, FilterOp(rest[1 : len(rest)-1]), true
+}
+
+// Where membangun fragment WHERE (tanpa kata WHERE) beserta argumennya.
+// Untuk driver "postgres" placeholder dimulai dari $start, selain itu "?".
+func (q QuerySpec) Where(driver string, start int) (string, []any) {
+	var (
+		clauses []string
+		args    []any
+	)
+	n := start
+	placeholder := func() string {
+		if driver == "postgres" {
+			p := fmt.Sprintf("$%d", n)
+			n++
+			return p
+		}
+		return "?"
+	}
+
+	for _, f := range q.Filters {
+		if f.Op == OpIn {
+			marks := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				marks[i] = placeholder()
+				args = append(args, v)
+			}
+			clauses = append(clauses, fmt.Sprintf("%s IN (%s)", f.Column, strings.Join(marks, ", ")))
+			continue
+		}
+		clauses = append(clauses, fmt.Sprintf("%s %s %s", f.Column, filterOpSQL[f.Op], placeholder()))
+		args = append(args, f.Values[0])
+	}
+
+	return strings.Join(clauses, " AND "), args
+}
+
+// OrderBy membangun fragment ORDER BY (tanpa kata ORDER BY).
+func (q QuerySpec) OrderBy() string {
+	parts := make([]string, 0, len(q.Sort))
+	for _, s := range q.Sort {
+		if s.Desc {
+			parts = append(parts, s.Column+" DESC")
+		} else {
+			parts = append(parts, s.Column+" ASC")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
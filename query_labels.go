@@ -0,0 +1,26 @@
+package app
+
+import (
+	tools "github.com/godev90/netpath/database"
+)
+
+// WithQueryLabels memberi label route, request_id (header X-Request-ID),
+// dan traceparent pada context request. Query tools.Exec dan tools.Query ke
+// alias dengan DBConfig.SQLCommenter membawa label ini sebagai komentar
+// sqlcommenter, sehingga query lambat bisa ditelusuri ke endpoint:
+//
+//	app.Use(netpath.WithQueryLabels())
+func WithQueryLabels() MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			labels := map[string]string{
+				"framework":   "netpath",
+				"route":       ctx.route,
+				"request_id":  ctx.RequestID(),
+				"traceparent": ctx.request.Header.Get("traceparent"),
+			}
+			ctx.request = ctx.request.WithContext(tools.WithLabels(ctx.request.Context(), labels))
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+// QuotaLimits adalah kuota satu tier. Nilai 0 berarti tanpa batas.
+type QuotaLimits struct {
+	Daily   int64 `json:"daily"`
+	Monthly int64 `json:"monthly"`
+}
+
+type QuotaConfig struct {
+	Alias  string // alias Redis di cache.Pool()
+	Prefix string // default "quota:"
+
+	// Tier menentukan tier session, misalnya "free" atau "paid".
+	Tier  func(Session) string
+	Tiers map[string]QuotaLimits
+
+	// Location menentukan batas hari dan bulan. Default UTC.
+	Location *time.Location
+}
+
+// Quota membatasi request per identitas session dengan jendela harian dan
+// bulanan yang disimpan di Redis. Request tanpa session tidak dihitung;
+// gabungkan dengan WithRateLimit untuk client anonim.
+type Quota struct {
+	cfg QuotaConfig
+}
+
+func NewQuota(cfg QuotaConfig) *Quota {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "quota:"
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	return &Quota{cfg: cfg}
+}
+
+// QuotaUsage adalah pemakaian satu jendela kuota.
+type QuotaUsage struct {
+	Period    string    `json:"period"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+type quotaWindow struct {
+	period string
+	limit  int64
+	key    string
+	reset  time.Time
+}
+
+func (q *Quota) windows(typ SessionType, identifier string, limits QuotaLimits, now time.Time) []quotaWindow {
+	now = now.In(q.cfg.Location)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, q.cfg.Location)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, q.cfg.Location)
+	id := fmt.Sprintf("%s%d:%s", q.cfg.Prefix, typ, identifier)
+
+	var list []quotaWindow
+	if limits.Daily > 0 {
+		list = append(list, quotaWindow{"daily", limits.Daily, id + ":d:" + day.Format("20060102"), day.AddDate(0, 0, 1)})
+	}
+	if limits.Monthly > 0 {
+		list = append(list, quotaWindow{"monthly", limits.Monthly, id + ":m:" + month.Format("200601"), month.AddDate(0, 1, 0)})
+	}
+	return list
+}
+
+// quotaScript menambah semua counter hanya jika tidak ada yang sudah penuh,
+// sehingga request yang ditolak tidak memakan kuota.
+// KEYS: counter; ARGV: limit per key lalu expire-at (unix) per key.
+// Hasil: {1, count...} jika diizinkan, {0, index, used} jika ditolak
+// (index 1-based).
+var quotaScript = redis.NewScript(`
+local n = #KEYS
+for i = 1, n do
+  local used = tonumber(redis.call('GET', KEYS[i]) or '0')
+  if used >= tonumber(ARGV[i]) then
+    return {0, i, used}
+  end
+end
+local out = {1}
+for i = 1, n do
+  out[i + 1] = redis.call('INCR', KEYS[i])
+  redis.call('EXPIREAT', KEYS[i], ARGV[n + i])
+end
+return out
+`)
+
+// Middleware menghitung request terhadap kuota tier session dan menulis
+// header X-RateLimit-Limit, X-RateLimit-Remaining, dan X-RateLimit-Reset
+// untuk jendela yang paling dekat habis.
+func (q *Quota) Middleware() MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			sess := ctx.Session()
+			if sess == nil || q.cfg.Tier == nil {
+				return next(ctx)
+			}
+			limits, ok := q.cfg.Tiers[q.cfg.Tier(sess)]
+			if !ok {
+				return next(ctx)
+			}
+			wins := q.windows(sess.Type(), sess.Identifier(), limits, time.Now())
+			if len(wins) == 0 {
+				return next(ctx)
+			}
+
+			client, err := cache.Pool().Get(q.cfg.Alias)
+			if err != nil {
+				return ctx.ServerError(err)
+			}
+			keys := make([]string, len(wins))
+			args := make([]any, 0, 2*len(wins))
+			for i, w := range wins {
+				keys[i] = w.key
+				args = append(args, w.limit)
+			}
+			for _, w := range wins {
+				// simpan sedikit lebih lama dari jendela untuk dashboard
+				args = append(args, w.reset.Add(time.Hour).Unix())
+			}
+
+			res, err := quotaScript.Run(ctx.request.Context(), client, keys, args...).Int64Slice()
+			if err != nil {
+				return ctx.ServerError(err)
+			}
+
+			h := ctx.writer.Header()
+			if res[0] == 0 {
+				w := wins[res[1]-1]
+				setQuotaHeaders(h.Set, w.limit, 0, w.reset)
+				h.Set("Retry-After", strconv.Itoa(int(time.Until(w.reset).Seconds()+0.5)))
+				return ctx.TooManyRequest(faults.ErrTooManyRequests)
+			}
+
+			tightest := 0
+			for i, w := range wins {
+				if w.limit-res[i+1] < wins[tightest].limit-res[tightest+1] {
+					tightest = i
+				}
+			}
+			w := wins[tightest]
+			setQuotaHeaders(h.Set, w.limit, w.limit-res[tightest+1], w.reset)
+			return next(ctx)
+		}
+	}
+}
+
+func setQuotaHeaders(set func(key, value string), limit, remaining int64, reset time.Time) {
+	set("X-RateLimit-Limit", strconv.FormatInt(limit, 10))
+	set("X-RateLimit-Remaining", strconv.FormatInt(max(remaining, 0), 10))
+	set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+}
+
+// Usage mengembalikan pemakaian kuota identitas untuk tier, misalnya untuk
+// dashboard pelanggan atau admin.
+func (q *Quota) Usage(ctx context.Context, typ SessionType, identifier, tier string) ([]QuotaUsage, error) {
+	limits, ok := q.cfg.Tiers[tier]
+	if !ok {
+		return nil, fmt.Errorf("netpath: unknown quota tier %q", tier)
+	}
+	wins := q.windows(typ, identifier, limits, time.Now())
+	if len(wins) == 0 {
+		return nil, nil
+	}
+
+	client, err := cache.Pool().Get(q.cfg.Alias)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(wins))
+	for i, w := range wins {
+		keys[i] = w.key
+	}
+	values, err := client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]QuotaUsage, len(wins))
+	for i, w := range wins {
+		var used int64
+		if s, ok := values[i].(string); ok {
+			used, _ = strconv.ParseInt(s, 10, 64)
+		}
+		usage[i] = QuotaUsage{
+			Period:    w.period,
+			Limit:     w.limit,
+			Used:      used,
+			Remaining: max(w.limit-used, 0),
+			ResetsAt:  w.reset,
+		}
+	}
+	return usage, nil
+}
+
+// SessionUsage adalah Usage untuk session yang sedang login.
+func (q *Quota) SessionUsage(ctx *Context) ([]QuotaUsage, error) {
+	sess := ctx.Session()
+	if sess == nil || q.cfg.Tier == nil {
+		return nil, faults.ErrUnauthorized
+	}
+	return q.Usage(ctx.request.Context(), sess.Type(), sess.Identifier(), q.cfg.Tier(sess))
+}
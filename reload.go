@@ -0,0 +1,260 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/netpath/events"
+	logging "github.com/godev90/netpath/helpers/logging"
+)
+
+// TopicConfigReloaded dipublikasikan ke events.Bus() setiap konfigurasi
+// berubah, dengan payload ConfigReload.
+const TopicConfigReloaded = "config.reloaded"
+
+// ConfigSource adalah asal konfigurasi JSON yang dipantau WatchConfig.
+type ConfigSource interface {
+	Load(ctx context.Context) ([]byte, error)
+}
+
+// FileSource membaca konfigurasi dari file.
+type FileSource string
+
+func (f FileSource) Load(ctx context.Context) ([]byte, error) {
+	return os.ReadFile(string(f))
+}
+
+// RedisSource membaca konfigurasi dari satu key Redis.
+type RedisSource struct {
+	Alias string
+	Key   string
+}
+
+func (s RedisSource) Load(ctx context.Context) ([]byte, error) {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return nil, err
+	}
+	return client.Get(ctx, s.Key).Bytes()
+}
+
+// RuntimeSettings adalah bagian konfigurasi yang aman diganti tanpa restart.
+// Key lain di dokumen konfigurasi (alamat listen, DSN, dsb) dilaporkan di
+// ConfigReload.Skipped dan tidak diterapkan.
+type RuntimeSettings struct {
+	LogLevel    *string                     `json:"log_level"`
+	Maintenance *bool                       `json:"maintenance"`
+	RateLimits  map[string]RateLimitSetting `json:"rate_limits"`
+	CORSOrigins []string                    `json:"cors_origins"`
+	Features    map[string]bool             `json:"features"`
+}
+
+// RateLimitSetting mengganti limiter WithNamedRateLimit. Per memakai format
+// time.ParseDuration, misalnya "1m".
+type RateLimitSetting struct {
+	Limit int    `json:"limit"`
+	Per   string `json:"per"`
+}
+
+// ConfigReload adalah hasil satu kali reload.
+type ConfigReload struct {
+	Applied []string          `json:"applied"`
+	Skipped []string          `json:"skipped,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+	At      time.Time         `json:"at"`
+}
+
+var reloadableKeys = map[string]bool{
+	"log_level":    true,
+	"maintenance":  true,
+	"rate_limits":  true,
+	"cors_origins": true,
+	"features":     true,
+}
+
+// runtimeState menyimpan nilai yang diganti reload; dibaca tanpa lock.
+type runtimeState struct {
+	corsOrigins atomic.Pointer[[]string]
+	features    atomic.Pointer[map[string]bool]
+}
+
+// Feature melaporkan apakah feature flag name aktif di konfigurasi terakhir.
+func (app *App) Feature(name string) bool {
+	if m := app.runtime.features.Load(); m != nil {
+		return (*m)[name]
+	}
+	return false
+}
+
+// Feature adalah app.Feature untuk handler.
+func (c *Context) Feature(name string) bool {
+	return c.app != nil && c.app.Feature(name)
+}
+
+// CORSOrigins mengembalikan origin dari konfigurasi terakhir, atau nil jika
+// belum pernah diisi. Pasang sebagai middleware.CORSConfig.AllowOriginsFunc.
+func (app *App) CORSOrigins() []string {
+	if list := app.runtime.corsOrigins.Load(); list != nil {
+		return *list
+	}
+	return nil
+}
+
+// ReloadConfig membaca src sekali dan menerapkan RuntimeSettings. Error
+// satu setting tidak menghalangi setting lain.
+func (app *App) ReloadConfig(ctx context.Context, src ConfigSource) (ConfigReload, error) {
+	raw, err := src.Load(ctx)
+	if err != nil {
+		return ConfigReload{}, err
+	}
+	return app.applyConfig(raw)
+}
+
+func (app *App) applyConfig(raw []byte) (ConfigReload, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return ConfigReload{}, fmt.Errorf("netpath: parse config: %w", err)
+	}
+	var s RuntimeSettings
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ConfigReload{}, fmt.Errorf("netpath: parse config: %w", err)
+	}
+
+	res := ConfigReload{Errors: map[string]string{}, At: time.Now()}
+	for key := range doc {
+		if !reloadableKeys[key] {
+			res.Skipped = append(res.Skipped, key)
+		}
+	}
+	sort.Strings(res.Skipped)
+
+	if s.LogLevel != nil {
+		if level, err := logging.ParseLevel(*s.LogLevel); err != nil {
+			res.Errors["log_level"] = err.Error()
+		} else {
+			logging.SetLevel(level)
+			res.Applied = append(res.Applied, "log_level")
+		}
+	}
+	if s.Maintenance != nil {
+		app.SetMaintenance(*s.Maintenance)
+		res.Applied = append(res.Applied, "maintenance")
+	}
+	if s.RateLimits != nil {
+		for name, setting := range s.RateLimits {
+			if err := setRateLimit(name, setting); err != nil {
+				res.Errors["rate_limits."+name] = err.Error()
+			}
+		}
+		res.Applied = append(res.Applied, "rate_limits")
+	}
+	if s.CORSOrigins != nil {
+		origins := append([]string(nil), s.CORSOrigins...)
+		app.runtime.corsOrigins.Store(&origins)
+		res.Applied = append(res.Applied, "cors_origins")
+	}
+	if s.Features != nil {
+		features := make(map[string]bool, len(s.Features))
+		for k, v := range s.Features {
+			features[k] = v
+		}
+		app.runtime.features.Store(&features)
+		res.Applied = append(res.Applied, "features")
+	}
+
+	for _, key := range res.Skipped {
+		logging.Warnf("config %q is not reloadable, skipped", key)
+	}
+	if len(res.Errors) == 0 {
+		res.Errors = nil
+	}
+	events.Bus().Publish(TopicConfigReloaded, res)
+	return res, nil
+}
+
+// setRateLimit mengganti semua limiter WithNamedRateLimit bernama name.
+// Bucket yang sedang berjalan tetap dipakai dengan limit baru.
+func setRateLimit(name string, setting RateLimitSetting) error {
+	per, err := time.ParseDuration(setting.Per)
+	if err != nil || per <= 0 || setting.Limit <= 0 {
+		return fmt.Errorf("invalid limit %d per %q", setting.Limit, setting.Per)
+	}
+
+	limiters.mu.Lock()
+	list := append([]*windowLimiter(nil), limiters.list...)
+	limiters.mu.Unlock()
+
+	found := false
+	for _, l := range list {
+		if l.name != name {
+			continue
+		}
+		l.mu.Lock()
+		l.limit, l.per = setting.Limit, per
+		l.mu.Unlock()
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("unknown rate limiter %q", name)
+	}
+	return nil
+}
+
+// WatchConfig menerapkan konfigurasi dari src lalu memeriksanya lagi setiap
+// interval (default 5 detik) sampai ctx selesai. Reload hanya terjadi jika
+// isinya berubah. Error pemuatan pertama dikembalikan; error berikutnya
+// hanya dicatat di log.
+func (app *App) WatchConfig(ctx context.Context, src ConfigSource, interval time.Duration) error {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	last, err := src.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := app.applyConfig(last); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		// error yang sama tidak dicatat ulang setiap interval
+		var lastErr string
+		report := func(err error) {
+			if err.Error() != lastErr {
+				lastErr = err.Error()
+				logging.Errorf("config reload: %v", err)
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			raw, err := src.Load(ctx)
+			if err != nil {
+				report(err)
+				continue
+			}
+			if bytes.Equal(raw, last) {
+				continue
+			}
+			if _, err := app.applyConfig(raw); err != nil {
+				report(err)
+				continue
+			}
+			last, lastErr = raw, ""
+		}
+	}()
+	return nil
+}
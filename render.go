@@ -0,0 +1,32 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+)
+
+var errNoTemplates = errors.New("netpath: no templates configured, use WithTemplates")
+
+// WithTemplates memasang template HTML yang dipakai ctx.Render, misalnya
+// template.Must(template.ParseFS(views, "views/*.html")).
+func WithTemplates(t *template.Template) Option {
+	return func(app *App) {
+		app.templates = t
+	}
+}
+
+// Render mengeksekusi template name dengan data dan menulisnya sebagai
+// text/html. Template dieksekusi ke buffer lebih dulu, sehingga error
+// template tidak menghasilkan response setengah jadi.
+func (c *Context) Render(code int, name string, data any) error {
+	if c.app == nil || c.app.templates == nil {
+		return c.ServerError(errNoTemplates)
+	}
+
+	var buf bytes.Buffer
+	if err := c.app.templates.ExecuteTemplate(&buf, name, data); err != nil {
+		return c.ServerError(err)
+	}
+	return c.Blob(code, "text/html; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+type ErrorHook func(ctx *Context, err error)
+
+type PanicHook func(ctx *Context, recovered any, stack []byte)
+
+// Reporter adalah integrasi error reporting (Sentry, Rollbar, dsb).
+type Reporter interface {
+	ReportError(ctx *Context, err error)
+	ReportPanic(ctx *Context, recovered any, stack []byte)
+}
+
+type Breadcrumb struct {
+	Time     time.Time
+	Category string
+	Message  string
+}
+
+// OnError dipanggil setiap kali handler mengembalikan error, setelah response ditulis.
+func (app *App) OnError(h ErrorHook) {
+	app.errorHooks = append(app.errorHooks, h)
+}
+
+// OnPanic dipanggil ketika handler panic. App tetap meneruskan panic
+// setelah hook dijalankan, kecuali panic sudah ditangani middleware.Recover.
+func (app *App) OnPanic(h PanicHook) {
+	app.panicHooks = append(app.panicHooks, h)
+}
+
+// UseReporter mendaftarkan r untuk error maupun panic.
+func (app *App) UseReporter(r Reporter) {
+	app.OnError(r.ReportError)
+	app.OnPanic(r.ReportPanic)
+}
+
+// AddBreadcrumb mencatat jejak kejadian selama request, ikut dikirim ke reporter.
+func (c *Context) AddBreadcrumb(category, message string) {
+	c.breadcrumbs = append(c.breadcrumbs, Breadcrumb{Time: time.Now(), Category: category, Message: message})
+}
+
+func (c *Context) Breadcrumbs() []Breadcrumb {
+	return c.breadcrumbs
+}
+
+// RequestID mengembalikan nilai header X-Request-ID dari request.
+func (c *Context) RequestID() string {
+	return c.request.Header.Get("X-Request-ID")
+}
+
+// ReportPanic meneruskan panic yang sudah di-recover ke hook OnPanic milik App.
+func (c *Context) ReportPanic(recovered any, stack []byte) {
+	if c.app == nil {
+		return
+	}
+	c.panicReported = true
+	for _, h := range c.app.panicHooks {
+		h(c, recovered, stack)
+	}
+}
+
+func (app *App) reportError(ctx *Context, err error) {
+	for _, h := range app.errorHooks {
+		h(ctx, err)
+	}
+}
+
+func (app *App) reportPanic(ctx *Context) {
+	if len(app.panicHooks) == 0 {
+		return
+	}
+	if p := recover(); p != nil {
+		if !ctx.panicReported {
+			ctx.ReportPanic(p, debug.Stack())
+		}
+		panic(p)
+	}
+}
@@ -0,0 +1,281 @@
+package reporter
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	path "github.com/godev90/netpath"
+)
+
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	Release     string
+	// MinStatus adalah status minimum error yang dilaporkan; default 500.
+	MinStatus int
+	Timeout   time.Duration
+}
+
+// Sentry mengirim error dan panic ke Sentry lewat endpoint store API,
+// tanpa bergantung pada SDK resmi.
+type Sentry struct {
+	cfg      SentryConfig
+	endpoint string
+	auth     string
+	client   *http.Client
+}
+
+func NewSentry(cfg SentryConfig) (*Sentry, error) {
+	u, err := url.Parse(cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sentry DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, errors.New("invalid sentry DSN: missing public key")
+	}
+
+	project := strings.Trim(u.Path, "/")
+	if project == "" {
+		return nil, errors.New("invalid sentry DSN: missing project id")
+	}
+
+	if cfg.MinStatus == 0 {
+		cfg.MinStatus = http.StatusInternalServerError
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	return &Sentry{
+		cfg:      cfg,
+		endpoint: fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, project),
+		auth: fmt.Sprintf("Sentry sentry_version=7, sentry_client=netpath/1.0, sentry_key=%s",
+			u.User.Username()),
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// ReportError melaporkan error dengan stack dari WithStack (atau error lain
+// yang punya method Callers() []uintptr). Error tanpa stack dikirim tanpa
+// stacktrace, karena stack saat ReportError dipanggil hanya berisi hook App.
+func (s *Sentry) ReportError(ctx *path.Context, err error) {
+	if status := ctx.Status(); status != 0 && status < s.cfg.MinStatus {
+		return
+	}
+	var frames []sentryFrame
+	var st stackTracer
+	if errors.As(err, &st) {
+		frames = pcFrames(st.Callers())
+	}
+	s.send(s.event(ctx, "error", fmt.Sprintf("%T", err), err.Error(), frames, nil))
+}
+
+// ReportPanic melaporkan panic dengan frame dari stack yang diambil saat
+// recover, dimulai dari lokasi panic.
+func (s *Sentry) ReportPanic(ctx *path.Context, recovered any, stack []byte) {
+	s.send(s.event(ctx, "fatal", "panic", fmt.Sprint(recovered), parseStack(stack), stack))
+}
+
+type stackTracer interface {
+	Callers() []uintptr
+}
+
+type stackError struct {
+	err error
+	pcs []uintptr
+}
+
+// WithStack membungkus err dengan stack pemanggilnya supaya ReportError bisa
+// menunjukkan lokasi error, bukan lokasi reporter:
+//
+//	if err != nil {
+//		return reporter.WithStack(err)
+//	}
+//
+// Error yang sudah membawa stack dikembalikan apa adanya.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	var st stackTracer
+	if errors.As(err, &st) {
+		return err
+	}
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	return &stackError{err: err, pcs: pcs[:n]}
+}
+
+func (e *stackError) Error() string      { return e.err.Error() }
+func (e *stackError) Unwrap() error      { return e.err }
+func (e *stackError) Callers() []uintptr { return e.pcs }
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+func (s *Sentry) event(ctx *path.Context, level, typ, value string, frames []sentryFrame, stack []byte) map[string]any {
+	r := ctx.Request()
+
+	exception := map[string]any{"type": typ, "value": value}
+	if len(frames) > 0 {
+		exception["stacktrace"] = map[string]any{"frames": frames}
+	}
+
+	event := map[string]any{
+		"event_id":    eventID(),
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+		"platform":    "go",
+		"level":       level,
+		"logger":      "netpath",
+		"transaction": r.Method + " " + ctx.RoutePattern(),
+		"environment": s.cfg.Environment,
+		"release":     s.cfg.Release,
+		"exception": map[string]any{
+			"values": []map[string]any{exception},
+		},
+		"request": map[string]any{
+			"url":          requestURL(r),
+			"method":       r.Method,
+			"query_string": r.URL.RawQuery,
+		},
+		"tags": map[string]string{
+			"route":      ctx.RoutePattern(),
+			"request_id": ctx.RequestID(),
+		},
+	}
+
+	if sess := ctx.Session(); sess != nil {
+		event["user"] = map[string]any{"id": sess.Identifier()}
+	}
+
+	if crumbs := ctx.Breadcrumbs(); len(crumbs) > 0 {
+		values := make([]map[string]any, 0, len(crumbs))
+		for _, b := range crumbs {
+			values = append(values, map[string]any{
+				"timestamp": b.Time.UTC().Format(time.RFC3339Nano),
+				"category":  b.Category,
+				"message":   b.Message,
+			})
+		}
+		event["breadcrumbs"] = map[string]any{"values": values}
+	}
+
+	if len(stack) > 0 {
+		event["extra"] = map[string]any{"stack": string(stack)}
+	}
+
+	return event
+}
+
+func (s *Sentry) send(event map[string]any) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("sentry: failed to encode event: %v", err)
+		return
+	}
+
+	go func() {
+		req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+		if err != nil {
+			log.Printf("sentry: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Sentry-Auth", s.auth)
+
+		resp, err := s.client.Do(req)
+		if err != nil {
+			log.Printf("sentry: failed to send event: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			log.Printf("sentry: event rejected with status %d", resp.StatusCode)
+		}
+	}()
+}
+
+// pcFrames mengubah program counter menjadi frame dalam urutan yang
+// diharapkan Sentry (terluar dulu).
+func pcFrames(pcs []uintptr) []sentryFrame {
+	frames := runtime.CallersFrames(pcs)
+	var out []sentryFrame
+	for {
+		f, more := frames.Next()
+		out = append(out, newFrame(f.Function, f.File, f.Line))
+		if !more {
+			break
+		}
+	}
+	slices.Reverse(out)
+	return out
+}
+
+// parseStack membaca output debug.Stack. Frame sebelum panic (recover,
+// debug.Stack, dan runtime.gopanic) dibuang sehingga frame terdalam adalah
+// lokasi panic.
+func parseStack(stack []byte) []sentryFrame {
+	lines := strings.Split(string(stack), "\n")
+	var out []sentryFrame
+	for i := 1; i+1 < len(lines); i += 2 {
+		fn := lines[i]
+		if j := strings.LastIndexByte(fn, '('); j > 0 {
+			fn = fn[:j]
+		}
+		loc := strings.TrimSpace(lines[i+1])
+		if j := strings.LastIndex(loc, " +0x"); j >= 0 {
+			loc = loc[:j]
+		}
+		file, line := loc, 0
+		if j := strings.LastIndexByte(loc, ':'); j >= 0 {
+			file = loc[:j]
+			line, _ = strconv.Atoi(loc[j+1:])
+		}
+		if fn == "panic" {
+			out = out[:0]
+			continue
+		}
+		out = append(out, newFrame(fn, file, line))
+	}
+	slices.Reverse(out)
+	return out
+}
+
+func newFrame(fn, file string, line int) sentryFrame {
+	return sentryFrame{
+		Function: fn,
+		Filename: file,
+		Lineno:   line,
+		InApp:    !strings.HasPrefix(fn, "runtime.") && !strings.HasPrefix(fn, "net/http."),
+	}
+}
+
+func eventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/godev90/validator/faults"
+)
+
+// jsonpCallback membatasi nama callback JSONP ke identifier JavaScript
+// (boleh bertitik) supaya tidak bisa dipakai menyisipkan script.
+var jsonpCallback = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// JSONP menulis data sebagai pemanggilan callback(data). Nama callback yang
+// tidak valid ditolak dengan BadInput.
+func (c *Context) JSONP(code int, callback string, data any) error {
+	if !jsonpCallback.MatchString(callback) {
+		return c.BadInput(faults.Errors{"callback": faults.ErrInvalidParameter.Render("callback")})
+	}
+
+	raw, err := json.Marshal(c.masked(data))
+	if err != nil {
+		return err
+	}
+
+	c.writer.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+	c.writer.Header().Set("X-Content-Type-Options", "nosniff")
+	c.httpStatus = code
+	c.writer.WriteHeader(code)
+	_, err = fmt.Fprintf(c.writer, "/**/ typeof %s === 'function' && %s(%s);", callback, callback, raw)
+	return err
+}
+
+// String menulis teks biasa hasil fmt.Sprintf(format, args...).
+func (c *Context) String(code int, format string, args ...any) error {
+	c.writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	c.httpStatus = code
+	c.writer.WriteHeader(code)
+
+	if len(args) == 0 {
+		_, err := c.writer.Write([]byte(format))
+		return err
+	}
+	_, err := fmt.Fprintf(c.writer, format, args...)
+	return err
+}
+
+// Blob menulis bytes apa adanya dengan content type yang diberikan. Content
+// type kosong dideteksi dari isi data. Bytes tidak bisa disaring WithMasking
+// karena tag mask sudah hilang; kirim data ber-mask lewat JSON.
+func (c *Context) Blob(code int, contentType string, data []byte) error {
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	c.writer.Header().Set("Content-Type", contentType)
+	c.httpStatus = code
+	c.writer.WriteHeader(code)
+	_, err := c.writer.Write(data)
+	return err
+}
+
+// Redirect mengarahkan client ke url dengan code 3xx, misalnya
+// http.StatusSeeOther setelah form POST berhasil.
+func (c *Context) Redirect(code int, url string) error {
+	if code < 300 || code > 399 {
+		code = http.StatusFound
+	}
+	c.httpStatus = code
+	http.Redirect(c.writer, c.request, url, code)
+	return nil
+}
@@ -0,0 +1,64 @@
+package app
+
+import "net/http"
+
+// responseWriter mencatat status dan jumlah byte yang ditulis, termasuk
+// tulisan langsung ke ctx.Writer() yang tidak lewat helper Context.
+type responseWriter struct {
+	http.ResponseWriter
+	status  int
+	size    int64
+	written bool
+	before  []func(status int, header http.Header)
+}
+
+// commit menandai response sudah dimulai dan menjalankan callback BeforeWrite.
+func (w *responseWriter) commit(code int) {
+	w.status = code
+	w.written = true
+	for _, fn := range w.before {
+		fn(code, w.Header())
+	}
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	// 1xx informational (103 Early Hints, 100 Continue) bukan response final
+	if code >= 100 && code < 200 && code != http.StatusSwitchingProtocols {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if !w.written {
+		w.commit(code)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.commit(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.written {
+			w.commit(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Written melaporkan apakah response (header atau body) sudah mulai dikirim.
+func (c *Context) Written() bool {
+	if c.httpStatus != 0 {
+		return true
+	}
+	return c.rw != nil && c.rw.written
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"container/list"
+	"sync"
+)
+
+// routeCacheKey berisi input yang sama dengan Router.find: router hasil
+// resolusi host (port dibuang, huruf kecil), method, dan path yang sudah
+// dinormalisasi. Path tidak dilipat walau WithCaseInsensitive aktif, karena
+// nilai param diambil dari path request apa adanya.
+type routeCacheKey struct {
+	router *Router
+	method string
+	path   string
+}
+
+type routeCacheItem struct {
+	key    routeCacheKey
+	entry  *routeEntry
+	params map[string]string
+}
+
+// routeCache adalah LRU sederhana untuk hasil matching route. Hanya hasil yang
+// match yang disimpan, sehingga path acak (scanner, 404) tidak mengusir path populer.
+type routeCache struct {
+	mu    sync.Mutex
+	size  int
+	items map[routeCacheKey]*list.Element
+	order *list.List
+}
+
+func newRouteCache(size int) *routeCache {
+	return &routeCache{
+		size:  size,
+		items: make(map[routeCacheKey]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+func (c *routeCache) get(key routeCacheKey) (*routeEntry, map[string]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, nil, false
+	}
+	c.order.MoveToFront(el)
+	item := el.Value.(*routeCacheItem)
+	return item.entry, copyParams(item.params), true
+}
+
+func (c *routeCache) put(key routeCacheKey, entry *routeEntry, params map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&routeCacheItem{key: key, entry: entry, params: copyParams(params)})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*routeCacheItem).key)
+	}
+}
+
+func copyParams(params map[string]string) map[string]string {
+	cp := make(map[string]string, len(params))
+	for k, v := range params {
+		cp[k] = v
+	}
+	return cp
+}
+
+// find mencari route lewat cache (jika aktif) sebelum memindai route secara
+// berurutan.
+func (app *App) find(router *Router, method, path string) (*routeEntry, map[string]string) {
+	if app.routeCache == nil {
+		return router.find(method, path, app.foldCase)
+	}
+
+	key := routeCacheKey{router: router, method: method, path: path}
+	if entry, params, ok := app.routeCache.get(key); ok {
+		return entry, params
+	}
+
+	entry, params := router.find(method, path, app.foldCase)
+	if entry != nil {
+		app.routeCache.put(key, entry, params)
+	}
+	return entry, params
+}
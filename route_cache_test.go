@@ -0,0 +1,83 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// BenchmarkRouteMatch membandingkan matching lewat tabel route dengan
+// WithRouteCache untuk path populer yang terdaftar paling akhir.
+func BenchmarkRouteMatch(b *testing.B) {
+	handler := func(ctx *Context) error { return nil }
+	build := func(opts ...Option) *App {
+		app := New(opts...)
+		for i := 0; i < 200; i++ {
+			app.Route().GET(fmt.Sprintf("/resource%d/:id/items/:item", i), handler)
+		}
+		return app
+	}
+	hot := "/resource199/42/items/7"
+
+	for _, bc := range []struct {
+		name string
+		app  *App
+	}{
+		{"table", build()},
+		{"cache", build(WithRouteCache(64))},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			router := bc.app.Route()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				entry, params := bc.app.find(router, "GET", hot)
+				if entry == nil || entry.pattern != "/resource199/:id/items/:item" || params["item"] != "7" {
+					b.Fatalf("matched %v %v", entry, params)
+				}
+			}
+		})
+	}
+}
+
+// Hasil cache harus sama dengan matching tanpa cache untuk setiap variasi
+// host, huruf, dan bentuk path yang dinormalisasi.
+func TestRouteCacheMatchesUncached(t *testing.T) {
+	build := func(opts ...Option) *App {
+		app := New(append(opts, WithCaseInsensitive(false), WithCleanPath())...)
+		show := func(name string) HandlerFunc {
+			return func(ctx *Context) error {
+				ctx.Writer().Write([]byte(name + " " + ctx.Param("id")))
+				return nil
+			}
+		}
+		app.Route().GET("/users/:id", show("main"))
+		app.Host("api.example.com").GET("/users/:id", show("api"))
+		app.Host("*.tenant.example.com").GET("/users/:id", show("tenant"))
+		return app
+	}
+	plain, cached := build(), build(WithRouteCache(8))
+
+	// urutan penting: entry cache dari request sebelumnya tidak boleh bocor
+	requests := []struct{ host, target, want string }{
+		{"example.com", "/users/ABC", "main ABC"},
+		{"example.com", "/USERS/abc", "main abc"},
+		{"example.com", "/users/abc", "main abc"},
+		{"api.example.com", "/users/abc", "api abc"},
+		{"API.Example.com:8443", "/Users/abc", "api abc"},
+		{"a.tenant.example.com", "/users/abc", "tenant abc"},
+		{"b.tenant.example.com", "/users/./x/../abc", "tenant abc"},
+		{"example.com", "/users/abc", "main abc"},
+	}
+	for _, req := range requests {
+		for name, app := range map[string]*App{"plain": plain, "cached": cached} {
+			r := httptest.NewRequest(http.MethodGet, req.target, nil)
+			r.Host = req.host
+			w := httptest.NewRecorder()
+			app.ServeHTTP(w, r)
+			if got := w.Body.String(); got != req.want {
+				t.Errorf("%s %s%s: got %q, want %q", name, req.host, req.target, got, req.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/godev90/validator/faults"
+)
+
+// RouteMeta adalah kebijakan yang dideklarasikan lewat middleware route,
+// group, dan App: dari WithCachePolicy, WithRateLimit, RequireAuth, dan
+// Describe. Dipakai generator spec (clientgen.OpenAPI) untuk membuat konfigurasi
+// gateway.
+type RouteMeta struct {
+	CacheControl string          `json:"cache_control,omitempty"`
+	Compression  []string        `json:"compression,omitempty"`
+	RateLimits   []RateLimitMeta `json:"rate_limits,omitempty"`
+	Auth         []string        `json:"auth,omitempty"`
+}
+
+type RateLimitMeta struct {
+	Name  string        `json:"name,omitempty"`
+	Limit int           `json:"limit"`
+	Per   time.Duration `json:"per"`
+}
+
+// middlewareMeta memetakan instance middleware ke fungsi yang mengisi
+// RouteMeta. Instance dikenali dari alamat closure-nya, jadi closure harus
+// menangkap variabel (closure tanpa variabel berbagi satu alamat); closure
+// ikut disimpan supaya alamatnya tidak dipakai ulang.
+var middlewareMeta sync.Map // uintptr -> metaEntry
+
+type metaEntry struct {
+	mw    MiddlewareFunc
+	apply func(*RouteMeta)
+}
+
+func funcKey(mw MiddlewareFunc) uintptr {
+	return uintptr(*(*unsafe.Pointer)(unsafe.Pointer(&mw)))
+}
+
+// describe mencatat metadata milik mw dan mengembalikan mw apa adanya.
+func describe(mw MiddlewareFunc, apply func(*RouteMeta)) MiddlewareFunc {
+	middlewareMeta.Store(funcKey(mw), metaEntry{mw: mw, apply: apply})
+	return mw
+}
+
+// metaOf menggabungkan metadata middleware sesuai urutan chain; cache
+// policy dan auth yang lebih dalam menimpa yang lebih luar, rate limit
+// dijumlahkan.
+func metaOf(mws []MiddlewareFunc) RouteMeta {
+	var meta RouteMeta
+	for _, mw := range mws {
+		if e, ok := middlewareMeta.Load(funcKey(mw)); ok {
+			e.(metaEntry).apply(&meta)
+		}
+	}
+	return meta
+}
+
+// Describe menambahkan hint untuk route atau group tanpa mengubah
+// perilakunya, misalnya kompresi yang diharapkan dari gateway:
+//
+//	r.GET("/reports", h, netpath.Describe(netpath.RouteMeta{Compression: []string{"br", "gzip"}}))
+//
+// Field yang kosong tidak menimpa metadata dari middleware lain.
+func Describe(meta RouteMeta) MiddlewareFunc {
+	mw := func(next HandlerFunc) HandlerFunc {
+		_ = meta // lihat middlewareMeta
+		return next
+	}
+	return describe(mw, func(m *RouteMeta) {
+		if meta.CacheControl != "" {
+			m.CacheControl = meta.CacheControl
+		}
+		if len(meta.Compression) > 0 {
+			m.Compression = meta.Compression
+		}
+		m.RateLimits = append(m.RateLimits, meta.RateLimits...)
+		if len(meta.Auth) > 0 {
+			m.Auth = meta.Auth
+		}
+	})
+}
+
+// RequireAuth menolak request tanpa session dengan 401. Schemes adalah nama
+// skema autentikasi yang diterima route (misalnya "bearer" atau "cookie")
+// untuk dokumentasi; session tetap dipasang middleware aplikasi. Skema HTTP
+// "basic" dan "bearer" juga dikirim di header WWW-Authenticate.
+func RequireAuth(schemes ...string) MiddlewareFunc {
+	if len(schemes) == 0 {
+		schemes = []string{"session"}
+	}
+	var challenges []string
+	for _, s := range schemes {
+		switch strings.ToLower(s) {
+		case "basic":
+			challenges = append(challenges, "Basic")
+		case "bearer":
+			challenges = append(challenges, "Bearer")
+		}
+	}
+	challenge := strings.Join(challenges, ", ")
+
+	mw := func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if ctx.session == nil {
+				if challenge != "" {
+					ctx.writer.Header().Set("WWW-Authenticate", challenge)
+				}
+				return ctx.Unauthorized(faults.ErrUnauthorized)
+			}
+			return next(ctx)
+		}
+	}
+	return describe(mw, func(m *RouteMeta) { m.Auth = schemes })
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// WithDebug mencetak banner dan tabel route (lengkap dengan lokasi
+// pendaftaran dan middleware tiap route) ke stderr ketika Run dimulai.
+func WithDebug() Option {
+	return func(app *App) {
+		app.debug = true
+	}
+}
+
+var selfPkg = reflect.TypeOf(App{}).PkgPath()
+
+// registrationSource mengembalikan file:line pemanggil pertama di luar
+// netpath, yaitu tempat route didaftarkan oleh aplikasi.
+func registrationSource() string {
+	var pcs [32]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, selfPkg+".") && !strings.HasPrefix(f.Function, selfPkg+"/") {
+			return shortFile(f.File) + ":" + fmt.Sprint(f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func shortFile(file string) string {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return file
+}
+
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+$|-fm$`)
+
+// funcName memberi nama pendek untuk middleware, misalnya
+// "netpath.WithCSRF" untuk closure yang dibuat WithCSRF.
+func funcName(fn any) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return "?"
+	}
+	name := closureSuffix.ReplaceAllString(f.Name(), "")
+	return name[strings.LastIndexByte(name, '/')+1:]
+}
+
+func middlewareNames(mws []MiddlewareFunc) []string {
+	names := make([]string, len(mws))
+	for i, mw := range mws {
+		names[i] = funcName(mw)
+	}
+	return names
+}
+
+// PrintRoutes menulis tabel route terurut ke w. Middleware global dari
+// App.Use ditampilkan terpisah di atas tabel.
+func (app *App) PrintRoutes(w io.Writer) {
+	routes := app.Routes()
+	sort.Slice(routes, func(i, j int) bool {
+		a, b := routes[i], routes[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Pattern != b.Pattern {
+			return a.Pattern < b.Pattern
+		}
+		return a.Method < b.Method
+	})
+
+	if len(app.mw) > 0 {
+		fmt.Fprintf(w, "global middleware: %s\n", strings.Join(middlewareNames(app.mw), " -> "))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tMIDDLEWARE\tSOURCE")
+	for _, r := range routes {
+		mws := strings.Join(r.Middleware, " -> ")
+		if mws == "" {
+			mws = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s%s\t%s\t%s\n", r.Method, r.Host, r.Pattern, mws, r.Source)
+	}
+	tw.Flush()
+}
+
+func (app *App) printBanner(w io.Writer, addr string) {
+	fmt.Fprintf(w, "netpath listening on %s (%d routes)\n", addr, len(app.Routes()))
+	app.PrintRoutes(w)
+}
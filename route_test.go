@@ -0,0 +1,138 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRoutePrecedence(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes []string
+		path   string
+		want   string
+		params map[string]string
+	}{
+		{
+			name:   "static beats param",
+			routes: []string{"/users/:id", "/users/me"},
+			path:   "/users/me",
+			want:   "/users/me",
+			params: map[string]string{},
+		},
+		{
+			name:   "param still matches other values",
+			routes: []string{"/users/:id", "/users/me"},
+			path:   "/users/42",
+			want:   "/users/:id",
+			params: map[string]string{"id": "42"},
+		},
+		{
+			name:   "param beats wildcard",
+			routes: []string{"/files/*path", "/files/:name"},
+			path:   "/files/a.txt",
+			want:   "/files/:name",
+			params: map[string]string{"name": "a.txt"},
+		},
+		{
+			name:   "wildcard takes deeper paths",
+			routes: []string{"/files/*path", "/files/:name"},
+			path:   "/files/a/b.txt",
+			want:   "/files/*path",
+			params: map[string]string{"path": "a/b.txt"},
+		},
+		{
+			name:   "exact match beats zero-segment wildcard",
+			routes: []string{"/files/*path", "/files"},
+			path:   "/files",
+			want:   "/files",
+			params: map[string]string{},
+		},
+		{
+			name:   "exact param route beats trailing wildcard",
+			routes: []string{"/users/:id/*rest", "/users/:id"},
+			path:   "/users/7",
+			want:   "/users/:id",
+			params: map[string]string{"id": "7"},
+		},
+		{
+			name:   "trailing wildcard keeps its own paths",
+			routes: []string{"/users/:id/*rest", "/users/:id"},
+			path:   "/users/7/posts/1",
+			want:   "/users/:id/*rest",
+			params: map[string]string{"id": "7", "rest": "posts/1"},
+		},
+		{
+			name:   "wildcard alone still matches zero segments",
+			routes: []string{"/files/*path"},
+			path:   "/files",
+			want:   "/files/*path",
+			params: map[string]string{"path": ""},
+		},
+		{
+			name:   "earlier static segment decides",
+			routes: []string{"/:kind/new", "/posts/:id"},
+			path:   "/posts/new",
+			want:   "/posts/:id",
+			params: map[string]string{"id": "new"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// hasil tidak boleh bergantung pada urutan pendaftaran
+			for _, routes := range [][]string{tc.routes, reversed(tc.routes)} {
+				app := New()
+				for _, r := range routes {
+					app.Route().GET(r, func(ctx *Context) error { return nil })
+				}
+				entry, params := app.find(app.Route(), "GET", tc.path)
+				if entry == nil {
+					t.Fatalf("%v: %s matched nothing, want %s", routes, tc.path, tc.want)
+				}
+				if entry.pattern != tc.want {
+					t.Errorf("%v: %s matched %s, want %s", routes, tc.path, entry.pattern, tc.want)
+				}
+				if !maps.Equal(params, tc.params) {
+					t.Errorf("%v: params = %v, want %v", routes, params, tc.params)
+				}
+			}
+		})
+	}
+}
+
+func TestRouteConflicts(t *testing.T) {
+	tests := []struct {
+		routes   []string
+		conflict bool
+	}{
+		{[]string{"/users/:id", "/users/:name"}, true},
+		{[]string{"/files/*a", "/files/*b"}, true},
+		{[]string{"/users/:id", "/users/me"}, false},
+		{[]string{"/files", "/files/*path"}, false},
+		{[]string{"/users/:id", "/users/:id/posts"}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(strings.Join(tc.routes, " "), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if (r != nil) != tc.conflict {
+					t.Fatalf("panic = %v, want conflict %v", r, tc.conflict)
+				}
+			}()
+			app := New()
+			for _, r := range tc.routes {
+				app.Route().GET(r, func(ctx *Context) error { return nil })
+			}
+		})
+	}
+}
+
+func reversed(s []string) []string {
+	r := slices.Clone(s)
+	slices.Reverse(r)
+	return r
+}
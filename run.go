@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// ServerConfig mengatur http.Server dan listener yang dipakai Run.
+// Nilai nol memakai default yang aman terhadap client lambat (slowloris).
+type ServerConfig struct {
+	ReadHeaderTimeout time.Duration // default 10 detik
+	ReadTimeout       time.Duration // default tanpa batas
+	WriteTimeout      time.Duration // default tanpa batas
+	IdleTimeout       time.Duration // default 120 detik
+	MaxHeaderBytes    int           // default http.DefaultMaxHeaderBytes
+	// MaxConns membatasi jumlah koneksi bersamaan; koneksi berikutnya
+	// menunggu di antrian accept. 0 berarti tanpa batas.
+	MaxConns int
+	// KeepAlive adalah interval TCP keep-alive. Default 3 menit, negatif mematikan.
+	KeepAlive time.Duration
+	// TLS mengaktifkan HTTPS, misalnya dari autotls.Manager.TLSConfig().
+	TLS *tls.Config
+}
+
+// Run menjalankan OnStart setiap module dan semua Warmup, lalu membuka addr
+// dan melayani sampai Shutdown dipanggil. Shutdown yang normal tidak
+// dianggap error.
+func (app *App) Run(addr string, cfg ...ServerConfig) error {
+	var c ServerConfig
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	if c.ReadHeaderTimeout == 0 {
+		c.ReadHeaderTimeout = 10 * time.Second
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = 120 * time.Second
+	}
+	if c.KeepAlive == 0 {
+		c.KeepAlive = 3 * time.Minute
+	}
+
+	if err := app.Start(context.Background()); err != nil {
+		return err
+	}
+	if err := app.runWarmups(context.Background()); err != nil {
+		return errors.Join(err, app.Stop(context.Background()))
+	}
+
+	lc := net.ListenConfig{KeepAlive: c.KeepAlive}
+	ln, err := lc.Listen(context.Background(), "tcp", addr)
+	if err != nil {
+		return errors.Join(err, app.Stop(context.Background()))
+	}
+	if c.MaxConns > 0 {
+		ln = newLimitListener(ln, c.MaxConns)
+	}
+	if c.TLS != nil {
+		ln = tls.NewListener(ln, c.TLS)
+	}
+
+	srv := &http.Server{
+		Handler:           app,
+		ReadHeaderTimeout: c.ReadHeaderTimeout,
+		ReadTimeout:       c.ReadTimeout,
+		WriteTimeout:      c.WriteTimeout,
+		IdleTimeout:       c.IdleTimeout,
+		MaxHeaderBytes:    c.MaxHeaderBytes,
+		TLSConfig:         c.TLS,
+	}
+
+	app.serverMu.Lock()
+	app.server = srv
+	app.serverMu.Unlock()
+
+	if app.debug {
+		app.printBanner(os.Stderr, ln.Addr().String())
+	}
+	app.ready.Store(true)
+	err = srv.Serve(ln)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown menghentikan server yang dijalankan Run. Koneksi long-lived dari
+// ctx.Hold diberi sinyal terminasi dan grace window lebih dulu, lalu request
+// aktif ditunggu sampai ctx habis dan OnStop setiap module dijalankan.
+func (app *App) Shutdown(ctx context.Context) error {
+	app.ready.Store(false)
+	app.drain(ctx)
+
+	app.serverMu.Lock()
+	srv := app.server
+	app.serverMu.Unlock()
+
+	var err error
+	if srv != nil {
+		err = srv.Shutdown(ctx)
+	}
+	return errors.Join(err, app.Stop(ctx))
+}
+
+// limitListener membatasi koneksi bersamaan dengan semaphore; slot dilepas
+// ketika koneksi ditutup. Accept yang menunggu slot berhenti saat listener
+// ditutup, sehingga Shutdown tidak tertahan oleh server yang sedang penuh.
+type limitListener struct {
+	net.Listener
+	sem       chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newLimitListener(ln net.Listener, n int) *limitListener {
+	return &limitListener{Listener: ln, sem: make(chan struct{}, n), done: make(chan struct{})}
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+	return &limitConn{Conn: conn, release: func() { <-l.sem }}, nil
+}
+
+func (l *limitListener) Close() error {
+	err := l.Listener.Close()
+	l.closeOnce.Do(func() { close(l.done) })
+	return err
+}
+
+type limitConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.release)
+	return err
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestLimitListener(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln := newLimitListener(inner, 1)
+	defer ln.Close()
+
+	dial := func() net.Conn {
+		c, err := net.Dial("tcp", inner.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { c.Close() })
+		return c
+	}
+	accepted := make(chan error, 1)
+	accept := func() net.Conn {
+		conn, err := ln.Accept()
+		accepted <- err
+		return conn
+	}
+
+	dial()
+	first := accept()
+	<-accepted
+
+	// slot penuh: Accept berikutnya menunggu sampai koneksi pertama ditutup
+	dial()
+	go accept()
+	select {
+	case err := <-accepted:
+		t.Fatalf("Accept returned %v while the only slot is taken", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	first.Close()
+	select {
+	case err := <-accepted:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Accept did not resume after the slot was released")
+	}
+
+	// slot penuh lagi: Close harus melepas Accept yang menunggu
+	go accept()
+	time.Sleep(20 * time.Millisecond)
+	ln.Close()
+	select {
+	case err := <-accepted:
+		if !errors.Is(err, net.ErrClosed) {
+			t.Fatalf("Accept after Close = %v, want net.ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Accept still blocked after Close")
+	}
+}
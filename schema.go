@@ -0,0 +1,320 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/godev90/validator/faults"
+)
+
+// Schema adalah subset JSON Schema yang didukung BindMap: type, properties,
+// required, items, enum, minimum/maximum, minLength/maxLength,
+// minItems/maxItems, pattern, format (email, date, date-time),
+// additionalProperties, default dan nullable.
+type Schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *Schema            `json:"items"`
+	Enum                 []any              `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	MinItems             *int               `json:"minItems"`
+	MaxItems             *int               `json:"maxItems"`
+	Pattern              string             `json:"pattern"`
+	Format               string             `json:"format"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Default              any                `json:"default"`
+	Nullable             bool               `json:"nullable"`
+	// Ref hanya dipakai dokumen OpenAPI dan di-resolve saat LoadOpenAPI.
+	Ref string `json:"$ref"`
+
+	pattern *regexp.Regexp
+}
+
+var schemas sync.Map
+
+// ParseSchema membaca JSON Schema dan meng-compile pattern di dalamnya.
+func ParseSchema(raw []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (s *Schema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("schema pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// RegisterSchema mendaftarkan schema dengan nama agar bisa dipakai WithSchema.
+// Schema boleh didaftarkan ulang saat runtime, misalnya ketika custom field berubah.
+func RegisterSchema(name string, raw []byte) error {
+	s, err := ParseSchema(raw)
+	if err != nil {
+		return err
+	}
+	schemas.Store(name, s)
+	return nil
+}
+
+// WithSchema memasang schema terdaftar pada route untuk dipakai ctx.BindMap.
+// Schema dicari saat request, sehingga pendaftaran ulang langsung berlaku.
+func WithSchema(name string) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			s, ok := schemas.Load(name)
+			if !ok {
+				return ctx.ServerError(fmt.Errorf("schema %q is not registered", name))
+			}
+			ctx.schema = s.(*Schema)
+			return next(ctx)
+		}
+	}
+}
+
+// BindMap membaca body JSON (atau form untuk content type form) ke map dan
+// memvalidasinya terhadap schema route. Nilai form dikonversi sesuai type di schema.
+func (c *Context) BindMap() (map[string]any, error) {
+	if c.schema == nil {
+		return nil, fmt.Errorf("no schema on route, use WithSchema")
+	}
+
+	var data map[string]any
+	ct := c.request.Header.Get("Content-Type")
+	if strings.HasPrefix(ct, "application/x-www-form-urlencoded") || strings.HasPrefix(ct, "multipart/form-data") {
+		if err := c.request.ParseForm(); err != nil {
+			return nil, err
+		}
+		data = c.schema.fromForm(c.request.Form)
+	} else {
+		body, err := c.BodyBytes()
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err := dec.Decode(&data); err != nil {
+			return nil, err
+		}
+	}
+
+	errs := faults.Errors{}
+	c.schema.validate("", data, errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return data, nil
+}
+
+func (s *Schema) fromForm(values map[string][]string) map[string]any {
+	data := make(map[string]any, len(values))
+	for key, vals := range values {
+		prop := s.Properties[key]
+		if prop != nil && prop.Type == "array" {
+			items := make([]any, len(vals))
+			for i, v := range vals {
+				items[i] = prop.Items.coerce(v)
+			}
+			data[key] = items
+			continue
+		}
+		if len(vals) > 0 {
+			data[key] = prop.coerce(vals[0])
+		}
+	}
+	return data
+}
+
+func (s *Schema) coerce(raw string) any {
+	if s == nil {
+		return raw
+	}
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
+func (s *Schema) validate(path string, v any, errs faults.Errors) {
+	key := path
+	if key == "" {
+		key = "body"
+	}
+	if v == nil && s.Nullable {
+		return
+	}
+
+	if len(s.Enum) > 0 && !enumContains(s.Enum, v) {
+		errs[key] = faults.ErrMustBeOneOf
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			errs[key] = faults.ErrTypeMismatch
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				if def := s.Properties[name]; def == nil || def.Default == nil {
+					errs[joinPath(path, name)] = faults.ErrRequired
+				}
+			}
+		}
+		for name, prop := range s.Properties {
+			if _, ok := obj[name]; !ok && prop.Default != nil {
+				obj[name] = prop.Default
+			}
+		}
+		for name, val := range obj {
+			prop, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					errs[joinPath(path, name)] = faults.ErrInvalidParameter.Render(name)
+				}
+				continue
+			}
+			prop.validate(joinPath(path, name), val, errs)
+		}
+
+	case "array":
+		arr, ok := v.([]any)
+		if !ok {
+			errs[key] = faults.ErrTypeMismatch
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			errs[key] = faults.ErrLengthBelowMinimum.Render(*s.MinItems)
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			errs[key] = faults.ErrLengthAboveMaximum.Render(*s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range arr {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			errs[key] = faults.ErrTypeMismatch
+			return
+		}
+		n := len([]rune(str))
+		if s.MinLength != nil && n < *s.MinLength {
+			errs[key] = faults.ErrLengthBelowMinimum.Render(*s.MinLength)
+		} else if s.MaxLength != nil && n > *s.MaxLength {
+			errs[key] = faults.ErrLengthAboveMaximum.Render(*s.MaxLength)
+		} else if s.pattern != nil && !s.pattern.MatchString(str) {
+			errs[key] = faults.ErrInvalidParameter.Render(key)
+		} else if err := checkFormat(s.Format, str); err != nil {
+			errs[key] = err
+		}
+
+	case "integer", "number":
+		f, ok := toFloat(v)
+		if !ok {
+			errs[key] = faults.ErrInvalidNumericFormat
+			return
+		}
+		if s.Type == "integer" && f != float64(int64(f)) {
+			errs[key] = faults.ErrInvalidIntegerNumber
+		} else if s.Minimum != nil && f < *s.Minimum {
+			errs[key] = faults.ErrBelowMinimum.Render(*s.Minimum)
+		} else if s.Maximum != nil && f > *s.Maximum {
+			errs[key] = faults.ErrAboveMaximum.Render(*s.Maximum)
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			errs[key] = faults.ErrTypeMismatch
+		}
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func enumContains(enum []any, v any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFormat(format, s string) error {
+	switch format {
+	case "email":
+		if _, err := mail.ParseAddress(s); err != nil {
+			return faults.ErrMustBeEmail
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return faults.ErrInvalidDateFormat
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return faults.ErrInvalidDatetimeFormat
+		}
+	}
+	return nil
+}
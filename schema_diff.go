@@ -0,0 +1,251 @@
+package app
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"mime"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	logging "github.com/godev90/netpath/helpers/logging"
+)
+
+// SchemaDiffConfig mengatur WithSchemaDiff.
+type SchemaDiffConfig struct {
+	// Metrics mencatat netpath_payload_fields_total per route, field, dan
+	// jenis ("unknown" atau "deprecated").
+	Metrics *Metrics
+	// MaxFields membatasi jumlah nama field unknown yang dicatat per route
+	// supaya label metrik tidak meledak; sisanya dicatat sebagai "_other".
+	// Default 50.
+	MaxFields int
+	// OnDiff dipanggil untuk setiap request yang mengirim field unknown atau
+	// deprecated. Default hanya mencatat log sekali per route dan field.
+	OnDiff func(ctx *Context, diff SchemaDiff)
+}
+
+// SchemaDiff adalah field di body request yang tidak ada di tipe Req
+// handler Handle, atau yang ditandai `deprecated:"..."`. Nama field memakai
+// path bertitik, misalnya "address.zip" atau "items[].sku".
+type SchemaDiff struct {
+	Route      string   `json:"route"`
+	Unknown    []string `json:"unknown,omitempty"`
+	Deprecated []string `json:"deprecated,omitempty"`
+}
+
+// WithSchemaDiff membandingkan body JSON dengan tipe Req handler Handle
+// sebelum handler berjalan, untuk mengukur field lama yang masih dikirim
+// client sebelum perubahan breaking. Request tidak pernah ditolak.
+//
+//	type UpdateUser struct {
+//		Name     string `json:"name"`
+//		Nickname string `json:"nickname" deprecated:"use name"`
+//	}
+func WithSchemaDiff(cfg SchemaDiffConfig) MiddlewareFunc {
+	if cfg.MaxFields <= 0 {
+		cfg.MaxFields = 50
+	}
+	tracker := &fieldTracker{max: cfg.MaxFields, seen: map[string]map[string]bool{}, logged: map[string]bool{}}
+	if cfg.OnDiff == nil {
+		cfg.OnDiff = tracker.log
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			if ctx.requestType == nil {
+				return next(ctx)
+			}
+			mediaType, _, _ := mime.ParseMediaType(ctx.request.Header.Get("Content-Type"))
+			if mediaType != "" && !isJSONMediaType(mediaType) {
+				return next(ctx)
+			}
+			body, err := ctx.BodyBytes()
+			if err != nil || len(bytes.TrimSpace(body)) == 0 {
+				return next(ctx)
+			}
+
+			w := payloadWalker{unknown: map[string]bool{}, deprecated: map[string]bool{}}
+			w.walk(body, ctx.requestType, "")
+			if len(w.unknown) == 0 && len(w.deprecated) == 0 {
+				return next(ctx)
+			}
+
+			diff := SchemaDiff{Route: ctx.route, Unknown: sortedKeys(w.unknown), Deprecated: sortedKeys(w.deprecated)}
+			for _, f := range diff.Unknown {
+				cfg.Metrics.payloadField(ctx.route, tracker.label(ctx.route, f), "unknown")
+			}
+			for _, f := range diff.Deprecated {
+				cfg.Metrics.payloadField(ctx.route, f, "deprecated")
+			}
+			cfg.OnDiff(ctx, diff)
+			return next(ctx)
+		}
+	}
+}
+
+// fieldTracker membatasi kardinalitas nama field unknown per route dan
+// mencatat log hanya untuk kemunculan pertama. Catatan log memakai label yang
+// sama dengan metrik, sehingga ukurannya ikut dibatasi MaxFields.
+type fieldTracker struct {
+	mu     sync.Mutex
+	max    int
+	seen   map[string]map[string]bool
+	logged map[string]bool
+}
+
+func (t *fieldTracker) label(route, field string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fields := t.seen[route]
+	if fields == nil {
+		fields = map[string]bool{}
+		t.seen[route] = fields
+	}
+	if !fields[field] {
+		if len(fields) >= t.max {
+			return "_other"
+		}
+		fields[field] = true
+	}
+	return field
+}
+
+func (t *fieldTracker) log(ctx *Context, diff SchemaDiff) {
+	for _, f := range diff.Unknown {
+		// field di luar MaxFields dicatat sekali sebagai "_other"
+		if t.first(diff.Route + " unknown " + t.label(diff.Route, f)) {
+			logging.Infof("schema diff %s %s: unknown field %q", ctx.request.Method, diff.Route, f)
+		}
+	}
+	// field deprecated berasal dari tipe Req, jumlahnya terbatas
+	for _, f := range diff.Deprecated {
+		if t.first(diff.Route + " deprecated " + f) {
+			logging.Infof("schema diff %s %s: deprecated field %q", ctx.request.Method, diff.Route, f)
+		}
+	}
+}
+
+func (t *fieldTracker) first(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.logged[key] {
+		return false
+	}
+	t.logged[key] = true
+	return true
+}
+
+type payloadField struct {
+	typ        reflect.Type
+	deprecated bool
+}
+
+// payloadFields memetakan nama JSON (huruf kecil, seperti pencocokan
+// encoding/json) ke field per tipe struct.
+var payloadFields sync.Map
+
+func fieldsOf(t reflect.Type) map[string]payloadField {
+	if v, ok := payloadFields.Load(t); ok {
+		return v.(map[string]payloadField)
+	}
+	fields := map[string]payloadField{}
+	collectPayloadFields(t, fields)
+	payloadFields.Store(t, fields)
+	return fields
+}
+
+func collectPayloadFields(t reflect.Type, out map[string]payloadField) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				collectPayloadFields(ft, out)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		_, deprecated := sf.Tag.Lookup("deprecated")
+		out[strings.ToLower(name)] = payloadField{typ: sf.Type, deprecated: deprecated}
+	}
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// payloadWalker menelusuri JSON mengikuti tipe Go; elemen slice memakai
+// path yang sama sehingga satu field dilaporkan sekali.
+type payloadWalker struct {
+	unknown, deprecated map[string]bool
+}
+
+func (w payloadWalker) walk(raw json.RawMessage, t reflect.Type, prefix string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	// tipe dengan UnmarshalJSON/UnmarshalText menentukan formatnya sendiri
+	if pt := reflect.PointerTo(t); pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) != nil {
+			return
+		}
+		fields := fieldsOf(t)
+		for key, value := range obj {
+			path := prefix + key
+			f, ok := fields[strings.ToLower(key)]
+			if !ok {
+				w.unknown[path] = true
+				continue
+			}
+			if f.deprecated {
+				w.deprecated[path] = true
+			}
+			w.walk(value, f.typ, path+".")
+		}
+
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(raw, &items) != nil {
+			return
+		}
+		itemPrefix := strings.TrimSuffix(prefix, ".") + "[]."
+		for _, item := range items {
+			w.walk(item, t.Elem(), itemPrefix)
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/godev90/validator/faults"
+)
+
+// SessionInfo adalah ringkasan session untuk layar "kelola perangkat".
+// Handle dipakai untuk mencabut session tanpa membuka ID session aslinya.
+type SessionInfo struct {
+	Handle    string    `json:"handle"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+	LastSeen  time.Time `json:"last_seen"`
+	Current   bool      `json:"current"`
+}
+
+func sessionHandle(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (c *Context) identitySessions() ([]*SessionRecord, error) {
+	if c.sessions == nil {
+		return nil, errors.New("netpath: sessions are not enabled, use WithSessions")
+	}
+	if c.session == nil {
+		return nil, faults.ErrUnauthorized
+	}
+
+	index, ok := c.sessions.cfg.Store.(SessionIndex)
+	if !ok {
+		return nil, errors.New("netpath: session store does not implement SessionIndex")
+	}
+	return index.Sessions(c.request.Context(), c.session.Type(), c.session.Identifier())
+}
+
+// ListSessions mengembalikan semua session aktif milik identitas session saat ini,
+// yang terbaru dipakai lebih dulu.
+func (c *Context) ListSessions() ([]SessionInfo, error) {
+	records, err := c.identitySessions()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]SessionInfo, 0, len(records))
+	for _, rec := range records {
+		infos = append(infos, SessionInfo{
+			Handle:    sessionHandle(rec.ID),
+			UserAgent: rec.UserAgent,
+			IP:        rec.IP,
+			CreatedAt: rec.CreatedAt,
+			LastSeen:  rec.LastSeen,
+			Current:   rec.ID == c.sessionID,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].LastSeen.After(infos[j].LastSeen) })
+	return infos, nil
+}
+
+// RevokeSession mencabut session milik identitas yang sama berdasarkan handle
+// dari ListSessions. Mencabut session saat ini sama dengan EndSession.
+func (c *Context) RevokeSession(handle string) error {
+	records, err := c.identitySessions()
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		if sessionHandle(rec.ID) != handle {
+			continue
+		}
+		if rec.ID == c.sessionID {
+			return c.EndSession()
+		}
+		return c.sessions.cfg.Store.Delete(c.request.Context(), rec.ID)
+	}
+	return faults.ErrNotFound
+}
+
+// RevokeOtherSessions mencabut semua session identitas ini kecuali yang sedang dipakai.
+func (c *Context) RevokeOtherSessions() error {
+	records, err := c.identitySessions()
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		if rec.ID == c.sessionID {
+			continue
+		}
+		if err := c.sessions.cfg.Store.Delete(c.request.Context(), rec.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+// SessionLimitPolicy menentukan tindakan saat identitas sudah mencapai batas session.
+type SessionLimitPolicy int
+
+const (
+	// EvictOldest menghapus session tertua agar session baru bisa dibuat.
+	EvictOldest SessionLimitPolicy = iota
+	// RejectNew menolak login baru dengan ErrSessionLimit.
+	RejectNew
+)
+
+// ErrSessionLimit dikembalikan StartSession jika RejectNew berlaku.
+var ErrSessionLimit = faults.ErrForbidden
+
+// SessionIndex diimplementasikan store yang bisa mencari session per identitas.
+type SessionIndex interface {
+	Sessions(ctx context.Context, typ SessionType, identifier string) ([]*SessionRecord, error)
+}
+
+func (c *Context) enforceSessionLimit(session Session) error {
+	limit := c.sessions.cfg.MaxSessions
+	if limit == nil {
+		return nil
+	}
+	allowed := limit(session)
+	if allowed <= 0 {
+		return nil
+	}
+
+	index, ok := c.sessions.cfg.Store.(SessionIndex)
+	if !ok {
+		return fmt.Errorf("netpath: MaxSessions requires a store implementing SessionIndex")
+	}
+
+	ctx := c.request.Context()
+	records, err := index.Sessions(ctx, session.Type(), session.Identifier())
+	if err != nil {
+		return err
+	}
+
+	active := records[:0]
+	for _, rec := range records {
+		// session saat ini akan digantikan, jadi tidak dihitung
+		if rec.ID != c.sessionID {
+			active = append(active, rec)
+		}
+	}
+	if len(active) < allowed {
+		return nil
+	}
+	if c.sessions.cfg.LimitPolicy == RejectNew {
+		return ErrSessionLimit
+	}
+
+	sort.Slice(active, func(i, j int) bool { return active[i].CreatedAt.Before(active[j].CreatedAt) })
+	for _, rec := range active[:len(active)-allowed+1] {
+		if err := c.sessions.cfg.Store.Delete(ctx, rec.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s RedisSessionStore) identityKey(typ SessionType, identifier string) string {
+	return s.key(fmt.Sprintf("identity:%d:%s", typ, identifier))
+}
+
+func (s RedisSessionStore) Sessions(ctx context.Context, typ SessionType, identifier string) ([]*SessionRecord, error) {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.identityKey(typ, identifier)
+	ids, err := client.ZRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*SessionRecord
+	for _, id := range ids {
+		raw, err := client.Get(ctx, s.key(id)).Bytes()
+		if err == redis.Nil {
+			// session sudah kedaluwarsa, bersihkan index
+			client.ZRem(ctx, key, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var rec SessionRecord
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return nil, err
+		}
+		records = append(records, &rec)
+	}
+	return records, nil
+}
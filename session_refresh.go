@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// RefreshRecord adalah refresh token yang disimpan; token aslinya tidak
+	// pernah disimpan, hanya hash SHA-256-nya.
+	RefreshRecord struct {
+		Hash       string          `json:"hash"`
+		Family     string          `json:"family"`
+		Type       SessionType     `json:"type"`
+		Identifier string          `json:"identifier"`
+		Data       json.RawMessage `json:"data"`
+		ExpiresAt  time.Time       `json:"expires_at"`
+	}
+
+	// RefreshStore diimplementasikan SessionStore yang mendukung refresh token.
+	RefreshStore interface {
+		SaveRefresh(ctx context.Context, rec *RefreshRecord) error
+		LoadRefresh(ctx context.Context, hash string) (*RefreshRecord, error)
+		// ConsumeRefresh menandai token terpakai secara atomik dan mengembalikan
+		// false jika token sudah pernah dipakai sebelumnya.
+		ConsumeRefresh(ctx context.Context, hash string) (bool, error)
+		// RevokeFamily menghapus semua token turunan dari login yang sama.
+		RevokeFamily(ctx context.Context, family string) error
+	}
+)
+
+var (
+	ErrRefreshInvalid = faults.ErrUnauthorized
+	errNoRefreshStore = errors.New("netpath: session store does not implement RefreshStore")
+)
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Context) refreshStore() (RefreshStore, error) {
+	if c.sessions == nil {
+		return nil, errors.New("netpath: sessions are not enabled, use WithSessions")
+	}
+	rs, ok := c.sessions.cfg.Store.(RefreshStore)
+	if !ok {
+		return nil, errNoRefreshStore
+	}
+	return rs, nil
+}
+
+// IssueRefreshToken membuat refresh token berumur panjang untuk session aktif,
+// memasangnya sebagai cookie, dan mengembalikan nilainya. Pemanggilan ini
+// memulai family baru, biasanya tepat setelah login dengan "ingat saya".
+func (c *Context) IssueRefreshToken() (string, error) {
+	return c.issueRefresh(newSessionID())
+}
+
+func (c *Context) issueRefresh(family string) (string, error) {
+	rs, err := c.refreshStore()
+	if err != nil {
+		return "", err
+	}
+	if c.session == nil {
+		return "", faults.ErrUnauthorized
+	}
+
+	data, err := json.Marshal(c.session)
+	if err != nil {
+		return "", err
+	}
+
+	token := newSessionID()
+	ttl := c.sessions.cfg.RefreshTTL
+	rec := &RefreshRecord{
+		Hash:       hashToken(token),
+		Family:     family,
+		Type:       c.session.Type(),
+		Identifier: c.session.Identifier(),
+		Data:       data,
+		ExpiresAt:  time.Now().Add(ttl),
+	}
+	if err := rs.SaveRefresh(c.request.Context(), rec); err != nil {
+		return "", err
+	}
+
+	c.SetCookie(c.sessions.refreshCookie(token, int(ttl.Seconds())))
+	return token, nil
+}
+
+func (m *sessionManager) refreshCookie(token string, maxAge int) *http.Cookie {
+	ck := m.cookie(token, maxAge)
+	ck.Name = m.cfg.RefreshCookieName
+	return ck
+}
+
+// RefreshSession menukar refresh token (dari body JSON "refresh_token" atau
+// cookie) dengan session baru dan refresh token baru. Token yang sudah pernah
+// dipakai dianggap bocor: seluruh family dicabut dan request ditolak.
+func (c *Context) RefreshSession() (string, error) {
+	rs, err := c.refreshStore()
+	if err != nil {
+		return "", err
+	}
+
+	token := ""
+	if ck, err := c.request.Cookie(c.sessions.cfg.RefreshCookieName); err == nil {
+		token = ck.Value
+	}
+	if token == "" {
+		var body struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		if raw, err := c.BodyBytes(); err == nil && len(raw) > 0 {
+			json.Unmarshal(raw, &body)
+		}
+		token = body.RefreshToken
+	}
+	if token == "" {
+		return "", ErrRefreshInvalid
+	}
+
+	ctx := c.request.Context()
+	hash := hashToken(token)
+	rec, err := rs.LoadRefresh(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrSessionNotFound) {
+			return "", ErrRefreshInvalid
+		}
+		return "", err
+	}
+	if time.Now().After(rec.ExpiresAt) {
+		return "", ErrRefreshInvalid
+	}
+
+	fresh, err := rs.ConsumeRefresh(ctx, hash)
+	if err != nil {
+		return "", err
+	}
+	if !fresh {
+		rs.RevokeFamily(ctx, rec.Family)
+		return "", ErrRefreshInvalid
+	}
+
+	session, err := decodeSession(&SessionRecord{Type: rec.Type, Data: rec.Data})
+	if err != nil {
+		return "", ErrRefreshInvalid
+	}
+	if err := c.StartSession(session); err != nil {
+		return "", err
+	}
+	return c.issueRefresh(rec.Family)
+}
+
+// RefreshHandler adalah handler siap pakai untuk route seperti POST /token/refresh.
+func RefreshHandler(ctx *Context) error {
+	token, err := ctx.RefreshSession()
+	if err != nil {
+		return ctx.Error(err)
+	}
+	return ctx.Success(map[string]string{
+		"session_id":    ctx.SessionID(),
+		"refresh_token": token,
+	})
+}
+
+func (s RedisSessionStore) refreshKey(hash string) string {
+	return s.key("refresh:" + hash)
+}
+
+func (s RedisSessionStore) familyKey(family string) string {
+	return s.key("refresh-family:" + family)
+}
+
+func (s RedisSessionStore) SaveRefresh(ctx context.Context, rec *RefreshRecord) error {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	ttl := time.Until(rec.ExpiresAt)
+	pipe := client.TxPipeline()
+	pipe.Set(ctx, s.refreshKey(rec.Hash), raw, ttl)
+	pipe.SAdd(ctx, s.familyKey(rec.Family), rec.Hash)
+	pipe.Expire(ctx, s.familyKey(rec.Family), ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (s RedisSessionStore) LoadRefresh(ctx context.Context, hash string) (*RefreshRecord, error) {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := client.Get(ctx, s.refreshKey(hash)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec RefreshRecord
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (s RedisSessionStore) ConsumeRefresh(ctx context.Context, hash string) (bool, error) {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return false, err
+	}
+
+	ttl, err := client.TTL(ctx, s.refreshKey(hash)).Result()
+	if err != nil {
+		return false, err
+	}
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	return client.SetNX(ctx, s.refreshKey(hash)+":used", 1, ttl).Result()
+}
+
+func (s RedisSessionStore) RevokeFamily(ctx context.Context, family string) error {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	hashes, err := client.SMembers(ctx, s.familyKey(family)).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{s.familyKey(family)}
+	for _, h := range hashes {
+		keys = append(keys, s.refreshKey(h))
+	}
+	return client.Del(ctx, keys...).Err()
+}
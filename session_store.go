@@ -0,0 +1,401 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+	"github.com/godev90/validator/faults"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrSessionNotFound dikembalikan SessionStore jika ID tidak dikenal atau kedaluwarsa.
+var ErrSessionNotFound = errors.New("session not found")
+
+type (
+	// SessionRecord adalah bentuk Session yang disimpan di SessionStore.
+	SessionRecord struct {
+		ID         string          `json:"id"`
+		Type       SessionType     `json:"type"`
+		Identifier string          `json:"identifier"`
+		Data       json.RawMessage `json:"data"`
+		CreatedAt  time.Time       `json:"created_at"`
+		LastSeen   time.Time       `json:"last_seen"`
+		UserAgent  string          `json:"user_agent"`
+		IP         string          `json:"ip"`
+	}
+
+	SessionStore interface {
+		Load(ctx context.Context, id string) (*SessionRecord, error)
+		Save(ctx context.Context, rec *SessionRecord, ttl time.Duration) error
+		Delete(ctx context.Context, id string) error
+	}
+
+	SessionConfig struct {
+		Store SessionStore
+
+		CookieName string        // default "np_session"
+		TTL        time.Duration // default 24 jam
+		Domain     string
+		Path       string // default "/"
+		// SameSite default mengikuti CookiePolicy App (atau Lax).
+		SameSite http.SameSite
+		// Insecure mematikan atribut Secure, hanya untuk development tanpa TLS.
+		// CookiePolicy App, jika dipasang, menimpa nilai ini.
+		Insecure bool
+
+		// RefreshTTL adalah umur refresh token, default 30 hari. Store harus
+		// mengimplementasikan RefreshStore untuk memakai refresh token.
+		RefreshTTL        time.Duration
+		RefreshCookieName string // default "np_refresh"
+
+		// MaxSessions mengembalikan batas session aktif untuk identitas
+		// session (0 berarti tanpa batas). Store harus mengimplementasikan SessionIndex.
+		MaxSessions func(Session) int
+		LimitPolicy SessionLimitPolicy
+
+		// TouchInterval membatasi seberapa sering LastSeen dan TTL session
+		// diperbarui di store. Default 1 menit.
+		TouchInterval time.Duration
+	}
+
+	sessionManager struct {
+		cfg SessionConfig
+	}
+)
+
+// WithSessions memuat Session dari cookie dan store pada setiap request,
+// lalu memasangnya di Context. Tipe session harus didaftarkan lewat
+// RegisterSessionType. Cookie tanpa session valid diabaikan.
+func WithSessions(cfg SessionConfig) MiddlewareFunc {
+	if cfg.Store == nil {
+		panic("netpath: WithSessions requires a Store")
+	}
+	if cfg.CookieName == "" {
+		cfg.CookieName = "np_session"
+	}
+	if cfg.TTL == 0 {
+		cfg.TTL = 24 * time.Hour
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/"
+	}
+	if cfg.TouchInterval == 0 {
+		cfg.TouchInterval = time.Minute
+	}
+	if cfg.RefreshTTL == 0 {
+		cfg.RefreshTTL = 30 * 24 * time.Hour
+	}
+	if cfg.RefreshCookieName == "" {
+		cfg.RefreshCookieName = "np_refresh"
+	}
+	m := &sessionManager{cfg: cfg}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			ctx.sessions = m
+
+			cookie, err := ctx.request.Cookie(cfg.CookieName)
+			if err != nil || cookie.Value == "" {
+				return next(ctx)
+			}
+
+			rec, err := cfg.Store.Load(ctx.request.Context(), cookie.Value)
+			if err != nil {
+				if errors.Is(err, ErrSessionNotFound) {
+					return next(ctx)
+				}
+				return ctx.Unavailable(err)
+			}
+
+			session, err := decodeSession(rec)
+			if err != nil {
+				return next(ctx)
+			}
+			ctx.session = session
+			ctx.sessionID = rec.ID
+
+			if time.Since(rec.LastSeen) > cfg.TouchInterval {
+				rec.LastSeen = time.Now()
+				rec.IP = clientKey(ctx.request)
+				if err := cfg.Store.Save(ctx.request.Context(), rec, cfg.TTL); err != nil {
+					log.Printf("[session] failed to touch session: %v", err)
+				}
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func decodeSession(rec *SessionRecord) (Session, error) {
+	t, ok := validSession[rec.Type]
+	if !ok {
+		return nil, faults.ErrUnauthorized
+	}
+
+	v := reflect.New(t)
+	if err := json.Unmarshal(rec.Data, v.Interface()); err != nil {
+		return nil, err
+	}
+	if s, ok := v.Interface().(Session); ok {
+		return s, nil
+	}
+	if s, ok := v.Elem().Interface().(Session); ok {
+		return s, nil
+	}
+	return nil, faults.ErrTypeMismatch
+}
+
+func newSessionID() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (m *sessionManager) cookie(id string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     m.cfg.CookieName,
+		Value:    id,
+		Path:     m.cfg.Path,
+		Domain:   m.cfg.Domain,
+		MaxAge:   maxAge,
+		Secure:   !m.cfg.Insecure,
+		HttpOnly: true,
+		SameSite: m.cfg.SameSite,
+	}
+}
+
+// SessionID mengembalikan ID session yang tersimpan di store, atau string kosong.
+func (c *Context) SessionID() string {
+	return c.sessionID
+}
+
+// StartSession menyimpan session ke store dengan ID baru, memasang cookie,
+// dan menjadikannya session aktif. Session lama (jika ada) dihapus.
+func (c *Context) StartSession(session Session) error {
+	if c.sessions == nil {
+		return errors.New("netpath: sessions are not enabled, use WithSessions")
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if err := c.enforceSessionLimit(session); err != nil {
+		return err
+	}
+
+	rec := &SessionRecord{
+		ID:         newSessionID(),
+		Type:       session.Type(),
+		Identifier: session.Identifier(),
+		Data:       data,
+		CreatedAt:  time.Now(),
+	}
+	return c.persistSession(rec, session)
+}
+
+// RotateSession memberi session aktif ID baru dan menghapus ID lama dari store.
+// Panggil setelah login atau perubahan hak akses untuk mencegah session fixation.
+func (c *Context) RotateSession() error {
+	if c.sessions == nil {
+		return errors.New("netpath: sessions are not enabled, use WithSessions")
+	}
+	if c.session == nil {
+		return faults.ErrUnauthorized
+	}
+
+	created := time.Now()
+	if c.sessionID != "" {
+		if rec, err := c.sessions.cfg.Store.Load(c.request.Context(), c.sessionID); err == nil {
+			created = rec.CreatedAt
+		}
+	}
+
+	data, err := json.Marshal(c.session)
+	if err != nil {
+		return err
+	}
+
+	rec := &SessionRecord{
+		ID:         newSessionID(),
+		Type:       c.session.Type(),
+		Identifier: c.session.Identifier(),
+		Data:       data,
+		CreatedAt:  created,
+	}
+	return c.persistSession(rec, c.session)
+}
+
+func (c *Context) persistSession(rec *SessionRecord, session Session) error {
+	rec.LastSeen = time.Now()
+	rec.UserAgent = c.request.UserAgent()
+	rec.IP = clientKey(c.request)
+
+	store := c.sessions.cfg.Store
+	if err := store.Save(c.request.Context(), rec, c.sessions.cfg.TTL); err != nil {
+		return err
+	}
+
+	if c.sessionID != "" && c.sessionID != rec.ID {
+		store.Delete(c.request.Context(), c.sessionID)
+	}
+
+	c.session = session
+	c.sessionID = rec.ID
+	c.SetCookie(c.sessions.cookie(rec.ID, int(c.sessions.cfg.TTL.Seconds())))
+	return nil
+}
+
+// EndSession menghapus session aktif dari store dan cookie.
+func (c *Context) EndSession() error {
+	if c.sessions == nil {
+		return errors.New("netpath: sessions are not enabled, use WithSessions")
+	}
+
+	if c.sessionID != "" {
+		if err := c.sessions.cfg.Store.Delete(c.request.Context(), c.sessionID); err != nil {
+			return err
+		}
+	}
+
+	c.session = nil
+	c.sessionID = ""
+	c.SetCookie(c.sessions.cookie("", -1))
+	return nil
+}
+
+// SecureCookies memaksa atribut Secure, HttpOnly, dan SameSite pada cookie
+// dengan nama tertentu yang di-set handler mana pun, termasuk handler
+// http.Handler yang di-mount.
+func SecureCookies(names ...string) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			ctx.writer = &cookieWriter{ResponseWriter: ctx.writer, names: names}
+			return next(ctx)
+		}
+	}
+}
+
+type cookieWriter struct {
+	http.ResponseWriter
+	names []string
+	done  bool
+}
+
+func (w *cookieWriter) WriteHeader(code int) {
+	w.harden()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cookieWriter) Write(p []byte) (int, error) {
+	w.harden()
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *cookieWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *cookieWriter) harden() {
+	if w.done {
+		return
+	}
+	w.done = true
+
+	header := w.Header()
+	for i, raw := range header["Set-Cookie"] {
+		name, _, _ := strings.Cut(raw, "=")
+		if !contains(w.names, strings.TrimSpace(name)) {
+			continue
+		}
+
+		lower := strings.ToLower(raw)
+		if !strings.Contains(lower, "; secure") {
+			raw += "; Secure"
+		}
+		if !strings.Contains(lower, "; httponly") {
+			raw += "; HttpOnly"
+		}
+		if !strings.Contains(lower, "; samesite") {
+			raw += "; SameSite=Lax"
+		}
+		header["Set-Cookie"][i] = raw
+	}
+}
+
+// RedisSessionStore menyimpan session sebagai JSON di Redis dengan TTL.
+type RedisSessionStore struct {
+	Alias  string
+	Prefix string // default "session:"
+}
+
+func (s RedisSessionStore) key(id string) string {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = "session:"
+	}
+	return prefix + id
+}
+
+func (s RedisSessionStore) Load(ctx context.Context, id string) (*SessionRecord, error) {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := client.Get(ctx, s.key(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rec SessionRecord
+	if err := json.Unmarshal(raw, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (s RedisSessionStore) Save(ctx context.Context, rec *SessionRecord, ttl time.Duration) error {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	index := s.identityKey(rec.Type, rec.Identifier)
+	pipe := client.TxPipeline()
+	pipe.Set(ctx, s.key(rec.ID), raw, ttl)
+	pipe.ZAdd(ctx, index, redis.Z{Score: float64(rec.CreatedAt.UnixNano()), Member: rec.ID})
+	pipe.Expire(ctx, index, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (s RedisSessionStore) Delete(ctx context.Context, id string) error {
+	client, err := cache.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	if rec, err := s.Load(ctx, id); err == nil {
+		client.ZRem(ctx, s.identityKey(rec.Type, rec.Identifier), id)
+	}
+	return client.Del(ctx, s.key(id)).Err()
+}
@@ -0,0 +1,295 @@
+package app
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/godev90/validator/faults"
+)
+
+// SigningKey adalah satu kunci di Keyring. Isi Secret untuk HMAC-SHA256
+// (JWS "HS256") atau PrivateKey untuk Ed25519 (JWS "EdDSA"). Kunci yang
+// hanya dipakai memverifikasi cukup berisi PublicKey.
+type SigningKey struct {
+	ID         string
+	Secret     []byte
+	PrivateKey ed25519.PrivateKey
+	PublicKey  ed25519.PublicKey
+}
+
+// Keyring menyimpan kunci penandatanganan dengan dukungan rotasi: response
+// selalu ditandatangani dengan kunci aktif, sedangkan verifikasi request
+// menerima semua kunci yang masih ada di ring.
+type Keyring struct {
+	mu      sync.RWMutex
+	keys    map[string]SigningKey
+	current string
+}
+
+// NewKeyring membuat ring; kunci pertama menjadi kunci aktif.
+func NewKeyring(keys ...SigningKey) *Keyring {
+	k := &Keyring{keys: make(map[string]SigningKey)}
+	for _, key := range keys {
+		k.Add(key)
+	}
+	if len(keys) > 0 {
+		k.current = keys[0].ID
+	}
+	return k
+}
+
+// Add menambahkan kunci tanpa mengaktifkannya, misalnya kunci baru yang
+// sudah dibagikan ke partner sebelum rotasi.
+func (k *Keyring) Add(key SigningKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[key.ID] = key
+}
+
+// Rotate menambahkan key dan menjadikannya kunci aktif. Kunci lama tetap
+// diterima untuk verifikasi sampai Retire dipanggil.
+func (k *Keyring) Rotate(key SigningKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[key.ID] = key
+	k.current = key.ID
+}
+
+// Retire menghapus kunci dari ring. Kunci aktif tidak bisa dihapus.
+func (k *Keyring) Retire(id string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if id != k.current {
+		delete(k.keys, id)
+	}
+}
+
+func (k *Keyring) Current() (SigningKey, bool) {
+	return k.Get(k.currentID())
+}
+
+func (k *Keyring) currentID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.current
+}
+
+func (k *Keyring) Get(id string) (SigningKey, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key, ok := k.keys[id]
+	return key, ok
+}
+
+type SignatureFormat int
+
+const (
+	// SignatureHMAC menulis "kid=<id>;sha256=<hex>" dengan HMAC-SHA256 atas body.
+	SignatureHMAC SignatureFormat = iota
+	// SignatureJWS menulis JWS compact dengan payload detached
+	// ("<header>..<signature>", RFC 7515 Appendix F).
+	SignatureJWS
+)
+
+type SignatureConfig struct {
+	Keys   *Keyring
+	Format SignatureFormat
+	// Header default "X-Signature" untuk HMAC dan "X-JWS-Signature" untuk JWS.
+	Header string
+}
+
+func (cfg *SignatureConfig) defaults() {
+	if cfg.Keys == nil {
+		panic("netpath: signature middleware requires a Keyring")
+	}
+	if cfg.Header == "" {
+		cfg.Header = "X-Signature"
+		if cfg.Format == SignatureJWS {
+			cfg.Header = "X-JWS-Signature"
+		}
+	}
+}
+
+// WithResponseSignature menandatangani body response dengan kunci aktif dan
+// menaruh signature di header. Response ditampung dulu sampai handler selesai.
+func WithResponseSignature(cfg SignatureConfig) MiddlewareFunc {
+	cfg.defaults()
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			buf := &bufferWriter{ResponseWriter: ctx.writer}
+			ctx.writer = buf
+			err := next(ctx)
+			// error yang belum dirender ditulis ke buffer di sini supaya ikut
+			// ditandatangani, bukan dirender App tanpa signature
+			if err != nil && buf.status == 0 {
+				ctx.Error(err)
+			}
+			ctx.writer = buf.ResponseWriter
+			if buf.status == 0 {
+				return err
+			}
+			if key, ok := cfg.Keys.Current(); ok {
+				if sig, serr := cfg.sign(key, buf.body.Bytes()); serr == nil {
+					ctx.writer.Header().Set(cfg.Header, sig)
+				} else if err == nil {
+					err = serr
+				}
+			}
+			ctx.writer.WriteHeader(buf.status)
+			if buf.body.Len() > 0 {
+				ctx.writer.Write(buf.body.Bytes())
+			}
+			return err
+		}
+	}
+}
+
+// WithSignatureVerification menolak request yang body-nya tidak
+// ditandatangani salah satu kunci di Keyring dengan 401.
+func WithSignatureVerification(cfg SignatureConfig) MiddlewareFunc {
+	cfg.defaults()
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			body, err := ctx.BodyBytes()
+			if err != nil {
+				return ctx.BadInput(err)
+			}
+			if !cfg.verify(ctx.request.Header.Get(cfg.Header), body) {
+				return ctx.Unauthorized(faults.ErrUnauthorized)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+func (cfg *SignatureConfig) sign(key SigningKey, body []byte) (string, error) {
+	if cfg.Format == SignatureHMAC {
+		if key.Secret == nil {
+			return "", errors.New("netpath: HMAC signature requires SigningKey.Secret")
+		}
+		return "kid=" + key.ID + ";sha256=" + hex.EncodeToString(hmacSum(key.Secret, body)), nil
+	}
+
+	alg := "HS256"
+	if key.Secret == nil {
+		alg = "EdDSA"
+	}
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": key.ID})
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	input := protected + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	var sig []byte
+	switch {
+	case key.Secret != nil:
+		sig = hmacSum(key.Secret, []byte(input))
+	case key.PrivateKey != nil:
+		sig = ed25519.Sign(key.PrivateKey, []byte(input))
+	default:
+		return "", errors.New("netpath: SigningKey has no private material")
+	}
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func (cfg *SignatureConfig) verify(value string, body []byte) bool {
+	if value == "" {
+		return false
+	}
+
+	if cfg.Format == SignatureHMAC {
+		var kid, sum string
+		for _, part := range strings.Split(value, ";") {
+			k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch k {
+			case "kid":
+				kid = v
+			case "sha256":
+				sum = v
+			}
+		}
+		key, ok := cfg.Keys.Get(kid)
+		want, err := hex.DecodeString(sum)
+		return ok && err == nil && key.Secret != nil && hmac.Equal(hmacSum(key.Secret, body), want)
+	}
+
+	protected, sig, ok := strings.Cut(value, "..")
+	if !ok {
+		return false
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(protected)
+	if err != nil {
+		return false
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if json.Unmarshal(rawHeader, &header) != nil {
+		return false
+	}
+	rawSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	key, ok := cfg.Keys.Get(header.Kid)
+	if !ok {
+		return false
+	}
+
+	input := []byte(protected + "." + base64.RawURLEncoding.EncodeToString(body))
+	switch header.Alg {
+	case "HS256":
+		return key.Secret != nil && hmac.Equal(hmacSum(key.Secret, input), rawSig)
+	case "EdDSA":
+		pub := key.PublicKey
+		if pub == nil && key.PrivateKey != nil {
+			pub = key.PrivateKey.Public().(ed25519.PublicKey)
+		}
+		return pub != nil && ed25519.Verify(pub, input, rawSig)
+	}
+	return false
+}
+
+func hmacSum(secret, data []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// bufferWriter menampung status dan body sampai middleware selesai; header
+// tetap ditulis langsung ke writer asli. Sengaja tanpa Unwrap: Flush atau
+// Hijack lewat http.ResponseController akan mengirim response sebelum
+// ditandatangani atau dienkripsi, jadi keduanya mengembalikan
+// http.ErrNotSupported.
+type bufferWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferWriter) WriteHeader(code int) {
+	if code >= 100 && code < 200 {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(p)
+}
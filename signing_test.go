@@ -0,0 +1,59 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseSignatureCoversFlushedResponse(t *testing.T) {
+	cfg := SignatureConfig{Keys: NewKeyring(SigningKey{ID: "k1", Secret: []byte("secret")})}
+	app := New()
+	var flushErr error
+	app.Route().GET("/report", func(ctx *Context) error {
+		ctx.Writer().Header().Set("Content-Type", "text/plain")
+		ctx.Writer().Write([]byte("part one;"))
+		flushErr = http.NewResponseController(ctx.Writer()).Flush()
+		ctx.Writer().Write([]byte("part two"))
+		return nil
+	}, WithResponseSignature(cfg))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/report", nil))
+
+	if !errors.Is(flushErr, http.ErrNotSupported) {
+		t.Fatalf("Flush through signed writer = %v, want ErrNotSupported", flushErr)
+	}
+	if w.Flushed {
+		t.Fatal("response was flushed before it was signed")
+	}
+	sig := w.Header().Get("X-Signature")
+	if sig == "" {
+		t.Fatal("response is not signed")
+	}
+	if body := w.Body.String(); body != "part one;part two" {
+		t.Fatalf("body = %q", body)
+	}
+	if !cfg.verify(sig, w.Body.Bytes()) {
+		t.Fatalf("signature %q does not cover the body", sig)
+	}
+}
+
+func TestResponseSignatureOnReturnedError(t *testing.T) {
+	cfg := SignatureConfig{Keys: NewKeyring(SigningKey{ID: "k1", Secret: []byte("secret")})}
+	app := New()
+	app.Route().GET("/missing", func(ctx *Context) error {
+		return ctx.NotFound(errors.New("no such report"))
+	}, WithResponseSignature(cfg))
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if w.Code != http.StatusNotFound || !strings.HasPrefix(w.Header().Get("X-Signature"), "kid=k1;") {
+		t.Fatalf("status %d signature %q", w.Code, w.Header().Get("X-Signature"))
+	}
+	if !cfg.verify(w.Header().Get("X-Signature"), w.Body.Bytes()) {
+		t.Fatal("error response signature does not verify")
+	}
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrStreamClosed dikembalikan Send setelah Done ditutup.
+var ErrStreamClosed = errors.New("stream closed")
+
+// SSEStream adalah response text/event-stream yang terdaftar untuk drain
+// saat Shutdown. Send aman dipanggil bersamaan.
+type SSEStream struct {
+	c       *Context
+	mu      sync.Mutex
+	done    chan struct{}
+	once    sync.Once
+	release func()
+	stop    func() bool
+}
+
+// SSE memulai response Server-Sent Events. Handler menulis lewat Send dan
+// berhenti ketika Done ditutup (client pergi atau server memutus setelah
+// drain), lalu memanggil Close:
+//
+//	s := ctx.SSE()
+//	defer s.Close()
+//	for {
+//		select {
+//		case <-s.Done():
+//			return nil
+//		case ev := <-updates:
+//			s.Send("update", ev)
+//		}
+//	}
+func (c *Context) SSE() *SSEStream {
+	h := c.writer.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("X-Accel-Buffering", "no")
+	c.httpStatus = http.StatusOK
+	c.writer.WriteHeader(http.StatusOK)
+	c.flush()
+
+	s := &SSEStream{c: c, done: make(chan struct{})}
+	s.stop = context.AfterFunc(c.request.Context(), s.finish)
+	s.release = c.Hold(s)
+	return s
+}
+
+// Send menulis satu event. data bertipe string dikirim apa adanya, selain
+// itu di-encode sebagai JSON. event kosong berarti event "message".
+func (s *SSEStream) Send(event string, data any) error {
+	var payload string
+	switch v := data.(type) {
+	case string:
+		payload = v
+	case []byte:
+		payload = string(v)
+	default:
+		raw, err := json.Marshal(s.c.masked(v))
+		if err != nil {
+			return err
+		}
+		payload = string(raw)
+	}
+
+	var b strings.Builder
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(payload, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteByte('\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.done:
+		return ErrStreamClosed
+	default:
+	}
+	if _, err := s.c.writer.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	s.c.flush()
+	return nil
+}
+
+// Done ditutup ketika client terputus atau stream dihentikan server.
+func (s *SSEStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close melepas stream dari daftar drain. Aman dipanggil berulang.
+func (s *SSEStream) Close() {
+	s.stop()
+	s.finish()
+	s.release()
+}
+
+// Drain mengirim event terminasi (DrainConfig.Event) supaya client
+// reconnect ke instance lain.
+func (s *SSEStream) Drain() {
+	event := s.c.app.drainCfg.Event
+	if event == "" {
+		event = "shutdown"
+	}
+	s.Send(event, map[string]any{"reconnect": true})
+}
+
+// Abort menutup Done sehingga handler keluar dan response selesai.
+func (s *SSEStream) Abort() error {
+	s.finish()
+	return nil
+}
+
+func (s *SSEStream) finish() {
+	s.once.Do(func() { close(s.done) })
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"iter"
+	"net/http"
+)
+
+// streamFlushEvery adalah jumlah baris di antara flush ke client.
+var streamFlushEvery = 100
+
+func (c *Context) flush() {
+	http.NewResponseController(c.writer).Flush()
+}
+
+// CSV menulis header dan baris secara streaming, flush setiap beberapa baris,
+// dan berhenti jika client terputus.
+func (c *Context) CSV(code int, header []string, rows iter.Seq[[]string]) error {
+	c.writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	c.httpStatus = code
+	c.writer.WriteHeader(code)
+
+	w := csv.NewWriter(c.writer)
+	if len(header) > 0 {
+		if err := w.Write(header); err != nil {
+			return err
+		}
+	}
+
+	done := c.request.Context().Done()
+	n := 0
+	for row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+		n++
+		if n%streamFlushEvery == 0 {
+			w.Flush()
+			if err := w.Error(); err != nil {
+				return err
+			}
+			c.flush()
+
+			select {
+			case <-done:
+				return c.request.Context().Err()
+			default:
+			}
+		}
+	}
+
+	w.Flush()
+	c.flush()
+	return w.Error()
+}
+
+// NDJSON menulis setiap item sebagai satu baris JSON (application/x-ndjson).
+func (c *Context) NDJSON(code int, items iter.Seq[any]) error {
+	c.writer.Header().Set("Content-Type", "application/x-ndjson")
+	c.httpStatus = code
+	c.writer.WriteHeader(code)
+
+	enc := json.NewEncoder(c.writer)
+	mask := c.masker()
+	done := c.request.Context().Done()
+	n := 0
+	for item := range items {
+		if err := enc.Encode(mask(item)); err != nil {
+			return err
+		}
+		n++
+		if n%streamFlushEvery == 0 {
+			c.flush()
+
+			select {
+			case <-done:
+				return c.request.Context().Err()
+			default:
+			}
+		}
+	}
+
+	c.flush()
+	return nil
+}
+
+// JSONStream menulis items sebagai satu array JSON tanpa menampung seluruh
+// slice atau hasil encode di memori. Jika terjadi error di tengah jalan,
+// array tidak ditutup sehingga client melihat JSON yang tidak valid.
+func (c *Context) JSONStream(code int, items iter.Seq[any]) error {
+	c.writer.Header().Set("Content-Type", "application/json")
+	c.httpStatus = code
+	c.writer.WriteHeader(code)
+
+	w := bufio.NewWriter(c.writer)
+	if err := w.WriteByte('['); err != nil {
+		return err
+	}
+
+	mask := c.masker()
+	done := c.request.Context().Done()
+	n := 0
+	for item := range items {
+		if n > 0 {
+			w.WriteByte(',')
+		}
+		raw, err := json.Marshal(mask(item))
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(raw); err != nil {
+			return err
+		}
+		n++
+		if n%streamFlushEvery == 0 {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			c.flush()
+
+			select {
+			case <-done:
+				return c.request.Context().Err()
+			default:
+			}
+		}
+	}
+
+	w.WriteByte(']')
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	c.flush()
+	return nil
+}
+
+// ChanSeq mengubah channel menjadi iter.Seq untuk dipakai dengan CSV, NDJSON, atau JSONStream.
+func ChanSeq[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"database/sql"
+	"net/http"
+
+	tools "github.com/godev90/netpath/database"
+)
+
+// WithTransaction membuka transaksi pada alias untuk setiap request. Transaksi
+// di-commit jika handler mengembalikan nil dan status response < 400, selain
+// itu di-rollback. Helper tools.Exec dan tools.Query yang memakai
+// ctx.Request().Context() otomatis berjalan di dalam transaksi ini.
+func WithTransaction(alias string, opts *sql.TxOptions) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) (err error) {
+			db, err := tools.Pool().Get(alias)
+			if err != nil {
+				return ctx.ServerError(err)
+			}
+
+			tx, err := db.BeginTx(ctx.request.Context(), opts)
+			if err != nil {
+				return ctx.Unavailable(err)
+			}
+
+			committed := false
+			defer func() {
+				if !committed {
+					tx.Rollback()
+				}
+			}()
+
+			ctx.request = ctx.request.WithContext(tools.WithTx(ctx.request.Context(), alias, tx))
+
+			if err = next(ctx); err != nil {
+				return err
+			}
+			if ctx.Status() >= http.StatusBadRequest {
+				return nil
+			}
+
+			if err = tx.Commit(); err != nil {
+				if !ctx.Written() {
+					return ctx.ServerError(err)
+				}
+				return err
+			}
+			committed = true
+			return nil
+		}
+	}
+}
+
+// Tx mengembalikan transaksi aktif untuk alias yang dibuka WithTransaction, atau nil.
+func (c *Context) Tx(alias string) *sql.Tx {
+	return tools.TxFrom(c.request.Context(), alias)
+}
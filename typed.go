@@ -0,0 +1,64 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Handle mendaftarkan handler bertipe. Req diisi dari body JSON (kecuali
+// GET, HEAD, dan DELETE), parameter path (tag `path`), dan query (tag
+// `query`), kemudian divalidasi. Rencana binding dan validasi disusun sekali
+// saat registrasi. Res dikirim lewat ctx.Success jika handler belum menulis
+// response. Tipe Req dan Res dicatat di tabel route sehingga bisa dipakai
+// generator client.
+func Handle[Req, Res any](r *Router, method, path string, fn func(*Context, Req) (Res, error), mws ...MiddlewareFunc) {
+	reqType := reflect.TypeOf((*Req)(nil)).Elem()
+	if reqType.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("netpath: Handle request type must be a struct, got %s", reqType))
+	}
+	method = strings.ToUpper(method)
+	plan := newTypedPlan[Req]()
+	hasBody := method != http.MethodGet && method != http.MethodHead && method != http.MethodDelete
+
+	h := func(ctx *Context) error {
+		var req Req
+		// Body diisi lebih dulu supaya parameter path dan query tidak bisa
+		// ditimpa lewat body.
+		if hasBody {
+			if err := ctx.decodeTypedBody(&req); err != nil {
+				return ctx.BadInput(err)
+			}
+		}
+		if err := plan.bind(ctx, &req); err != nil {
+			return ctx.BadInput(err)
+		}
+
+		res, err := fn(ctx, req)
+		if err != nil {
+			return err
+		}
+		if ctx.Written() {
+			return nil
+		}
+		return ctx.Success(res)
+	}
+
+	entry := r.handle(method, r.prefix+path, h, mws...)
+	entry.request = reqType
+	entry.response = reflect.TypeOf((*Res)(nil)).Elem()
+}
+
+func (c *Context) decodeTypedBody(dest any) error {
+	body, err := c.BodyBytes()
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, dest)
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/godev90/validator"
+	"github.com/godev90/validator/faults"
+)
+
+// typedPlan adalah hasil kompilasi tipe request Handle saat registrasi.
+// Field path dan query diisi lewat closure yang menulis langsung ke offset
+// field, sehingga request biasa tidak menyentuh reflection kecuali untuk
+// decode JSON dan tipe dari RegisterBindType. Validasi memakai
+// validationPlan yang juga disusun saat registrasi.
+type typedPlan[Req any] struct {
+	path  []typedField
+	query []typedField
+	// validate nil berarti Req tidak punya aturan validasi sama sekali.
+	validate func(*Req) error
+}
+
+type typedField struct {
+	key        string
+	def        string
+	hasDefault bool
+	required   bool
+	set        func(p unsafe.Pointer, raw string) error
+}
+
+func newTypedPlan[Req any]() *typedPlan[Req] {
+	t := reflect.TypeOf((*Req)(nil)).Elem()
+	plan := &typedPlan[Req]{
+		path:  typedFields(t, "path"),
+		query: typedFields(t, "query"),
+	}
+
+	switch {
+	case reflect.PointerTo(t).Implements(validatorType):
+		plan.validate = func(req *Req) error {
+			return any(req).(validator.Validator).Validate()
+		}
+	case needsValidation(t, map[reflect.Type]bool{}):
+		vp := validationPlanFor(t)
+		plan.validate = func(req *Req) error {
+			errs := faults.Errors{}
+			vp.validate(reflect.ValueOf(req).Elem(), "", errs)
+			if len(errs) > 0 {
+				return errs
+			}
+			return nil
+		}
+	}
+	return plan
+}
+
+// bind mengisi parameter path dan query ke req lalu memvalidasinya.
+func (p *typedPlan[Req]) bind(c *Context, req *Req) error {
+	ptr := unsafe.Pointer(req)
+	var errs faults.Errors
+	for i := range p.path {
+		raw, ok := c.Params[p.path[i].key]
+		errs = p.path[i].apply(ptr, raw, ok, errs)
+	}
+	if len(p.query) > 0 {
+		q := c.request.URL.Query()
+		for i := range p.query {
+			vals := q[p.query[i].key]
+			raw := ""
+			if len(vals) > 0 {
+				raw = vals[0]
+			}
+			errs = p.query[i].apply(ptr, raw, len(vals) > 0, errs)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if p.validate == nil {
+		return nil
+	}
+	return p.validate(req)
+}
+
+// apply mengikuti aturan assignValues untuk default dan required.
+func (f *typedField) apply(ptr unsafe.Pointer, raw string, present bool, errs faults.Errors) faults.Errors {
+	var err error
+	switch {
+	case present && (raw != "" || !(f.hasDefault || f.required)):
+		err = f.set(ptr, raw)
+	case f.hasDefault:
+		err = f.set(ptr, f.def)
+	case f.required:
+		if errs == nil {
+			errs = faults.Errors{}
+		}
+		errs[f.key] = faults.ErrRequired
+	}
+	if err != nil {
+		if errs == nil {
+			errs = faults.Errors{}
+		}
+		errs[f.key] = faults.ErrInvalidParameter.Render(f.key)
+	}
+	return errs
+}
+
+func typedFields(t reflect.Type, tag string) []typedField {
+	var fields []typedField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tagValue := sf.Tag.Get(tag)
+		if tagValue == "" {
+			continue
+		}
+		set := fastSetter(t, i)
+		if set == nil {
+			continue
+		}
+
+		var bf bindField
+		parseBindTag(tagValue, &bf)
+		fields = append(fields, typedField{
+			key:        bf.key,
+			def:        bf.def,
+			hasDefault: bf.hasDefault,
+			required:   bf.required,
+			set:        set,
+		})
+	}
+	return fields
+}
+
+// fastSetter membuat setter untuk field ke-i dari struct t. Tipe dasar
+// ditulis langsung lewat pointer; sisanya (pointer dan tipe dari
+// RegisterBindType) memakai setterFor seperti BindQuery. Parsing mengikuti
+// setterFor: angka dan bool yang tidak valid menjadi nilai nol.
+func fastSetter(t reflect.Type, i int) func(unsafe.Pointer, string) error {
+	sf := t.Field(i)
+	off := sf.Offset
+
+	if _, custom := bindConverters.Load(sf.Type); !custom {
+		switch sf.Type {
+		case reflect.TypeFor[string]():
+			return func(p unsafe.Pointer, raw string) error {
+				*(*string)(unsafe.Add(p, off)) = raw
+				return nil
+			}
+		case reflect.TypeFor[int]():
+			return func(p unsafe.Pointer, raw string) error {
+				n, _ := strconv.ParseInt(raw, 10, 64)
+				*(*int)(unsafe.Add(p, off)) = int(n)
+				return nil
+			}
+		case reflect.TypeFor[int64]():
+			return func(p unsafe.Pointer, raw string) error {
+				n, _ := strconv.ParseInt(raw, 10, 64)
+				*(*int64)(unsafe.Add(p, off)) = n
+				return nil
+			}
+		case reflect.TypeFor[float64]():
+			return func(p unsafe.Pointer, raw string) error {
+				f, _ := strconv.ParseFloat(raw, 64)
+				*(*float64)(unsafe.Add(p, off)) = f
+				return nil
+			}
+		case reflect.TypeFor[bool]():
+			return func(p unsafe.Pointer, raw string) error {
+				b, _ := strconv.ParseBool(raw)
+				*(*bool)(unsafe.Add(p, off)) = b
+				return nil
+			}
+		}
+	}
+
+	set := setterFor(sf.Type)
+	if set == nil {
+		return nil
+	}
+	return func(p unsafe.Pointer, raw string) error {
+		return set(reflect.NewAt(t, p).Elem().Field(i), raw)
+	}
+}
+
+var validatorType = reflect.TypeFor[validator.Validator]()
+
+// needsValidation melaporkan apakah validateStruct punya sesuatu untuk
+// diperiksa pada t: tag validation atau tipe yang mengimplementasikan
+// validator.Validator, di level mana pun.
+func needsValidation(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	if t.Implements(validatorType) || reflect.PointerTo(t).Implements(validatorType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			if sf.Tag.Get("validation") != "" || needsValidation(sf.Type, seen) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return needsValidation(t.Elem(), seen)
+	case reflect.Interface:
+		return true
+	}
+	return false
+}
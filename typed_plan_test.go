@@ -0,0 +1,101 @@
+package app
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/godev90/validator/faults"
+)
+
+type benchAddress struct {
+	City string `json:"city" validation:"required,name"`
+	Zip  string `json:"zip" validation:"required,digit,minlen=5,maxlen=5"`
+}
+
+type benchOrderRequest struct {
+	ID      int64          `path:"id"`
+	Page    int            `query:"page,default=1"`
+	Sort    string         `query:"sort,default=created_at"`
+	Email   string         `json:"email" validation:"required,email"`
+	Name    string         `json:"name" validation:"required,name,maxlen=64"`
+	Note    *string        `json:"note" validation:"maxlen=200"`
+	Address benchAddress   `json:"address"`
+	Items   []benchAddress `json:"items"`
+}
+
+func benchOrderContext() (*Context, benchOrderRequest) {
+	r := httptest.NewRequest("GET", "/orders/42?page=3&sort=total", nil)
+	ctx := &Context{request: r, Params: map[string]string{"id": "42"}}
+	body := benchOrderRequest{
+		Email:   "buyer@example.com",
+		Name:    "Buyer",
+		Address: benchAddress{City: "Bandung", Zip: "40115"},
+		Items:   []benchAddress{{City: "Jakarta", Zip: "10110"}, {City: "Depok", Zip: "16411"}},
+	}
+	return ctx, body
+}
+
+// BenchmarkTypedBind membandingkan plan Handle dengan jalur reflektif
+// (assignValues per tag lalu validateValue).
+func BenchmarkTypedBind(b *testing.B) {
+	b.Run("plan", func(b *testing.B) {
+		plan := newTypedPlan[benchOrderRequest]()
+		ctx, body := benchOrderContext()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			req := body
+			if err := plan.bind(ctx, &req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reflective", func(b *testing.B) {
+		ctx, body := benchOrderContext()
+		params := map[string][]string{"id": {"42"}}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			req := body
+			if errs := assignValues(params, &req, "path"); len(errs) > 0 {
+				b.Fatal(errs)
+			}
+			if errs := assignValues(ctx.request.URL.Query(), &req, "query"); len(errs) > 0 {
+				b.Fatal(errs)
+			}
+			errs := faults.Errors{}
+			validateValue(reflect.ValueOf(&req), "", errs)
+			if len(errs) > 0 {
+				b.Fatal(errs)
+			}
+		}
+	})
+}
+
+// BenchmarkTypedValidate mengukur validasi saja, tanpa binding.
+func BenchmarkTypedValidate(b *testing.B) {
+	_, body := benchOrderContext()
+
+	b.Run("plan", func(b *testing.B) {
+		vp := validationPlanFor(reflect.TypeOf(body))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			errs := faults.Errors{}
+			vp.validate(reflect.ValueOf(&body).Elem(), "", errs)
+			if len(errs) > 0 {
+				b.Fatal(errs)
+			}
+		}
+	})
+
+	b.Run("reflective", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			errs := faults.Errors{}
+			validateValue(reflect.ValueOf(&body), "", errs)
+			if len(errs) > 0 {
+				b.Fatal(errs)
+			}
+		}
+	})
+}
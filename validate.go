@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/godev90/validator"
+	"github.com/godev90/validator/faults"
+)
+
+// validateStruct menjalankan validator pada dest beserta struct bersarang,
+// elemen slice, dan value map. Error dilaporkan dengan path lengkap seperti
+// "items[2].price" supaya client tahu elemen mana yang salah. Jika dest
+// mengimplementasikan validator.Validator, hasilnya dipakai apa adanya.
+// Aturan per tipe dikompilasi sekali lewat validationPlanFor.
+func validateStruct(dest any) error {
+	if validate, ok := dest.(validator.Validator); ok {
+		return validate.Validate()
+	}
+
+	v := reflect.ValueOf(dest)
+	if !v.IsValid() {
+		return nil
+	}
+	plan := validationPlanFor(v.Type())
+	if plan.validate == nil {
+		return nil
+	}
+	errs := faults.Errors{}
+	plan.validate(v, "", errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateValue adalah jalur reflektif tanpa plan, dipakai untuk field
+// interface yang tipenya baru diketahui saat request.
+func validateValue(v reflect.Value, path string, errs faults.Errors) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var err error
+		if val, ok := asValidator(v); ok && path != "" {
+			err = val.Validate()
+		} else {
+			err = validator.ValidateStruct(v.Interface())
+		}
+		mergeErrors(errs, path, err)
+
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			name := fieldName(sf)
+			if name == "" {
+				continue
+			}
+			if _, failed := errs[joinPath(path, name)]; failed {
+				continue
+			}
+			validateValue(v.Field(i), joinPath(path, name), errs)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			validateValue(iter.Value(), joinPath(path, iter.Key().String()), errs)
+		}
+	}
+}
+
+func asValidator(v reflect.Value) (validator.Validator, bool) {
+	if v.CanAddr() {
+		if val, ok := v.Addr().Interface().(validator.Validator); ok {
+			return val, true
+		}
+	}
+	val, ok := v.Interface().(validator.Validator)
+	return val, ok
+}
+
+func fieldName(sf reflect.StructField) string {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	return sf.Name
+}
+
+func mergeErrors(errs faults.Errors, path string, err error) {
+	if err == nil {
+		return
+	}
+	if nested, ok := err.(faults.Errors); ok {
+		for k, e := range nested {
+			errs[joinPath(path, k)] = e
+		}
+		return
+	}
+	if path == "" {
+		path = "body"
+	}
+	errs[path] = err
+}
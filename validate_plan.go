@@ -0,0 +1,238 @@
+package app
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/godev90/validator"
+	"github.com/godev90/validator/faults"
+)
+
+// validationPlan adalah validateValue yang sudah dikompilasi untuk satu tipe:
+// tag validation dipecah sekali dan hanya field yang punya aturan, atau yang
+// isinya perlu divalidasi, yang dikunjungi. Hasilnya sama dengan
+// validateValue.
+type validationPlan struct {
+	// validate nil berarti tidak ada yang perlu diperiksa pada tipe ini.
+	validate func(v reflect.Value, path string, errs faults.Errors)
+}
+
+type ruleField struct {
+	index    int
+	name     string // key error, seperti validator.ValidateStruct
+	ptr      bool
+	required bool
+	rules    []fieldRule
+}
+
+type fieldRule struct {
+	name  string
+	param string
+}
+
+var (
+	validationPlans   sync.Map // reflect.Type -> *validationPlan
+	validationPlansMu sync.Mutex
+)
+
+// validationPlanFor mengembalikan plan untuk t, menyusunnya sekali per tipe.
+func validationPlanFor(t reflect.Type) *validationPlan {
+	if p, ok := validationPlans.Load(t); ok {
+		return p.(*validationPlan)
+	}
+
+	validationPlansMu.Lock()
+	defer validationPlansMu.Unlock()
+	if p, ok := validationPlans.Load(t); ok {
+		return p.(*validationPlan)
+	}
+	// plan baru disimpan setelah lengkap; tipe rekursif memakai building
+	building := map[reflect.Type]*validationPlan{}
+	plan := compileValidation(t, building)
+	for typ, p := range building {
+		validationPlans.Store(typ, p)
+	}
+	return plan
+}
+
+func compileValidation(t reflect.Type, building map[reflect.Type]*validationPlan) *validationPlan {
+	if p, ok := validationPlans.Load(t); ok {
+		return p.(*validationPlan)
+	}
+	if p, ok := building[t]; ok {
+		return p
+	}
+	plan := &validationPlan{}
+	building[t] = plan
+	if !needsValidation(t, map[reflect.Type]bool{}) {
+		return plan
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := compileValidation(t.Elem(), building)
+		plan.validate = func(v reflect.Value, path string, errs faults.Errors) {
+			if !v.IsNil() {
+				elem.validate(v.Elem(), path, errs)
+			}
+		}
+
+	case reflect.Interface:
+		// tipe dinamis baru diketahui saat request
+		plan.validate = validateValue
+
+	case reflect.Struct:
+		plan.validate = compileStruct(t, building)
+
+	case reflect.Slice, reflect.Array:
+		elem := compileValidation(t.Elem(), building)
+		plan.validate = func(v reflect.Value, path string, errs faults.Errors) {
+			for i := 0; i < v.Len(); i++ {
+				elem.validate(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+			}
+		}
+
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			plan.validate = func(reflect.Value, string, faults.Errors) {}
+			return plan
+		}
+		elem := compileValidation(t.Elem(), building)
+		plan.validate = func(v reflect.Value, path string, errs faults.Errors) {
+			iter := v.MapRange()
+			for iter.Next() {
+				elem.validate(iter.Value(), joinPath(path, iter.Key().String()), errs)
+			}
+		}
+	}
+	return plan
+}
+
+func compileStruct(t reflect.Type, building map[reflect.Type]*validationPlan) func(reflect.Value, string, faults.Errors) {
+	type nestedField struct {
+		index int
+		name  string
+		plan  *validationPlan
+	}
+
+	var (
+		rules  []ruleField
+		nested []nestedField
+	)
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		if f, ok := compileRules(sf, i); ok {
+			rules = append(rules, f)
+		}
+		if name := fieldName(sf); name != "" && needsValidation(sf.Type, map[reflect.Type]bool{}) {
+			nested = append(nested, nestedField{index: i, name: name, plan: compileValidation(sf.Type, building)})
+		}
+	}
+	custom := t.Implements(validatorType) || reflect.PointerTo(t).Implements(validatorType)
+
+	return func(v reflect.Value, path string, errs faults.Errors) {
+		var err error
+		val, ok := validator.Validator(nil), false
+		if custom && path != "" {
+			val, ok = asValidator(v)
+		}
+		if ok {
+			err = val.Validate()
+		} else {
+			err = checkRules(v, rules)
+		}
+		mergeErrors(errs, path, err)
+
+		for _, f := range nested {
+			p := joinPath(path, f.name)
+			if _, failed := errs[p]; failed {
+				continue
+			}
+			f.plan.validate(v.Field(f.index), p, errs)
+		}
+	}
+}
+
+// compileRules memecah tag validation field seperti validator.ValidateStruct.
+func compileRules(sf reflect.StructField, index int) (ruleField, bool) {
+	tag := sf.Tag.Get("validation")
+	if tag == "" {
+		return ruleField{}, false
+	}
+
+	name := sf.Tag.Get("json")
+	if name == "" || name == "-" {
+		name = sf.Name
+	} else {
+		name, _, _ = strings.Cut(name, ",")
+	}
+
+	f := ruleField{index: index, name: name, ptr: sf.Type.Kind() == reflect.Ptr}
+	for _, rule := range strings.Split(tag, ",") {
+		rule = strings.TrimSpace(rule)
+		ruleName, param, _ := strings.Cut(rule, "=")
+		if ruleName == "required" {
+			f.required = true
+		}
+		f.rules = append(f.rules, fieldRule{name: ruleName, param: param})
+	}
+	return f, true
+}
+
+// checkRules setara dengan validator.ValidateStruct untuk field yang sudah
+// dikompilasi. Rule dicari saat dipanggil sehingga RegisterValidator setelah
+// registrasi route tetap berlaku.
+func checkRules(v reflect.Value, fields []ruleField) error {
+	var errs faults.Errors
+	fail := func(name string, err error) {
+		if errs == nil {
+			errs = faults.Errors{}
+		}
+		errs[name] = err
+	}
+
+	for i := range fields {
+		f := &fields[i]
+		field := v.Field(f.index)
+		if f.ptr {
+			if field.IsNil() {
+				if f.required {
+					if fn, ok := validator.GetValidator("required"); ok {
+						if err := fn(nil, ""); err != nil {
+							fail(f.name, err)
+						}
+					}
+				}
+				continue
+			}
+			field = field.Elem()
+		}
+
+		var value any
+		for _, rule := range f.rules {
+			if _, failed := errs[f.name]; failed {
+				break
+			}
+			fn, ok := validator.GetValidator(rule.name)
+			if !ok {
+				continue
+			}
+			if value == nil {
+				value = field.Interface()
+			}
+			if err := fn(value, rule.param); err != nil {
+				fail(f.name, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
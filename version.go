@@ -0,0 +1,107 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VersionConfig menandai versi API lama. Deprecated dan Sunset yang tidak
+// nol dikirim sebagai header Deprecation (RFC 9745) dan Sunset (RFC 8594).
+type VersionConfig struct {
+	Deprecated time.Time
+	Sunset     time.Time
+	// Link menunjuk dokumentasi migrasi, dikirim sebagai Link rel="deprecation".
+	Link string
+}
+
+// APIVersion adalah informasi versi yang ditampilkan ke client.
+type APIVersion struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Deprecated *time.Time `json:"deprecated,omitempty"`
+	Sunset     *time.Time `json:"sunset,omitempty"`
+}
+
+type apiVersion struct {
+	name string
+	cfg  VersionConfig
+}
+
+// Version mengembalikan group "/{name}" untuk satu versi API. Setelah ada
+// versi terdaftar, request ke "/" dijawab dengan daftar versi, dan request
+// tanpa prefix versi yang tidak cocok dengan route mendapat 404 berisi
+// daftar yang sama.
+func (app *App) Version(name string, cfg ...VersionConfig) *Router {
+	var c VersionConfig
+	if len(cfg) > 0 {
+		c = cfg[0]
+	}
+	app.versions = append(app.versions, apiVersion{name: name, cfg: c})
+
+	var mws []MiddlewareFunc
+	if !c.Deprecated.IsZero() || !c.Sunset.IsZero() {
+		mws = append(mws, deprecationHeaders(c))
+	}
+	return app.router.Group("/"+name, mws...)
+}
+
+// Versions mengembalikan versi API terdaftar sesuai urutan Version.
+func (app *App) Versions() []APIVersion {
+	list := make([]APIVersion, 0, len(app.versions))
+	for _, v := range app.versions {
+		info := APIVersion{Name: v.name, Prefix: "/" + v.name}
+		if !v.cfg.Deprecated.IsZero() {
+			info.Deprecated = &v.cfg.Deprecated
+		}
+		if !v.cfg.Sunset.IsZero() {
+			info.Sunset = &v.cfg.Sunset
+		}
+		list = append(list, info)
+	}
+	return list
+}
+
+func deprecationHeaders(cfg VersionConfig) MiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			h := ctx.writer.Header()
+			if !cfg.Deprecated.IsZero() {
+				h.Set("Deprecation", "@"+strconv.FormatInt(cfg.Deprecated.Unix(), 10))
+			}
+			if !cfg.Sunset.IsZero() {
+				h.Set("Sunset", cfg.Sunset.UTC().Format(http.TimeFormat))
+			}
+			if cfg.Link != "" {
+				h.Add("Link", "<"+cfg.Link+`>; rel="deprecation"`)
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// versionIndex menangani request tanpa prefix versi yang tidak cocok dengan route.
+func (app *App) versionIndex(path string) HandlerFunc {
+	if len(app.versions) == 0 {
+		return nil
+	}
+	first, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	for _, v := range app.versions {
+		if v.name == first {
+			return nil
+		}
+	}
+
+	return func(ctx *Context) error {
+		body := map[string]any{"versions": app.Versions()}
+		if path == "/" {
+			return ctx.Success(body)
+		}
+		ctx.httpStatus = http.StatusNotFound
+		return ctx.JSON(http.StatusNotFound, map[string]any{
+			"code": http.StatusNotFound,
+			"data": body,
+		})
+	}
+}
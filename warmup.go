@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godev90/validator/faults"
+)
+
+// WarmupFunc menyiapkan aplikasi sebelum menerima traffic, misalnya mengisi
+// cache, meng-compile template, atau memastikan migrasi sudah dijalankan.
+type WarmupFunc func(ctx context.Context) error
+
+// Warmup mendaftarkan fn yang dijalankan Run setelah OnStart module dan
+// sebelum listener dibuka, sesuai urutan pendaftaran. Warmup yang gagal
+// membatalkan startup.
+func (app *App) Warmup(fns ...WarmupFunc) {
+	app.warmups = append(app.warmups, fns...)
+}
+
+func (app *App) runWarmups(ctx context.Context) error {
+	for i, fn := range app.warmups {
+		if err := fn(ctx); err != nil {
+			return fmt.Errorf("netpath: warmup #%d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Ready melaporkan apakah semua warmup sudah selesai dan server sedang
+// melayani request. Ready kembali false begitu Shutdown dipanggil.
+func (app *App) Ready() bool {
+	return app.ready.Load()
+}
+
+// Readiness adalah handler untuk readiness probe: 200 jika Ready, 503 jika
+// belum atau sedang shutdown.
+func (app *App) Readiness(ctx *Context) error {
+	if !app.Ready() {
+		return ctx.Unavailable(faults.ErrServiceUnavailable)
+	}
+	return ctx.Success(map[string]any{"ready": true})
+}
@@ -0,0 +1,89 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+)
+
+// DefaultTolerance adalah selisih maksimum timestamp delivery dengan jam
+// penerima bila Receiver.Tolerance kosong.
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrInvalidSignature  = errors.New("webhooks: invalid signature")
+	ErrStaleTimestamp    = errors.New("webhooks: timestamp missing or outside tolerance")
+	ErrDuplicateDelivery = errors.New("webhooks: delivery already received")
+)
+
+// Receiver memverifikasi delivery masuk di sisi penerima: signature, umur
+// timestamp, dan delivery yang sudah pernah diterima.
+type Receiver struct {
+	Secret string
+	// Tolerance adalah selisih maksimum timestamp dengan jam lokal, ke dua
+	// arah. Default DefaultTolerance.
+	Tolerance time.Duration
+	// Seen mencatat delivery yang sudah diterima. Nil berarti tanpa
+	// penolakan delivery ganda.
+	Seen cache.Cache
+}
+
+// Verify memeriksa header signature dan timestamp r terhadap body, lalu
+// mencatat delivery di Seen. Delivery yang ID atau signature-nya sudah
+// tercatat ditolak dengan ErrDuplicateDelivery; signature ikut dicatat karena
+// header ID tidak ditandatangani. Catatan disimpan selama dua kali Tolerance,
+// rentang waktu request yang sama masih lolos pemeriksaan timestamp.
+//
+// Jika pemrosesan delivery gagal, panggil Release supaya retry dari pengirim
+// (ID sama, timestamp baru) tetap diterima.
+func (rc *Receiver) Verify(ctx context.Context, r *http.Request, body []byte) error {
+	tolerance := rc.tolerance()
+	signature := r.Header.Get(HeaderSignature)
+	if err := verify(rc.Secret, r.Header.Get(HeaderTimestamp), body, signature, tolerance, time.Now()); err != nil {
+		return err
+	}
+	if rc.Seen == nil {
+		return nil
+	}
+
+	keys := []string{signatureKey(signature)}
+	if id := r.Header.Get(HeaderDelivery); id != "" {
+		keys = append(keys, deliveryKey(id))
+	}
+	for _, key := range keys {
+		_, err := rc.Seen.Get(ctx, key)
+		if err == nil {
+			return ErrDuplicateDelivery
+		}
+		if !errors.Is(err, cache.ErrMiss) {
+			return err
+		}
+	}
+	for _, key := range keys {
+		if err := rc.Seen.Set(ctx, key, []byte{1}, 2*tolerance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Release menghapus catatan delivery id dari Seen.
+func (rc *Receiver) Release(ctx context.Context, id string) error {
+	if rc.Seen == nil {
+		return nil
+	}
+	return rc.Seen.Delete(ctx, deliveryKey(id))
+}
+
+func (rc *Receiver) tolerance() time.Duration {
+	if rc.Tolerance > 0 {
+		return rc.Tolerance
+	}
+	return DefaultTolerance
+}
+
+func deliveryKey(id string) string         { return "webhooks:delivery:" + id }
+func signatureKey(signature string) string { return "webhooks:signature:" + signature }
@@ -0,0 +1,151 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+)
+
+// memoryCache adalah cache.Cache di memori untuk test; ttl diabaikan.
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.items[key]
+	if !ok {
+		return nil, cache.ErrMiss
+	}
+	return v, nil
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	return nil
+}
+
+func (c *memoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+	return nil
+}
+
+func signedRequest(secret, id string, at time.Time, body string) *http.Request {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	r := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader([]byte(body)))
+	r.Header.Set(HeaderDelivery, id)
+	r.Header.Set(HeaderTimestamp, ts)
+	r.Header.Set(HeaderSignature, Sign(secret, ts, []byte(body)))
+	return r
+}
+
+func TestReceiverVerify(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		req  *http.Request
+		body string
+		want error
+	}{
+		{"valid", signedRequest("s3cret", "d1", now, `{"a":1}`), `{"a":1}`, nil},
+		{"clock skew within tolerance", signedRequest("s3cret", "d2", now.Add(-4*time.Minute), `{}`), `{}`, nil},
+		{"future within tolerance", signedRequest("s3cret", "d3", now.Add(4*time.Minute), `{}`), `{}`, nil},
+		{"too old", signedRequest("s3cret", "d4", now.Add(-6*time.Minute), `{}`), `{}`, ErrStaleTimestamp},
+		{"too far ahead", signedRequest("s3cret", "d5", now.Add(6*time.Minute), `{}`), `{}`, ErrStaleTimestamp},
+		{"tampered body", signedRequest("s3cret", "d6", now, `{"a":1}`), `{"a":2}`, ErrInvalidSignature},
+		{"wrong secret", signedRequest("other", "d7", now, `{}`), `{}`, ErrInvalidSignature},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rc := &Receiver{Secret: "s3cret", Seen: &memoryCache{items: map[string][]byte{}}}
+			if err := rc.Verify(context.Background(), tc.req, []byte(tc.body)); !errors.Is(err, tc.want) {
+				t.Fatalf("Verify = %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	// signature tanpa pemeriksaan umur bisa di-replay kapan saja
+	old := signedRequest("s3cret", "d8", now.Add(-time.Hour), `{}`)
+	if Verify("s3cret", old.Header.Get(HeaderTimestamp), []byte(`{}`), old.Header.Get(HeaderSignature)) {
+		t.Fatal("Verify accepted an hour-old delivery")
+	}
+
+	rc := &Receiver{Secret: "s3cret", Tolerance: 2 * time.Hour}
+	if err := rc.Verify(context.Background(), old, []byte(`{}`)); err != nil {
+		t.Fatalf("custom tolerance: %v", err)
+	}
+}
+
+func TestReceiverRejectsReplay(t *testing.T) {
+	ctx := context.Background()
+	rc := &Receiver{Secret: "s3cret", Seen: &memoryCache{items: map[string][]byte{}}}
+	now := time.Now()
+
+	first := signedRequest("s3cret", "d1", now, `{}`)
+	if err := rc.Verify(ctx, first, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Verify(ctx, first, []byte(`{}`)); !errors.Is(err, ErrDuplicateDelivery) {
+		t.Fatalf("replay: %v, want ErrDuplicateDelivery", err)
+	}
+
+	// ID baru pada request yang sama tetap ditolak lewat signature-nya
+	forged := signedRequest("s3cret", "d1", now, `{}`)
+	forged.Header.Set(HeaderDelivery, "forged")
+	if err := rc.Verify(ctx, forged, []byte(`{}`)); !errors.Is(err, ErrDuplicateDelivery) {
+		t.Fatalf("replay with new ID: %v, want ErrDuplicateDelivery", err)
+	}
+
+	// retry pengirim memakai ID sama dengan timestamp baru
+	retry := signedRequest("s3cret", "d1", now.Add(time.Second), `{}`)
+	if err := rc.Verify(ctx, retry, []byte(`{}`)); !errors.Is(err, ErrDuplicateDelivery) {
+		t.Fatalf("retry of accepted delivery: %v, want ErrDuplicateDelivery", err)
+	}
+	if err := rc.Release(ctx, "d1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Verify(ctx, retry, []byte(`{}`)); err != nil {
+		t.Fatalf("retry after Release: %v", err)
+	}
+}
+
+func TestDispatcherDeliveryVerifies(t *testing.T) {
+	rc := &Receiver{Secret: "s3cret", Seen: &memoryCache{items: map[string][]byte{}}}
+	results := make(chan error, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		results <- rc.Verify(r.Context(), r, body)
+	}))
+	defer srv.Close()
+
+	d := New(Config{MaxAttempts: 1})
+	defer d.Close()
+	d.Register(Endpoint{ID: "ep", URL: srv.URL, Secret: "s3cret", Events: []string{"order.paid"}})
+	if _, err := d.Publish(context.Background(), "order.paid", map[string]int{"id": 7}); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-results:
+		if err != nil {
+			t.Fatalf("receiver rejected dispatcher delivery: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery not received")
+	}
+}
@@ -0,0 +1,146 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tools "github.com/godev90/netpath/database"
+)
+
+// SQLStore menyimpan status terakhir delivery di Table dan setiap percobaan di
+// AttemptsTable pada alias DB pool. Placeholder mengikuti driver alias.
+// Skema yang diharapkan:
+//
+//	CREATE TABLE webhook_deliveries (
+//		id           VARCHAR(32) PRIMARY KEY,
+//		endpoint_id  VARCHAR(64) NOT NULL,
+//		event        VARCHAR(128) NOT NULL,
+//		payload      TEXT NOT NULL,
+//		attempt      INT NOT NULL,
+//		status_code  INT NOT NULL,
+//		error        TEXT,
+//		delivered    BOOLEAN NOT NULL,
+//		created_at   TIMESTAMP NOT NULL,
+//		attempted_at TIMESTAMP NOT NULL
+//	);
+//
+//	CREATE TABLE webhook_delivery_attempts (
+//		delivery_id  VARCHAR(32) NOT NULL,
+//		attempt      INT NOT NULL,
+//		status_code  INT NOT NULL,
+//		error        TEXT,
+//		delivered    BOOLEAN NOT NULL,
+//		attempted_at TIMESTAMP NOT NULL,
+//		PRIMARY KEY (delivery_id, attempt)
+//	);
+type SQLStore struct {
+	Alias         string
+	Table         string
+	AttemptsTable string
+}
+
+func NewSQLStore(alias string) *SQLStore {
+	return &SQLStore{Alias: alias, Table: "webhook_deliveries", AttemptsTable: "webhook_delivery_attempts"}
+}
+
+func (s *SQLStore) ph(n int) string {
+	if tools.Pool().Driver(s.Alias) == "postgres" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (s *SQLStore) SaveAttempt(ctx context.Context, d Delivery) error {
+	db, err := tools.Pool().Get(s.Alias)
+	if err != nil {
+		return err
+	}
+
+	update := fmt.Sprintf("UPDATE %s SET attempt = %s, status_code = %s, error = %s, delivered = %s, attempted_at = %s WHERE id = %s",
+		s.Table, s.ph(1), s.ph(2), s.ph(3), s.ph(4), s.ph(5), s.ph(6))
+	res, err := db.ExecContext(ctx, update, d.Attempt, d.StatusCode, d.Error, d.Delivered, d.AttemptedAt, d.ID)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		insert := fmt.Sprintf("INSERT INTO %s (id, endpoint_id, event, payload, attempt, status_code, error, delivered, created_at, attempted_at) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)",
+			s.Table, s.ph(1), s.ph(2), s.ph(3), s.ph(4), s.ph(5), s.ph(6), s.ph(7), s.ph(8), s.ph(9), s.ph(10))
+		_, err = db.ExecContext(ctx, insert, d.ID, d.EndpointID, d.Event, string(d.Payload),
+			d.Attempt, d.StatusCode, d.Error, d.Delivered, d.CreatedAt, d.AttemptedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	attempt := fmt.Sprintf("INSERT INTO %s (delivery_id, attempt, status_code, error, delivered, attempted_at) VALUES (%s, %s, %s, %s, %s, %s)",
+		s.AttemptsTable, s.ph(1), s.ph(2), s.ph(3), s.ph(4), s.ph(5), s.ph(6))
+	_, err = db.ExecContext(ctx, attempt, d.ID, d.Attempt, d.StatusCode, d.Error, d.Delivered, d.AttemptedAt)
+	return err
+}
+
+// History mengembalikan semua percobaan delivery id, urut dari yang pertama.
+// Setiap elemen berisi data delivery dengan hasil percobaan tersebut.
+func (s *SQLStore) History(ctx context.Context, id string) ([]Delivery, error) {
+	base, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	db, err := tools.Pool().Get(s.Alias)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT attempt, status_code, error, delivered, attempted_at FROM %s WHERE delivery_id = %s ORDER BY attempt",
+		s.AttemptsTable, s.ph(1))
+	rows, err := db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []Delivery
+	for rows.Next() {
+		d := base
+		var errText *string
+		if err := rows.Scan(&d.Attempt, &d.StatusCode, &errText, &d.Delivered, &d.AttemptedAt); err != nil {
+			return nil, err
+		}
+		d.Error = ""
+		if errText != nil {
+			d.Error = *errText
+		}
+		history = append(history, d)
+	}
+	return history, rows.Err()
+}
+
+func (s *SQLStore) Get(ctx context.Context, id string) (Delivery, error) {
+	db, err := tools.Pool().Get(s.Alias)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	query := fmt.Sprintf("SELECT id, endpoint_id, event, payload, attempt, status_code, error, delivered, created_at, attempted_at FROM %s WHERE id = %s",
+		s.Table, s.ph(1))
+
+	var (
+		d       Delivery
+		payload string
+		errText *string
+		created time.Time
+		tried   time.Time
+	)
+	err = db.QueryRowContext(ctx, query, id).Scan(&d.ID, &d.EndpointID, &d.Event, &payload,
+		&d.Attempt, &d.StatusCode, &errText, &d.Delivered, &created, &tried)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	d.Payload = []byte(payload)
+	if errText != nil {
+		d.Error = *errText
+	}
+	d.CreatedAt, d.AttemptedAt = created, tried
+	return d, nil
+}
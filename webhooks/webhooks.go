@@ -0,0 +1,312 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+type Endpoint struct {
+	ID     string
+	URL    string
+	Secret string
+	Events []string
+}
+
+type Delivery struct {
+	ID          string
+	EndpointID  string
+	Event       string
+	Payload     []byte
+	Attempt     int
+	StatusCode  int
+	Error       string
+	Delivered   bool
+	CreatedAt   time.Time
+	AttemptedAt time.Time
+}
+
+// Store menyimpan setiap percobaan pengiriman supaya bisa diaudit dan dikirim ulang.
+// SaveAttempt dipanggil sekali per percobaan; nomor Attempt terus bertambah,
+// termasuk saat Redeliver, sehingga (ID, Attempt) unik dan riwayat lama tidak
+// perlu ditimpa.
+type Store interface {
+	SaveAttempt(ctx context.Context, d Delivery) error
+	Get(ctx context.Context, id string) (Delivery, error)
+}
+
+type Config struct {
+	MaxAttempts int
+	// InitialBackoff digandakan setiap percobaan gagal, dibatasi MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Timeout        time.Duration
+	Store          Store
+}
+
+var ErrUnknownEndpoint = errors.New("webhooks: unknown endpoint")
+
+type Dispatcher struct {
+	cfg    Config
+	client *http.Client
+
+	mu        sync.RWMutex
+	endpoints map[string]Endpoint
+	byEvent   map[string][]string
+
+	// ctx dibatalkan Close untuk menghentikan retry yang sedang menunggu
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(cfg Config) *Dispatcher {
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.InitialBackoff == 0 {
+		cfg.InitialBackoff = time.Second
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = 5 * time.Minute
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		cfg:       cfg,
+		client:    &http.Client{Timeout: cfg.Timeout},
+		endpoints: make(map[string]Endpoint),
+		byEvent:   make(map[string][]string),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Close menghentikan pengiriman yang sedang berjalan atau menunggu retry dan
+// menunggu goroutine-nya selesai, misalnya saat shutdown. Delivery yang
+// terhenti bisa dikirim ulang lewat Redeliver.
+func (d *Dispatcher) Close() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// Register mendaftarkan endpoint untuk event-event yang disebutkan. Event "*"
+// berarti endpoint menerima semua event. Mendaftarkan ID yang sama lagi
+// mengganti URL, secret, dan daftar event endpoint tersebut.
+func (d *Dispatcher) Register(ep Endpoint) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if old, ok := d.endpoints[ep.ID]; ok {
+		for _, event := range old.Events {
+			d.byEvent[event] = slices.DeleteFunc(d.byEvent[event], func(id string) bool { return id == ep.ID })
+		}
+	}
+	d.endpoints[ep.ID] = ep
+	for _, event := range ep.Events {
+		if !slices.Contains(d.byEvent[event], ep.ID) {
+			d.byEvent[event] = append(d.byEvent[event], ep.ID)
+		}
+	}
+}
+
+// Publish mengirim payload ke semua endpoint yang berlangganan event secara
+// asynchronous. Nilai kembalian adalah ID delivery per endpoint.
+func (d *Dispatcher) Publish(ctx context.Context, event string, payload any) ([]string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	// endpoint yang berlangganan event dan "*" hanya menerima satu delivery
+	d.mu.RLock()
+	ids := append(append([]string{}, d.byEvent[event]...), d.byEvent["*"]...)
+	d.mu.RUnlock()
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	deliveries := make([]string, 0, len(ids))
+	for _, endpointID := range ids {
+		del := Delivery{
+			ID:         newID(),
+			EndpointID: endpointID,
+			Event:      event,
+			Payload:    body,
+			CreatedAt:  time.Now(),
+		}
+		deliveries = append(deliveries, del.ID)
+		d.start(ctx, del)
+	}
+	return deliveries, nil
+}
+
+// Redeliver mengirim ulang delivery yang tersimpan di Store.
+func (d *Dispatcher) Redeliver(ctx context.Context, deliveryID string) error {
+	if d.cfg.Store == nil {
+		return errors.New("webhooks: redelivery requires a Store")
+	}
+
+	del, err := d.cfg.Store.Get(ctx, deliveryID)
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	_, ok := d.endpoints[del.EndpointID]
+	d.mu.RUnlock()
+	if !ok {
+		return ErrUnknownEndpoint
+	}
+
+	// nomor Attempt dilanjutkan agar riwayat percobaan sebelumnya tetap ada
+	d.start(ctx, del)
+	return nil
+}
+
+// start menjalankan deliver di goroutine yang lepas dari pembatalan ctx
+// pemanggil tetapi berhenti saat Close.
+func (d *Dispatcher) start(ctx context.Context, del Delivery) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(d.ctx, cancel)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer cancel()
+		defer stop()
+		d.deliver(ctx, del)
+	}()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, del Delivery) {
+	backoff := d.cfg.InitialBackoff
+	for i := 1; i <= d.cfg.MaxAttempts; i++ {
+		del.Attempt++
+		del.AttemptedAt = time.Now()
+
+		del.StatusCode, del.Error = 0, ""
+		status, err := d.send(ctx, del)
+		del.StatusCode = status
+		if err != nil {
+			del.Error = err.Error()
+		}
+		del.Delivered = err == nil && status >= 200 && status < 300
+
+		if d.cfg.Store != nil {
+			// hasil percobaan tetap dicatat walaupun Close membatalkan ctx
+			if serr := d.cfg.Store.SaveAttempt(context.WithoutCancel(ctx), del); serr != nil {
+				log.Printf("webhooks: failed to persist delivery %s: %v", del.ID, serr)
+			}
+		}
+		if del.Delivered {
+			return
+		}
+
+		if i < d.cfg.MaxAttempts {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				log.Printf("webhooks: delivery %s to endpoint %s stopped after %d attempts: %v", del.ID, del.EndpointID, del.Attempt, ctx.Err())
+				return
+			case <-timer.C:
+			}
+			backoff *= 2
+			if backoff > d.cfg.MaxBackoff {
+				backoff = d.cfg.MaxBackoff
+			}
+		}
+	}
+	log.Printf("webhooks: delivery %s to endpoint %s failed after %d attempts", del.ID, del.EndpointID, del.Attempt)
+}
+
+func (d *Dispatcher) send(ctx context.Context, del Delivery) (int, error) {
+	d.mu.RLock()
+	ep, ok := d.endpoints[del.EndpointID]
+	d.mu.RUnlock()
+	if !ok {
+		return 0, ErrUnknownEndpoint
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.URL, bytes.NewReader(del.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, del.Event)
+	req.Header.Set(HeaderDelivery, del.ID)
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, Sign(ep.Secret, ts, del.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhooks: endpoint responded %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign menghasilkan signature "sha256=<hex>" atas "<timestamp>.<body>".
+// Penerima memverifikasi dengan menghitung ulang nilai yang sama.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify membandingkan signature secara constant-time dan menolak timestamp
+// yang berselisih lebih dari DefaultTolerance dengan jam lokal. Pakai Receiver
+// untuk tolerance lain dan penolakan delivery ganda.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return verify(secret, timestamp, body, signature, DefaultTolerance, time.Now()) == nil
+}
+
+func verify(secret, timestamp string, body []byte, signature string, tolerance time.Duration, now time.Time) error {
+	if !hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrStaleTimestamp
+	}
+	if skew := now.Sub(time.Unix(ts, 0)); skew > tolerance || skew < -tolerance {
+		return ErrStaleTimestamp
+	}
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
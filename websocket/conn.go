@@ -0,0 +1,317 @@
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Opcode frame WebSocket (RFC 6455).
+const (
+	continuationFrame = 0
+	TextMessage       = 1
+	BinaryMessage     = 2
+	CloseMessage      = 8
+	PingMessage       = 9
+	PongMessage       = 10
+)
+
+// CloseGoingAway adalah kode close ketika server berhenti.
+const CloseGoingAway = 1001
+
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	ErrBadHandshake   = errors.New("websocket: bad handshake")
+	ErrMessageTooBig  = errors.New("websocket: message too big")
+	ErrProtocol       = errors.New("websocket: protocol error")
+	ErrOriginRejected = errors.New("websocket: origin not allowed")
+)
+
+// CloseError dikembalikan ReadMessage ketika client mengirim frame close.
+type CloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket: close %d %s", e.Code, e.Reason)
+}
+
+type UpgradeOptions struct {
+	// CheckOrigin memvalidasi header Origin. Default: Origin harus sama dengan Host.
+	CheckOrigin func(r *http.Request) bool
+	// MaxMessageSize membatasi ukuran pesan yang dibaca. Default 1 MiB.
+	MaxMessageSize int64
+	Subprotocols   []string
+}
+
+// Conn adalah koneksi WebSocket sisi server. ReadMessage hanya boleh dipanggil
+// dari satu goroutine; WriteMessage aman dipanggil bersamaan.
+type Conn struct {
+	conn    net.Conn
+	br      *bufio.Reader
+	wmu     sync.Mutex
+	maxSize int64
+
+	Subprotocol string
+}
+
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	_, host, ok := strings.Cut(origin, "://")
+	return ok && strings.EqualFold(host, r.Host)
+}
+
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h.Values(name) {
+		for _, part := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Upgrade melakukan handshake WebSocket dan mengambil alih koneksi.
+// Jika gagal, response error sudah ditulis ke w.
+func Upgrade(w http.ResponseWriter, r *http.Request, opts UpgradeOptions) (*Conn, error) {
+	if r.Method != http.MethodGet ||
+		!headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		return nil, ErrBadHandshake
+	}
+
+	check := opts.CheckOrigin
+	if check == nil {
+		check = sameOrigin
+	}
+	if !check(r) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return nil, ErrOriginRejected
+	}
+
+	subprotocol := ""
+	for _, p := range opts.Subprotocols {
+		if headerContains(r.Header, "Sec-WebSocket-Protocol", p) {
+			subprotocol = p
+			break
+		}
+	}
+
+	netConn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n"
+	if subprotocol != "" {
+		resp += "Sec-WebSocket-Protocol: " + subprotocol + "\r\n"
+	}
+	resp += "\r\n"
+
+	netConn.SetDeadline(time.Time{})
+	if _, err := netConn.Write([]byte(resp)); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	maxSize := opts.MaxMessageSize
+	if maxSize <= 0 {
+		maxSize = 1 << 20
+	}
+	return &Conn{conn: netConn, br: rw.Reader, maxSize: maxSize, Subprotocol: subprotocol}, nil
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.conn.SetReadDeadline(t)
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	return c.conn.SetWriteDeadline(t)
+}
+
+// ReadMessage membaca satu pesan utuh (frame lanjutan digabung). Ping dijawab
+// otomatis dengan pong; frame close dijawab lalu dikembalikan sebagai *CloseError.
+func (c *Conn) ReadMessage() (int, []byte, error) {
+	var (
+		opcode  int
+		message []byte
+	)
+
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch op {
+		case PingMessage:
+			if err := c.WriteMessage(PongMessage, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case PongMessage:
+			continue
+		case CloseMessage:
+			ce := &CloseError{Code: 1005}
+			if len(payload) >= 2 {
+				ce.Code = int(binary.BigEndian.Uint16(payload))
+				ce.Reason = string(payload[2:])
+			}
+			c.WriteMessage(CloseMessage, payload[:min(len(payload), 2)])
+			return 0, nil, ce
+		case continuationFrame:
+			if opcode == 0 {
+				return 0, nil, ErrProtocol
+			}
+		case TextMessage, BinaryMessage:
+			if opcode != 0 {
+				return 0, nil, ErrProtocol
+			}
+			opcode = op
+		default:
+			return 0, nil, ErrProtocol
+		}
+
+		if int64(len(message)+len(payload)) > c.maxSize {
+			c.Close(1009, "message too big")
+			return 0, nil, ErrMessageTooBig
+		}
+		message = append(message, payload...)
+		if fin {
+			return opcode, message, nil
+		}
+	}
+}
+
+func (c *Conn) readFrame() (fin bool, opcode int, payload []byte, err error) {
+	var head [2]byte
+	if _, err = io.ReadFull(c.br, head[:]); err != nil {
+		return
+	}
+
+	fin = head[0]&0x80 != 0
+	opcode = int(head[0] & 0x0f)
+	if head[0]&0x70 != 0 || head[1]&0x80 == 0 {
+		// extension bit tidak didukung dan frame dari client wajib di-mask
+		return false, 0, nil, ErrProtocol
+	}
+
+	length := int64(head[1] & 0x7f)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err = io.ReadFull(c.br, ext[:]); err != nil {
+			return
+		}
+		length = int64(binary.BigEndian.Uint64(ext[:]))
+	}
+
+	if opcode >= CloseMessage && (length > 125 || !fin) {
+		return false, 0, nil, ErrProtocol
+	}
+	if length > c.maxSize || length < 0 {
+		c.Close(1009, "message too big")
+		return false, 0, nil, ErrMessageTooBig
+	}
+
+	var mask [4]byte
+	if _, err = io.ReadFull(c.br, mask[:]); err != nil {
+		return
+	}
+
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return fin, opcode, payload, nil
+}
+
+// WriteMessage menulis satu frame utuh.
+func (c *Conn) WriteMessage(opcode int, data []byte) error {
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|byte(opcode))
+	switch n := len(data); {
+	case n <= 125:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if _, err := c.conn.Write(header); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(data)
+	return err
+}
+
+// Drain mengirim frame close 1001 (Going Away) tanpa menutup koneksi, supaya
+// client menutup dengan normal dan reconnect ke instance lain.
+func (c *Conn) Drain() {
+	payload := binary.BigEndian.AppendUint16(nil, CloseGoingAway)
+	payload = append(payload, "server shutting down"...)
+	c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	c.WriteMessage(CloseMessage, payload)
+	c.conn.SetWriteDeadline(time.Time{})
+}
+
+// Abort menutup koneksi tanpa handshake close.
+func (c *Conn) Abort() error {
+	return c.conn.Close()
+}
+
+// Close mengirim frame close dengan kode dan alasan lalu menutup koneksi.
+func (c *Conn) Close(code int, reason string) error {
+	payload := binary.BigEndian.AppendUint16(nil, uint16(code))
+	payload = append(payload, reason...)
+	c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	c.WriteMessage(CloseMessage, payload)
+	return c.conn.Close()
+}
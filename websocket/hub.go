@@ -0,0 +1,318 @@
+package websocket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/godev90/netpath/cache"
+)
+
+type HubConfig struct {
+	// SendQueue adalah kapasitas antrian kirim per koneksi. Client yang
+	// antriannya penuh dianggap lambat dan diputus. Default 64.
+	SendQueue int
+	// WriteTimeout membatasi lama satu penulisan ke client. Default 10 detik.
+	WriteTimeout time.Duration
+	// PingInterval mengirim ping berkala untuk menjaga koneksi. Default 30 detik.
+	PingInterval time.Duration
+
+	// RedisAlias mengaktifkan fanout antar instance lewat Redis pub/sub.
+	RedisAlias string
+	Channel    string // default "ws:hub"
+}
+
+// Hub mengelola client, room, broadcast, pesan langsung, dan presence.
+type Hub struct {
+	cfg HubConfig
+	id  string
+
+	mu      sync.RWMutex
+	clients map[string]map[*Client]struct{}
+	rooms   map[string]map[*Client]struct{}
+
+	cancel context.CancelFunc
+}
+
+// Client adalah satu koneksi WebSocket yang terdaftar di Hub. Satu ID
+// (misalnya identitas user) boleh punya beberapa koneksi.
+type Client struct {
+	ID   string
+	Conn *Conn
+
+	hub   *Hub
+	send  chan outgoing
+	rooms map[string]struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+type outgoing struct {
+	opcode int
+	data   []byte
+}
+
+// envelope adalah pesan yang dikirim antar instance lewat Redis.
+type envelope struct {
+	Origin string `json:"origin"`
+	Room   string `json:"room,omitempty"`
+	To     string `json:"to,omitempty"`
+	Data   []byte `json:"data"`
+}
+
+func NewHub(cfg HubConfig) (*Hub, error) {
+	if cfg.SendQueue == 0 {
+		cfg.SendQueue = 64
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = 10 * time.Second
+	}
+	if cfg.PingInterval == 0 {
+		cfg.PingInterval = 30 * time.Second
+	}
+	if cfg.Channel == "" {
+		cfg.Channel = "ws:hub"
+	}
+
+	id := make([]byte, 8)
+	rand.Read(id)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &Hub{
+		cfg:     cfg,
+		id:      hex.EncodeToString(id),
+		clients: make(map[string]map[*Client]struct{}),
+		rooms:   make(map[string]map[*Client]struct{}),
+		cancel:  cancel,
+	}
+
+	if cfg.RedisAlias != "" {
+		client, err := cache.Pool().Get(cfg.RedisAlias)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		sub := client.Subscribe(ctx, cfg.Channel)
+		go func() {
+			defer sub.Close()
+			for msg := range sub.Channel() {
+				var env envelope
+				if err := json.Unmarshal([]byte(msg.Payload), &env); err != nil || env.Origin == h.id {
+					continue
+				}
+				if env.To != "" {
+					h.sendLocal(env.To, env.Data)
+				} else {
+					h.broadcastLocal(env.Room, env.Data)
+				}
+			}
+		}()
+	}
+
+	return h, nil
+}
+
+// Close menghentikan bridge Redis. Koneksi client tidak ditutup.
+func (h *Hub) Close() {
+	h.cancel()
+}
+
+// Register mendaftarkan koneksi dan memulai goroutine penulis untuknya.
+func (h *Hub) Register(id string, conn *Conn) *Client {
+	c := &Client{
+		ID:    id,
+		Conn:  conn,
+		hub:   h,
+		send:  make(chan outgoing, h.cfg.SendQueue),
+		rooms: make(map[string]struct{}),
+		done:  make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	if h.clients[id] == nil {
+		h.clients[id] = make(map[*Client]struct{})
+	}
+	h.clients[id][c] = struct{}{}
+	h.mu.Unlock()
+
+	go c.writeLoop()
+	return c
+}
+
+// Unregister melepas client dari semua room dan menutup koneksinya.
+func (h *Hub) Unregister(c *Client) {
+	c.once.Do(func() {
+		h.mu.Lock()
+		for room := range c.rooms {
+			delete(h.rooms[room], c)
+			if len(h.rooms[room]) == 0 {
+				delete(h.rooms, room)
+			}
+		}
+		delete(h.clients[c.ID], c)
+		if len(h.clients[c.ID]) == 0 {
+			delete(h.clients, c.ID)
+		}
+		h.mu.Unlock()
+
+		close(c.done)
+		c.Conn.Close(1000, "")
+	})
+}
+
+// Join memasukkan client ke room. Client yang sudah di-Unregister (misalnya
+// diputus karena lambat) diabaikan supaya tidak tertinggal di room.
+func (h *Hub) Join(c *Client, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c.ID][c]; !ok {
+		return
+	}
+	if h.rooms[room] == nil {
+		h.rooms[room] = make(map[*Client]struct{})
+	}
+	h.rooms[room][c] = struct{}{}
+	c.rooms[room] = struct{}{}
+}
+
+func (h *Hub) Leave(c *Client, room string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.rooms[room], c)
+	if len(h.rooms[room]) == 0 {
+		delete(h.rooms, room)
+	}
+	delete(c.rooms, room)
+}
+
+// Presence mengembalikan ID unik client di room pada instance ini.
+func (h *Hub) Presence(room string) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[string]struct{})
+	var ids []string
+	for c := range h.rooms[room] {
+		if _, ok := seen[c.ID]; !ok {
+			seen[c.ID] = struct{}{}
+			ids = append(ids, c.ID)
+		}
+	}
+	return ids
+}
+
+// Broadcast mengirim pesan teks ke semua client di room, termasuk di instance
+// lain jika bridge Redis aktif.
+func (h *Hub) Broadcast(room string, data []byte) {
+	h.broadcastLocal(room, data)
+	h.publish(envelope{Room: room, Data: data})
+}
+
+// Send mengirim pesan teks langsung ke semua koneksi milik ID.
+func (h *Hub) Send(id string, data []byte) {
+	h.sendLocal(id, data)
+	h.publish(envelope{To: id, Data: data})
+}
+
+func (h *Hub) broadcastLocal(room string, data []byte) {
+	h.mu.RLock()
+	targets := make([]*Client, 0, len(h.rooms[room]))
+	for c := range h.rooms[room] {
+		targets = append(targets, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		c.enqueue(outgoing{opcode: TextMessage, data: data})
+	}
+}
+
+func (h *Hub) sendLocal(id string, data []byte) {
+	h.mu.RLock()
+	targets := make([]*Client, 0, len(h.clients[id]))
+	for c := range h.clients[id] {
+		targets = append(targets, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		c.enqueue(outgoing{opcode: TextMessage, data: data})
+	}
+}
+
+func (h *Hub) publish(env envelope) {
+	if h.cfg.RedisAlias == "" {
+		return
+	}
+
+	client, err := cache.Pool().Get(h.cfg.RedisAlias)
+	if err != nil {
+		return
+	}
+	env.Origin = h.id
+	raw, _ := json.Marshal(env)
+	if err := client.Publish(context.Background(), h.cfg.Channel, raw).Err(); err != nil {
+		log.Printf("[websocket] failed to publish to %s: %v", h.cfg.Channel, err)
+	}
+}
+
+// enqueue tidak pernah memblokir: client yang antriannya penuh diputus.
+func (c *Client) enqueue(msg outgoing) {
+	select {
+	case <-c.done:
+	case c.send <- msg:
+	default:
+		log.Printf("[websocket] evicting slow client %s", c.ID)
+		go c.hub.Unregister(c)
+	}
+}
+
+// Send mengirim pesan teks ke koneksi ini saja.
+func (c *Client) Send(data []byte) {
+	c.enqueue(outgoing{opcode: TextMessage, data: data})
+}
+
+func (c *Client) writeLoop() {
+	ping := time.NewTicker(c.hub.cfg.PingInterval)
+	defer ping.Stop()
+
+	for {
+		var msg outgoing
+		select {
+		case <-c.done:
+			return
+		case msg = <-c.send:
+		case <-ping.C:
+			msg = outgoing{opcode: PingMessage}
+		}
+
+		c.Conn.SetWriteDeadline(time.Now().Add(c.hub.cfg.WriteTimeout))
+		if err := c.Conn.WriteMessage(msg.opcode, msg.data); err != nil {
+			c.hub.Unregister(c)
+			return
+		}
+	}
+}
+
+// Listen membaca pesan sampai koneksi putus, memanggil onMessage untuk
+// setiap pesan, lalu melepas client dari Hub.
+func (c *Client) Listen(onMessage func(c *Client, opcode int, data []byte)) error {
+	defer c.hub.Unregister(c)
+
+	for {
+		opcode, data, err := c.Conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if onMessage != nil {
+			onMessage(c, opcode, data)
+		}
+	}
+}
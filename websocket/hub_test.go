@@ -0,0 +1,51 @@
+package websocket
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"slices"
+	"testing"
+)
+
+func pipeConn(t *testing.T) *Conn {
+	t.Helper()
+	server, client := net.Pipe()
+	go io.Copy(io.Discard, client)
+	t.Cleanup(func() { client.Close() })
+	return &Conn{conn: server, br: bufio.NewReader(server), maxSize: 1 << 20}
+}
+
+func TestHubJoinAfterUnregister(t *testing.T) {
+	h, err := NewHub(HubConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	stay := h.Register("alice", pipeConn(t))
+	gone := h.Register("bob", pipeConn(t))
+	h.Join(stay, "lobby")
+	h.Join(gone, "lobby")
+	h.Unregister(gone)
+
+	// Join yang datang terlambat, misalnya dari handler pesan yang masih
+	// berjalan saat client diputus
+	h.Join(gone, "lobby")
+	h.Join(gone, "vip")
+
+	if got := h.Presence("lobby"); !slices.Equal(got, []string{"alice"}) {
+		t.Fatalf("lobby presence %v, want [alice]", got)
+	}
+	if got := h.Presence("vip"); len(got) != 0 {
+		t.Fatalf("vip presence %v, want empty", got)
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if _, ok := h.rooms["vip"]; ok {
+		t.Fatal("unregistered client left an empty room behind")
+	}
+	if _, ok := h.clients["bob"]; ok {
+		t.Fatal("unregistered client still listed")
+	}
+}